@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// CreateAPIKey calls POST /api-keys. API keys can only be managed with a
+// user session, not with another API key.
+func (c *Client) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*APIKey, error) {
+	var key APIKey
+	if err := c.do(ctx, http.MethodPost, "/api-keys", nil, req, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListAPIKeys calls GET /api-keys. An empty workspace lists the caller's own
+// keys.
+func (c *Client) ListAPIKeys(ctx context.Context, workspace string) ([]APIKey, error) {
+	query := url.Values{}
+	if workspace != "" {
+		query.Set("workspace", workspace)
+	}
+	var keys []APIKey
+	if err := c.do(ctx, http.MethodGet, "/api-keys", query, nil, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RotateAPIKey calls POST /api-keys/{id}/rotate. The old secret stops
+// working immediately.
+func (c *Client) RotateAPIKey(ctx context.Context, id uuid.UUID) (*APIKey, error) {
+	var key APIKey
+	if err := c.do(ctx, http.MethodPost, "/api-keys/"+id.String()+"/rotate", nil, nil, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeAPIKey calls DELETE /api-keys/{id}.
+func (c *Client) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, "/api-keys/"+id.String(), nil, nil, nil)
+}
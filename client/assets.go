@@ -0,0 +1,53 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// UploadAsset calls POST /components/{slug}/assets with a single file. The
+// server detects the content type from the data itself.
+func (c *Client) UploadAsset(ctx context.Context, slug, fileName string, data []byte) (*Asset, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, c.url(componentPath(slug)+"/assets", nil), body.Bytes(), mw.FormDataContentType())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var assets []Asset
+	if err := decodeEnvelope(resp, &assets); err != nil {
+		return nil, err
+	}
+	return &assets[0], nil
+}
+
+// ListAssets calls GET /components/{slug}/assets.
+func (c *Client) ListAssets(ctx context.Context, slug string) ([]Asset, error) {
+	var assets []Asset
+	if err := c.do(ctx, http.MethodGet, componentPath(slug)+"/assets", nil, nil, &assets); err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
+
+// DeleteAsset calls DELETE /components/{slug}/assets/{id}.
+func (c *Client) DeleteAsset(ctx context.Context, slug string, id uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, componentPath(slug)+"/assets/"+id.String(), nil, nil, nil)
+}
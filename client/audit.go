@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListAuditEvents calls GET /audit. Only admins can read the audit log.
+func (c *Client) ListAuditEvents(ctx context.Context, params ListAuditParams) ([]AuditEvent, error) {
+	var events []AuditEvent
+	if err := c.do(ctx, http.MethodGet, "/audit", params.values(), nil, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (p ListAuditParams) values() url.Values {
+	v := url.Values{}
+	if p.EntityType != "" {
+		v.Set("entity_type", p.EntityType)
+	}
+	if p.EntityID != uuid.Nil {
+		v.Set("entity_id", p.EntityID.String())
+	}
+	if p.Entity != "" {
+		v.Set("entity", p.Entity)
+	}
+	if p.ActorID != uuid.Nil {
+		v.Set("actor_id", p.ActorID.String())
+	}
+	if p.Action != "" {
+		v.Set("action", p.Action)
+	}
+	if !p.From.IsZero() {
+		v.Set("from", p.From.Format(time.RFC3339))
+	}
+	if !p.To.IsZero() {
+		v.Set("to", p.To.Format(time.RFC3339))
+	}
+	if p.Page > 0 {
+		v.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.Limit > 0 {
+		v.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return v
+}
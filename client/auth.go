@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Register calls POST /auth/register and returns the new user with a fresh
+// session.
+func (c *Client) Register(ctx context.Context, req RegisterRequest) (*AuthResult, error) {
+	var result AuthResult
+	if err := c.do(ctx, http.MethodPost, "/auth/register", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Login calls POST /auth/login. A wrong email or password returns an
+// *APIError with status 401.
+func (c *Client) Login(ctx context.Context, email, password string) (*AuthResult, error) {
+	var result AuthResult
+	body := map[string]string{"email": email, "password": password}
+	if err := c.do(ctx, http.MethodPost, "/auth/login", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Refresh calls POST /auth/refresh. The old refresh token stops working once
+// the new pair is returned.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	var tokens Tokens
+	body := map[string]string{"refresh_token": refreshToken}
+	if err := c.do(ctx, http.MethodPost, "/auth/refresh", nil, body, &tokens); err != nil {
+		return nil, err
+	}
+	return &tokens, nil
+}
+
+// Logout calls POST /auth/logout, revoking the session of the client's token
+// or, with all set, every session of the user.
+func (c *Client) Logout(ctx context.Context, all bool) error {
+	return c.do(ctx, http.MethodPost, "/auth/logout", nil, map[string]bool{"all": all}, nil)
+}
+
+// ForgotPassword calls POST /auth/password/forgot. It succeeds whether or not
+// the email is registered.
+func (c *Client) ForgotPassword(ctx context.Context, email string) error {
+	return c.do(ctx, http.MethodPost, "/auth/password/forgot", nil, map[string]string{"email": email}, nil)
+}
+
+// ResetPassword calls POST /auth/password/reset with a token from
+// ForgotPassword. All sessions of the user are revoked.
+func (c *Client) ResetPassword(ctx context.Context, token, password string) error {
+	body := map[string]string{"token": token, "password": password}
+	return c.do(ctx, http.MethodPost, "/auth/password/reset", nil, body, nil)
+}
+
+// Me calls GET /me and returns the caller's profile and components.
+func (c *Client) Me(ctx context.Context) (*Me, error) {
+	var me Me
+	if err := c.do(ctx, http.MethodGet, "/me", nil, nil, &me); err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateCategory calls POST /categories.
+func (c *Client) CreateCategory(ctx context.Context, name string) (*Category, error) {
+	var category Category
+	body := map[string]string{"name": name}
+	if err := c.do(ctx, http.MethodPost, "/categories", nil, body, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// ListCategories calls GET /categories.
+func (c *Client) ListCategories(ctx context.Context) ([]Category, error) {
+	var categories []Category
+	if err := c.do(ctx, http.MethodGet, "/categories", nil, nil, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// CreateTag calls POST /tags.
+func (c *Client) CreateTag(ctx context.Context, name string) (*Tag, error) {
+	var tag Tag
+	body := map[string]string{"name": name}
+	if err := c.do(ctx, http.MethodPost, "/tags", nil, body, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// ListTags calls GET /tags.
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	if err := c.do(ctx, http.MethodGet, "/tags", nil, nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
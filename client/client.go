@@ -0,0 +1,205 @@
+// Package client is a typed Go client for the ComponentHub /api/v1 API.
+//
+//	c := client.New("http://localhost:8080/api/v1")
+//	component, err := c.GetComponent(ctx, "button")
+//	if errors.Is(err, client.ErrNotFound) {
+//		...
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client talks to a ComponentHub service. The zero value is not usable; create
+// one with New.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	header     http.Header
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the default http.Client.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithRetry sets how many times a request is retried, and the initial backoff
+// which doubles on every attempt. GET, HEAD, PUT and DELETE requests are
+// retried after a 5xx response or a transport error; a 429 response is
+// retried for every method because the server did not handle the request.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithHeader adds a header to every request, e.g. Authorization.
+func WithHeader(key, value string) Option {
+	return func(c *Client) { c.header.Set(key, value) }
+}
+
+// WithToken authenticates every request with an access token from Login or
+// Register, or with an API key.
+func WithToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// New creates a client for the API rooted at baseURL, for example
+// "http://localhost:8080/api/v1".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		maxRetries: 3,
+		backoff:    200 * time.Millisecond,
+		header:     http.Header{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// envelope is the {success,data,error} wrapper written by utils.Success,
+// utils.Created and utils.Error.
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   *string         `json:"error"`
+}
+
+// do sends the request and decodes the enveloped data into out. A nil out
+// discards the data.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var payload []byte
+	var contentType string
+	if in != nil {
+		var err error
+		if payload, err = json.Marshal(in); err != nil {
+			return err
+		}
+		contentType = "application/json"
+	}
+
+	resp, err := c.send(ctx, method, c.url(path, query), payload, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeEnvelope(resp, out)
+}
+
+func (c *Client) url(path string, query url.Values) string {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// decodeEnvelope unwraps the response envelope into out, turning error
+// responses into *APIError.
+func decodeEnvelope(resp *http.Response, out interface{}) error {
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		if resp.StatusCode >= 400 {
+			return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body)), RequestID: resp.Header.Get("X-Request-ID")}
+		}
+		return fmt.Errorf("componenthub: decode %s %s: %w", resp.Request.Method, resp.Request.URL.Path, err)
+	}
+
+	if resp.StatusCode >= 400 || !env.Success {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode), RequestID: resp.Header.Get("X-Request-ID")}
+		if env.Error != nil {
+			apiErr.Message = *env.Error
+		}
+		return apiErr
+	}
+
+	if out == nil || len(env.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(env.Data, out)
+}
+
+// send performs the HTTP round trip, retrying with exponential backoff as
+// described on WithRetry. A 429 waits at least as long as its Retry-After
+// header.
+func (c *Client) send(ctx context.Context, method, u string, payload []byte, contentType string) (*http.Response, error) {
+	wait := c.backoff
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range c.header {
+			req.Header[key] = values
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if !retryable(method, resp, err) || attempt >= c.maxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+		delay := wait
+		if resp != nil {
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && time.Duration(seconds)*time.Second > delay {
+				delay = time.Duration(seconds) * time.Second
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		wait *= 2
+	}
+}
+
+// retryable reports whether a request may be sent again. A 429 was rejected
+// before it was handled, so any method is safe to repeat; a 5xx or transport
+// error may come after the change was made, so only idempotent methods are
+// repeated.
+func retryable(method string, resp *http.Response, err error) bool {
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if err == nil && resp.StatusCode < 500 {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
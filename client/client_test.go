@@ -0,0 +1,365 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"service_components/client"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEnvelopeDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/components/button":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"success":true,"data":{"slug":"button","name":"Button","version":"1.2.0"},"error":null}`)
+		case "DELETE /api/v1/components/button":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL+"/api/v1/", client.WithToken("secret"))
+	component, err := c.GetComponent(context.Background(), "button")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if component.Slug != "button" || component.Name != "Button" || component.Version != "1.2.0" {
+		t.Errorf("decoded %+v", component)
+	}
+
+	if err := c.DeleteComponent(context.Background(), "button"); err != nil {
+		t.Errorf("DeleteComponent: %v", err)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	tests := []struct {
+		status   int
+		body     string
+		sentinel error
+		message  string
+	}{
+		{http.StatusBadRequest, `{"success":false,"data":null,"error":"name is required"}`, client.ErrBadRequest, "name is required"},
+		{http.StatusUnauthorized, `{"success":false,"data":null,"error":"Authentication required"}`, client.ErrUnauthorized, "Authentication required"},
+		{http.StatusForbidden, `{"success":false,"data":null,"error":"Insufficient scope"}`, client.ErrForbidden, "Insufficient scope"},
+		{http.StatusNotFound, `{"success":false,"data":null,"error":"Component not found"}`, client.ErrNotFound, "Component not found"},
+		{http.StatusConflict, `{"success":false,"data":null,"error":"Slug already exists"}`, client.ErrConflict, "Slug already exists"},
+		{http.StatusTooManyRequests, `{"success":false,"data":null,"error":"Rate limit exceeded"}`, client.ErrRateLimited, "Rate limit exceeded"},
+		{http.StatusInternalServerError, `{"success":false,"data":null,"error":"Failed to fetch component"}`, client.ErrServer, "Failed to fetch component"},
+		{http.StatusBadGateway, "upstream unavailable\n", client.ErrServer, "upstream unavailable"},
+		{http.StatusNotFound, `{"success":false,"data":null,"error":null}`, client.ErrNotFound, "Not Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-ID", "req-1")
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			c := client.New(server.URL, client.WithRetry(0, 0))
+			_, err := c.GetComponent(context.Background(), "button")
+			if !errors.Is(err, tt.sentinel) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tt.sentinel)
+			}
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("%T is not *client.APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Message != tt.message || apiErr.RequestID != "req-1" {
+				t.Errorf("got %+v", apiErr)
+			}
+			if tt.sentinel != client.ErrNotFound && errors.Is(err, client.ErrNotFound) {
+				t.Errorf("%d also matches ErrNotFound", tt.status)
+			}
+		})
+	}
+}
+
+func TestSuccessFalseIsAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"success":false,"data":null,"error":"Something went wrong"}`)
+	}))
+	defer server.Close()
+
+	_, err := client.New(server.URL).GetComponent(context.Background(), "button")
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "Something went wrong" {
+		t.Errorf("got %v", err)
+	}
+}
+
+// flakyServer answers with statuses in order, then 200, and records the time
+// and body of every attempt.
+type flakyServer struct {
+	mu       sync.Mutex
+	statuses []int
+	header   http.Header
+	attempts []time.Time
+	bodies   []string
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	attempt := len(s.attempts)
+	s.attempts = append(s.attempts, time.Now())
+	s.bodies = append(s.bodies, string(body))
+	s.mu.Unlock()
+
+	if attempt < len(s.statuses) {
+		for key, values := range s.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(s.statuses[attempt])
+		io.WriteString(w, `{"success":false,"data":null,"error":"try again"}`)
+		return
+	}
+	io.WriteString(w, `{"success":true,"data":{"id":"00000000-0000-0000-0000-000000000001","name":"ci"},"error":null}`)
+}
+
+func TestRetryOnServerErrors(t *testing.T) {
+	flaky := &flakyServer{statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError}}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	backoff := 20 * time.Millisecond
+	c := client.New(server.URL, client.WithRetry(3, backoff))
+	if _, err := c.DeprecateComponent(context.Background(), "button", client.DeprecateRequest{Message: "Use Button v2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(flaky.attempts) != 3 {
+		t.Fatalf("%d attempts, want 3", len(flaky.attempts))
+	}
+	// The backoff doubles: 20ms, then 40ms.
+	for i, want := range []time.Duration{backoff, 2 * backoff} {
+		if gap := flaky.attempts[i+1].Sub(flaky.attempts[i]); gap < want {
+			t.Errorf("wait before attempt %d was %v, want at least %v", i+2, gap, want)
+		}
+	}
+	// The request body is sent again in full on every attempt.
+	for i, body := range flaky.bodies {
+		if body != flaky.bodies[0] || body == "" {
+			t.Errorf("attempt %d sent body %q, first sent %q", i+1, body, flaky.bodies[0])
+		}
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	flaky := &flakyServer{statuses: []int{500, 500, 500, 500}}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	_, err := client.New(server.URL, client.WithRetry(2, time.Millisecond)).GetComponent(context.Background(), "button")
+	if !errors.Is(err, client.ErrServer) {
+		t.Errorf("got %v, want ErrServer", err)
+	}
+	if len(flaky.attempts) != 3 {
+		t.Errorf("%d attempts, want 3", len(flaky.attempts))
+	}
+}
+
+func TestNoRetryOnClientErrors(t *testing.T) {
+	flaky := &flakyServer{statuses: []int{http.StatusNotFound}}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	_, err := client.New(server.URL, client.WithRetry(3, time.Millisecond)).GetComponent(context.Background(), "button")
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("got %v, want ErrNotFound", err)
+	}
+	if len(flaky.attempts) != 1 {
+		t.Errorf("%d attempts, want 1", len(flaky.attempts))
+	}
+}
+
+// A POST may have created something before the server failed, so it is not
+// sent again.
+func TestNoRetryForPostOnServerErrors(t *testing.T) {
+	flaky := &flakyServer{statuses: []int{http.StatusServiceUnavailable}}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	_, err := client.New(server.URL, client.WithRetry(3, time.Millisecond)).CreateTag(context.Background(), "primary")
+	if !errors.Is(err, client.ErrServer) {
+		t.Errorf("got %v, want ErrServer", err)
+	}
+	if len(flaky.attempts) != 1 {
+		t.Errorf("%d attempts, want 1", len(flaky.attempts))
+	}
+}
+
+func TestNoRetryForPostOnTransportErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, client.WithRetry(2, time.Millisecond))
+	if _, err := c.CreateTag(context.Background(), "primary"); err == nil {
+		t.Fatal("CreateTag succeeded on a closed connection")
+	}
+	if got := attempts.Swap(0); got != 1 {
+		t.Errorf("POST: %d attempts, want 1", got)
+	}
+
+	if _, err := c.GetComponent(context.Background(), "button"); err == nil {
+		t.Fatal("GetComponent succeeded on a closed connection")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("GET: %d attempts, want 3", got)
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	flaky := &flakyServer{
+		statuses: []int{http.StatusTooManyRequests},
+		header:   http.Header{"Retry-After": {"1"}},
+	}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	c := client.New(server.URL, client.WithRetry(1, time.Millisecond))
+	if _, err := c.CreateTag(context.Background(), "primary"); err != nil {
+		t.Fatal(err)
+	}
+	if len(flaky.attempts) != 2 {
+		t.Fatalf("%d attempts, want 2", len(flaky.attempts))
+	}
+	if gap := flaky.attempts[1].Sub(flaky.attempts[0]); gap < time.Second {
+		t.Errorf("retried after %v, want at least the 1s Retry-After", gap)
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	flaky := &flakyServer{statuses: []int{500, 500}}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.New(server.URL, client.WithRetry(3, time.Hour)).GetComponent(ctx, "button")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// pagedServer serves GET /components from total components by page and limit,
+// like the real server.
+func pagedServer(t *testing.T, total int, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if page < 1 || limit < 1 {
+			t.Errorf("page=%d limit=%d", page, limit)
+		}
+
+		fmt.Fprint(w, `{"success":true,"error":null,"data":[`)
+		for i, n := (page-1)*limit, 0; i < total && n < limit; i, n = i+1, n+1 {
+			if n > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"slug":"component-%d"}`, i)
+		}
+		fmt.Fprint(w, `]}`)
+	}))
+}
+
+func TestAllComponents(t *testing.T) {
+	var requests []string
+	server := pagedServer(t, 5, &requests)
+	defer server.Close()
+
+	c := client.New(server.URL)
+	var slugs []string
+	for component, err := range c.AllComponents(context.Background(), client.ListComponentsParams{Query: "button", Limit: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		slugs = append(slugs, component.Slug)
+	}
+
+	if fmt.Sprint(slugs) != "[component-0 component-1 component-2 component-3 component-4]" {
+		t.Errorf("slugs = %v", slugs)
+	}
+	want := []string{"limit=2&page=1&q=button", "limit=2&page=2&q=button", "limit=2&page=3&q=button"}
+	if fmt.Sprint(requests) != fmt.Sprint(want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestAllComponentsStopsEarly(t *testing.T) {
+	var requests []string
+	server := pagedServer(t, 10, &requests)
+	defer server.Close()
+
+	c := client.New(server.URL)
+	n := 0
+	for _, err := range c.AllComponents(context.Background(), client.ListComponentsParams{Limit: 3}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 4 {
+			break
+		}
+	}
+	if len(requests) != 2 {
+		t.Errorf("%d pages fetched after breaking on the 4th item, want 2", len(requests))
+	}
+}
+
+func TestAllComponentsCapsLimit(t *testing.T) {
+	var requests []string
+	server := pagedServer(t, 0, &requests)
+	defer server.Close()
+
+	for _, err := range client.New(server.URL).AllComponents(context.Background(), client.ListComponentsParams{Limit: 500}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(requests) != 1 || requests[0] != "limit=100&page=1" {
+		t.Errorf("requests = %v", requests)
+	}
+}
+
+func TestAllComponentsYieldsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"success":false,"data":null,"error":"Insufficient scope"}`)
+	}))
+	defer server.Close()
+
+	var errs []error
+	for component, err := range client.New(server.URL).AllComponents(context.Background(), client.ListComponentsParams{}) {
+		if component.Slug != "" {
+			t.Errorf("yielded %q with an error", component.Slug)
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], client.ErrForbidden) {
+		t.Errorf("errors = %v", errs)
+	}
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func componentPath(slug string) string {
+	return "/components/" + url.PathEscape(slug)
+}
+
+// CreateComponent calls POST /components.
+func (c *Client) CreateComponent(ctx context.Context, req CreateComponentRequest) (*Component, error) {
+	var component Component
+	if err := c.do(ctx, http.MethodPost, "/components", nil, req, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// ListComponents calls GET /components and returns a single page.
+func (c *Client) ListComponents(ctx context.Context, params ListComponentsParams) ([]Component, error) {
+	var components []Component
+	if err := c.do(ctx, http.MethodGet, "/components", params.values(), nil, &components); err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+// AllComponents iterates over every component matching params, fetching the
+// next page once the current one is exhausted. Iteration stops at the first
+// error, which is yielded with a zero Component.
+func (c *Client) AllComponents(ctx context.Context, params ListComponentsParams) iter.Seq2[Component, error] {
+	return func(yield func(Component, error) bool) {
+		if params.Page < 1 {
+			params.Page = 1
+		}
+		if params.Limit < 1 {
+			params.Limit = 20
+		}
+		// The server caps limit at 100; a larger limit would end the
+		// iteration after the first short page.
+		if params.Limit > 100 {
+			params.Limit = 100
+		}
+
+		for {
+			page, err := c.ListComponents(ctx, params)
+			if err != nil {
+				yield(Component{}, err)
+				return
+			}
+			for _, component := range page {
+				if !yield(component, nil) {
+					return
+				}
+			}
+			if len(page) < params.Limit {
+				return
+			}
+			params.Page++
+		}
+	}
+}
+
+// GetComponent calls GET /components/{slug}.
+func (c *Client) GetComponent(ctx context.Context, slug string) (*Component, error) {
+	var component Component
+	if err := c.do(ctx, http.MethodGet, componentPath(slug), nil, nil, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// UpdateComponent calls PATCH /components/{slug}. Renaming a component also
+// changes its slug; use the slug of the returned component afterwards.
+func (c *Client) UpdateComponent(ctx context.Context, slug string, req UpdateComponentRequest) (*Component, error) {
+	var component Component
+	if err := c.do(ctx, http.MethodPatch, componentPath(slug), nil, req, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// DeleteComponent calls DELETE /components/{slug}.
+func (c *Client) DeleteComponent(ctx context.Context, slug string) error {
+	return c.do(ctx, http.MethodDelete, componentPath(slug), nil, nil, nil)
+}
+
+// AddComponentTag calls POST /components/{slug}/tags.
+func (c *Client) AddComponentTag(ctx context.Context, slug string, tagID uuid.UUID) (*Component, error) {
+	var component Component
+	body := map[string]uuid.UUID{"tag_id": tagID}
+	if err := c.do(ctx, http.MethodPost, componentPath(slug)+"/tags", nil, body, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// UpdateComponentStatus calls PATCH /components/{slug}/status.
+func (c *Client) UpdateComponentStatus(ctx context.Context, slug, status string) (*Component, error) {
+	var component Component
+	body := map[string]string{"status": status}
+	if err := c.do(ctx, http.MethodPatch, componentPath(slug)+"/status", nil, body, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// UpdateComponentApproval calls PATCH /components/{slug}/approval.
+func (c *Client) UpdateComponentApproval(ctx context.Context, slug string, req UpdateComponentApprovalRequest) (*Component, error) {
+	var component Component
+	if err := c.do(ctx, http.MethodPatch, componentPath(slug)+"/approval", nil, req, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// ForkComponent calls POST /components/{slug}/fork. The fork starts as a
+// draft with the code, props, tags and category of the source.
+func (c *Client) ForkComponent(ctx context.Context, slug string, req ForkRequest) (*Component, error) {
+	var component Component
+	if err := c.do(ctx, http.MethodPost, componentPath(slug)+"/fork", nil, req, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// ListForks calls GET /components/{slug}/forks.
+func (c *Client) ListForks(ctx context.Context, slug string) ([]Component, error) {
+	var forks []Component
+	if err := c.do(ctx, http.MethodGet, componentPath(slug)+"/forks", nil, nil, &forks); err != nil {
+		return nil, err
+	}
+	return forks, nil
+}
+
+// DeprecateComponent calls PUT /components/{slug}/deprecation.
+func (c *Client) DeprecateComponent(ctx context.Context, slug string, req DeprecateRequest) (*Component, error) {
+	var component Component
+	if err := c.do(ctx, http.MethodPut, componentPath(slug)+"/deprecation", nil, req, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// UndeprecateComponent calls DELETE /components/{slug}/deprecation.
+func (c *Client) UndeprecateComponent(ctx context.Context, slug string) (*Component, error) {
+	var component Component
+	if err := c.do(ctx, http.MethodDelete, componentPath(slug)+"/deprecation", nil, nil, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// ListVariants calls GET /components/{slug}/variants.
+func (c *Client) ListVariants(ctx context.Context, slug string) ([]Variant, error) {
+	var variants []Variant
+	if err := c.do(ctx, http.MethodGet, componentPath(slug)+"/variants", nil, nil, &variants); err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// PutVariant calls PUT /components/{slug}/variants/{framework}, creating or
+// replacing the implementation for that framework.
+func (c *Client) PutVariant(ctx context.Context, slug, framework, code, styles string) (*Variant, error) {
+	var variant Variant
+	body := map[string]string{"code": code, "styles": styles}
+	path := componentPath(slug) + "/variants/" + url.PathEscape(framework)
+	if err := c.do(ctx, http.MethodPut, path, nil, body, &variant); err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// DeleteVariant calls DELETE /components/{slug}/variants/{framework}.
+func (c *Client) DeleteVariant(ctx context.Context, slug, framework string) error {
+	return c.do(ctx, http.MethodDelete, componentPath(slug)+"/variants/"+url.PathEscape(framework), nil, nil, nil)
+}
+
+func (p ListComponentsParams) values() url.Values {
+	v := url.Values{}
+	if len(p.Tags) > 0 {
+		v.Set("tag", strings.Join(p.Tags, ","))
+	}
+	if p.Category != "" {
+		v.Set("category", p.Category)
+	}
+	if p.Status != "" {
+		v.Set("status", p.Status)
+	}
+	if p.Approval != "" {
+		v.Set("approval", p.Approval)
+	}
+	if p.Framework != "" {
+		v.Set("framework", p.Framework)
+	}
+	if p.Query != "" {
+		v.Set("q", p.Query)
+	}
+	if p.Deprecated != "" {
+		v.Set("deprecated", p.Deprecated)
+	}
+	if p.Page > 0 {
+		v.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.Limit > 0 {
+		v.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by APIError through errors.Is.
+var (
+	ErrBadRequest   = errors.New("componenthub: bad request")
+	ErrUnauthorized = errors.New("componenthub: unauthorized")
+	ErrForbidden    = errors.New("componenthub: forbidden")
+	ErrNotFound     = errors.New("componenthub: not found")
+	ErrConflict     = errors.New("componenthub: conflict")
+	ErrRateLimited  = errors.New("componenthub: rate limited")
+	ErrServer       = errors.New("componenthub: server error")
+)
+
+// APIError is returned for every non-2xx response. Message is the "error"
+// field of the response envelope. RequestID is the X-Request-ID response
+// header, which identifies the request in the server logs.
+type APIError struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("componenthub: %d %s (request %s)", e.StatusCode, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("componenthub: %d %s", e.StatusCode, e.Message)
+}
+
+// Is maps the status code to one of the sentinel errors, so callers can write
+// errors.Is(err, client.ErrNotFound).
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return target == ErrBadRequest
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusConflict:
+		return target == ErrConflict
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+	return e.StatusCode >= 500 && target == ErrServer
+}
+
+// GraphQLError is returned by GraphQL when the response has errors. Data that
+// did resolve is still decoded.
+type GraphQLError struct {
+	Errors []GraphQLErrorMessage
+}
+
+// GraphQLErrorMessage is one entry of the GraphQL "errors" array. Errors from
+// mutations carry the REST status in Extensions["status"] and a code such as
+// "NOT_FOUND" in Extensions["code"].
+type GraphQLErrorMessage struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *GraphQLError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Message
+	}
+	return "componenthub: graphql: " + strings.Join(messages, "; ")
+}
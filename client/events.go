@@ -0,0 +1,97 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxEventSize bounds one SSE line; events carry a full component snapshot.
+const maxEventSize = 4 << 20
+
+// StreamEvents calls GET /events/stream and passes every catalog event to fn
+// until ctx is done, the server closes the stream or fn returns an error,
+// which is returned as is. Heartbeats are skipped. A nil error means the
+// server ended the stream; call StreamEvents again with the last Sequence
+// seen as params.LastEventID to continue. The stream ignores the client's
+// http.Client timeout.
+func (c *Client) StreamEvents(ctx context.Context, params StreamEventsParams, fn func(CatalogEvent) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url("/events/stream", params.values()), nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if params.LastEventID > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatInt(params.LastEventID, 10))
+	}
+
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return decodeEnvelope(resp, nil)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxEventSize)
+	var name string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if err := dispatchEvent(name, data, fn); err != nil {
+				return err
+			}
+			name, data = "", nil
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+func dispatchEvent(name string, data []string, fn func(CatalogEvent) error) error {
+	switch name {
+	case "", "ping":
+		return nil
+	case EventStreamReset:
+		return fn(CatalogEvent{Type: EventStreamReset})
+	}
+	var event CatalogEvent
+	if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &event); err != nil {
+		return err
+	}
+	return fn(event)
+}
+
+func (p StreamEventsParams) values() url.Values {
+	v := url.Values{}
+	if len(p.Types) > 0 {
+		v.Set("type", strings.Join(p.Types, ","))
+	}
+	if len(p.Categories) > 0 {
+		v.Set("category", strings.Join(p.Categories, ","))
+	}
+	return v
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+func filePath(slug string, id uuid.UUID) string {
+	return componentPath(slug) + "/files/" + id.String()
+}
+
+// ListFiles calls GET /components/{slug}/files. An empty framework returns the
+// files of every framework.
+func (c *Client) ListFiles(ctx context.Context, slug, framework string) ([]File, error) {
+	query := url.Values{}
+	if framework != "" {
+		query.Set("framework", framework)
+	}
+
+	var files []File
+	if err := c.do(ctx, http.MethodGet, componentPath(slug)+"/files", query, nil, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// AddFile calls POST /components/{slug}/files.
+func (c *Client) AddFile(ctx context.Context, slug string, req FileRequest) (*File, error) {
+	var file File
+	if err := c.do(ctx, http.MethodPost, componentPath(slug)+"/files", nil, req, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// UpdateFile calls PATCH /components/{slug}/files/{id}.
+func (c *Client) UpdateFile(ctx context.Context, slug string, id uuid.UUID, req UpdateFileRequest) (*File, error) {
+	var file File
+	if err := c.do(ctx, http.MethodPatch, filePath(slug, id), nil, req, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DeleteFile calls DELETE /components/{slug}/files/{id}.
+func (c *Client) DeleteFile(ctx context.Context, slug string, id uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, filePath(slug, id), nil, nil, nil)
+}
+
+// Download calls GET /components/{slug}/download and returns the zip archive.
+func (c *Client) Download(ctx context.Context, slug, framework string) ([]byte, error) {
+	query := url.Values{}
+	if framework != "" {
+		query.Set("framework", framework)
+	}
+
+	resp, err := c.send(ctx, http.MethodGet, c.url(componentPath(slug)+"/download", query), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, decodeEnvelope(resp, nil)
+	}
+	return io.ReadAll(resp.Body)
+}
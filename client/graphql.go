@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GraphQL calls POST /graphql and decodes the "data" field into out. When the
+// response has errors they are returned as *GraphQLError, after decoding any
+// partial data into out. Failures before the query runs, such as a missing
+// scope or rate limiting, are returned as *APIError.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	resp, err := c.send(ctx, http.MethodPost, c.url("/graphql", nil), payload, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Data   json.RawMessage       `json:"data"`
+		Errors []GraphQLErrorMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || (resp.StatusCode >= 400 && len(result.Errors) == 0) {
+		var env envelope
+		if json.Unmarshal(body, &env) == nil && env.Error != nil {
+			return &APIError{StatusCode: resp.StatusCode, Message: *env.Error, RequestID: resp.Header.Get("X-Request-ID")}
+		}
+		if resp.StatusCode >= 400 {
+			return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body)), RequestID: resp.Header.Get("X-Request-ID")}
+		}
+		return fmt.Errorf("componenthub: decode POST /graphql: %w", err)
+	}
+
+	if out != nil && len(result.Data) > 0 && string(result.Data) != "null" {
+		if err := json.Unmarshal(result.Data, out); err != nil {
+			return err
+		}
+	}
+	if len(result.Errors) > 0 {
+		return &GraphQLError{Errors: result.Errors}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Health calls GET /health. The health endpoint is not wrapped in the
+// response envelope.
+func (c *Client) Health(ctx context.Context) (*Health, error) {
+	var health Health
+	if err := c.getRaw(ctx, c.baseURL+"/health", &health); err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
+// RegistryIndex fetches /registry/index.json. The registry is served from the
+// server root, outside the /api/v1 prefix.
+func (c *Client) RegistryIndex(ctx context.Context) (*Registry, error) {
+	var registry Registry
+	if err := c.getRaw(ctx, c.registryURL("index"), &registry); err != nil {
+		return nil, err
+	}
+	return &registry, nil
+}
+
+// RegistryItem fetches /registry/{slug}.json.
+func (c *Client) RegistryItem(ctx context.Context, slug string) (*RegistryItem, error) {
+	var item RegistryItem
+	if err := c.getRaw(ctx, c.registryURL(slug), &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (c *Client) registryURL(name string) string {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return c.baseURL + "/registry/" + url.PathEscape(name) + ".json"
+	}
+	u.Path = "/registry/" + url.PathEscape(name) + ".json"
+	u.RawQuery = ""
+	return u.String()
+}
+
+// getRaw fetches an absolute URL whose body is plain JSON rather than the
+// {success,data,error} envelope. Error bodies still use the envelope.
+func (c *Client) getRaw(ctx context.Context, rawURL string, out interface{}) error {
+	resp, err := c.send(ctx, http.MethodGet, rawURL, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode), RequestID: resp.Header.Get("X-Request-ID")}
+		var env envelope
+		if json.NewDecoder(resp.Body).Decode(&env) == nil && env.Error != nil {
+			apiErr.Message = *env.Error
+		}
+		return apiErr
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
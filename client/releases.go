@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CreateRelease calls POST /components/{slug}/releases.
+func (c *Client) CreateRelease(ctx context.Context, slug string, req CreateReleaseRequest) (*Release, error) {
+	var release Release
+	if err := c.do(ctx, http.MethodPost, componentPath(slug)+"/releases", nil, req, &release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// ListReleases calls GET /components/{slug}/releases, newest first.
+func (c *Client) ListReleases(ctx context.Context, slug string) ([]Release, error) {
+	var releases []Release
+	if err := c.do(ctx, http.MethodGet, componentPath(slug)+"/releases", nil, nil, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// GetComponentVersion calls GET /components/{slug}?version=. The code, files,
+// variants and props of the returned component are those of the release.
+func (c *Client) GetComponentVersion(ctx context.Context, slug, version string) (*Component, error) {
+	var component Component
+	query := url.Values{"version": {version}}
+	if err := c.do(ctx, http.MethodGet, componentPath(slug), query, nil, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// UploadScreenshot calls POST /components/{slug}/screenshots with a single
+// PNG, JPEG or GIF image. The server generates the thumbnail.
+func (c *Client) UploadScreenshot(ctx context.Context, slug, fileName string, data []byte, labels ScreenshotLabels) (*Screenshot, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, err
+	}
+	for field, value := range map[string]string{"theme": labels.Theme, "viewport": labels.Viewport, "caption": labels.Caption} {
+		if value == "" {
+			continue
+		}
+		if err := mw.WriteField(field, value); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, c.url(componentPath(slug)+"/screenshots", nil), body.Bytes(), mw.FormDataContentType())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var screenshots []Screenshot
+	if err := decodeEnvelope(resp, &screenshots); err != nil {
+		return nil, err
+	}
+	return &screenshots[0], nil
+}
+
+// ListScreenshots calls GET /components/{slug}/screenshots. The result is in
+// gallery order.
+func (c *Client) ListScreenshots(ctx context.Context, slug string) ([]Screenshot, error) {
+	var screenshots []Screenshot
+	if err := c.do(ctx, http.MethodGet, componentPath(slug)+"/screenshots", nil, nil, &screenshots); err != nil {
+		return nil, err
+	}
+	return screenshots, nil
+}
+
+// UpdateScreenshot calls PATCH /components/{slug}/screenshots/{id}.
+func (c *Client) UpdateScreenshot(ctx context.Context, slug string, id uuid.UUID, req UpdateScreenshotRequest) (*Screenshot, error) {
+	var screenshot Screenshot
+	if err := c.do(ctx, http.MethodPatch, componentPath(slug)+"/screenshots/"+id.String(), nil, req, &screenshot); err != nil {
+		return nil, err
+	}
+	return &screenshot, nil
+}
+
+// ReorderScreenshots calls PUT /components/{slug}/screenshots/order. ids must
+// contain every screenshot of the component exactly once.
+func (c *Client) ReorderScreenshots(ctx context.Context, slug string, ids []uuid.UUID) ([]Screenshot, error) {
+	var screenshots []Screenshot
+	body := map[string][]uuid.UUID{"ids": ids}
+	if err := c.do(ctx, http.MethodPut, componentPath(slug)+"/screenshots/order", nil, body, &screenshots); err != nil {
+		return nil, err
+	}
+	return screenshots, nil
+}
+
+// DeleteScreenshot calls DELETE /components/{slug}/screenshots/{id}.
+func (c *Client) DeleteScreenshot(ctx context.Context, slug string, id uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, componentPath(slug)+"/screenshots/"+id.String(), nil, nil, nil)
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Export calls GET /export and returns the raw archive. format is "ndjson"
+// or "zip".
+func (c *Client) Export(ctx context.Context, format string) ([]byte, error) {
+	resp, err := c.send(ctx, http.MethodGet, c.url("/export", url.Values{"format": {format}}), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, decodeEnvelope(resp, nil)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Import calls POST /import with an archive produced by Export.
+func (c *Client) Import(ctx context.Context, archive []byte, opts ImportOptions) (*ImportReport, error) {
+	query := url.Values{}
+	if opts.Policy != "" {
+		query.Set("policy", opts.Policy)
+	}
+	if opts.DryRun {
+		query.Set("dry_run", strconv.FormatBool(true))
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, c.url("/import", query), archive, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var report ImportReport
+	if err := decodeEnvelope(resp, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
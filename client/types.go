@@ -0,0 +1,531 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Category struct {
+	ID        uuid.UUID `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Component struct {
+	ID                 uuid.UUID       `json:"id"`
+	Slug               string          `json:"slug"`
+	Name               string          `json:"name"`
+	Description        string          `json:"description"`
+	Category           Category        `json:"category"`
+	CodeJSX            string          `json:"code_jsx"`
+	CodeCSS            string          `json:"code_css,omitempty"`
+	PropsDefinition    json.RawMessage `json:"props_definition,omitempty"`
+	UserID             uuid.UUID       `json:"user_id"`
+	WorkspaceID        *uuid.UUID      `json:"workspace_id,omitempty"`
+	Visibility         string          `json:"visibility"`
+	Tags               []*Tag          `json:"tags,omitempty"`
+	Variants           []Variant       `json:"variants,omitempty"`
+	Files              []File          `json:"files,omitempty"`
+	Screenshots        []Screenshot    `json:"screenshots,omitempty"`
+	PrimaryImage       *Screenshot     `json:"primary_image,omitempty"`
+	Status             string          `json:"status"`
+	ApprovalStatus     string          `json:"approval_status"`
+	ReviewerID         uuid.UUID       `json:"reviewer_id"`
+	LatestVersion      string          `json:"latest_version,omitempty"`
+	DeprecatedAt       *time.Time      `json:"deprecated_at,omitempty"`
+	DeprecationMessage string          `json:"deprecation_message,omitempty"`
+	SunsetAt           *time.Time      `json:"sunset_at,omitempty"`
+	ReplacedBy         string          `json:"replaced_by,omitempty"`
+	ForkedFrom         string          `json:"forked_from,omitempty"`
+	ForkedFromVersion  string          `json:"forked_from_version,omitempty"`
+	Version            string          `json:"version,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
+
+// Variant is the implementation of a component for one framework.
+type Variant struct {
+	ID        uuid.UUID `json:"id"`
+	Framework string    `json:"framework"`
+	Code      string    `json:"code"`
+	Styles    string    `json:"styles,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Variant returns the implementation for framework. React components created
+// before variants existed only carry CodeJSX/CodeCSS, which is returned as a
+// react variant.
+func (c *Component) Variant(framework string) (Variant, bool) {
+	for _, variant := range c.Variants {
+		if variant.Framework == framework {
+			return variant, true
+		}
+	}
+	if framework == "react" && c.CodeJSX != "" {
+		return Variant{Framework: framework, Code: c.CodeJSX, Styles: c.CodeCSS}, true
+	}
+	return Variant{}, false
+}
+
+// File is one source file of a component. Files with role "component" and
+// "style" make up the variant of their framework.
+type File struct {
+	ID        uuid.UUID `json:"id"`
+	Framework string    `json:"framework"`
+	Path      string    `json:"path"`
+	Language  string    `json:"language"`
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FileRequest adds a file. Framework defaults to react; Language and Role
+// are detected from the path when empty.
+type FileRequest struct {
+	Framework string `json:"framework,omitempty"`
+	Path      string `json:"path"`
+	Language  string `json:"language,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Content   string `json:"content"`
+}
+
+// UpdateFileRequest renames a file and/or replaces its content.
+type UpdateFileRequest struct {
+	Path    *string `json:"path,omitempty"`
+	Content *string `json:"content,omitempty"`
+}
+
+type Health struct {
+	Status  string `json:"status"`
+	Service string `json:"service"`
+}
+
+type CreateComponentRequest struct {
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	CategoryID      uuid.UUID        `json:"category_id"`
+	CodeJSX         string           `json:"code_jsx"`
+	CodeCSS         string           `json:"code_css,omitempty"`
+	Variants        []VariantRequest `json:"variants,omitempty"`
+	Files           []FileRequest    `json:"files,omitempty"`
+	PropsDefinition interface{}      `json:"props_definition,omitempty"`
+	Workspace       string           `json:"workspace,omitempty"`
+	Visibility      string           `json:"visibility,omitempty"`
+}
+
+type VariantRequest struct {
+	Framework string `json:"framework"`
+	Code      string `json:"code"`
+	Styles    string `json:"styles,omitempty"`
+}
+
+// UpdateComponentRequest only sends the fields that are set.
+type UpdateComponentRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// UpdateComponentApprovalRequest sets the approval status. The server records
+// the caller as the reviewer.
+type UpdateComponentApprovalRequest struct {
+	ApprovalStatus string `json:"approval_status"`
+}
+
+// ListComponentsParams mirrors the query parameters of GET /components.
+// Zero values are left out of the query.
+type ListComponentsParams struct {
+	Tags      []string
+	Category  string
+	Status    string
+	Approval  string
+	Framework string
+	Query     string
+	// Deprecated is "true" for only deprecated components or "all" for
+	// every component; by default deprecated components are left out.
+	Deprecated string
+	Page       int
+	Limit      int
+}
+
+type RegistryFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content,omitempty"`
+	Type    string `json:"type"`
+	Target  string `json:"target,omitempty"`
+}
+
+type RegistryItem struct {
+	Schema               string         `json:"$schema,omitempty"`
+	Name                 string         `json:"name"`
+	Type                 string         `json:"type"`
+	Title                string         `json:"title"`
+	Description          string         `json:"description,omitempty"`
+	Dependencies         []string       `json:"dependencies,omitempty"`
+	RegistryDependencies []string       `json:"registryDependencies,omitempty"`
+	Files                []RegistryFile `json:"files"`
+	Categories           []string       `json:"categories,omitempty"`
+	Docs                 string         `json:"docs,omitempty"`
+	Meta                 map[string]any `json:"meta,omitempty"`
+}
+
+type Registry struct {
+	Schema   string         `json:"$schema"`
+	Name     string         `json:"name"`
+	Homepage string         `json:"homepage"`
+	Items    []RegistryItem `json:"items"`
+}
+
+// ImportOptions controls POST /import. Policy is one of "skip" (default),
+// "overwrite" or "rename".
+type ImportOptions struct {
+	Policy string
+	DryRun bool
+}
+
+type ImportItemResult struct {
+	Kind    string `json:"kind"`
+	Slug    string `json:"slug"`
+	Action  string `json:"action"`
+	NewSlug string `json:"new_slug,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool               `json:"dry_run"`
+	Policy  string             `json:"policy"`
+	Summary map[string]int     `json:"summary"`
+	Items   []ImportItemResult `json:"items"`
+}
+
+// Asset is a binary file (image, icon, font) attached to a component. URL is a
+// signed download link relative to the server root that expires after a
+// while; list the assets again for a fresh one.
+type Asset struct {
+	ID          uuid.UUID `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Hash        string    `json:"hash"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Screenshot is a gallery image of a component. URL and ThumbnailURL are
+// signed links relative to the server root that expire after a while.
+type Screenshot struct {
+	ID           uuid.UUID `json:"id"`
+	Theme        string    `json:"theme,omitempty"`
+	Viewport     string    `json:"viewport,omitempty"`
+	Caption      string    `json:"caption,omitempty"`
+	Position     int       `json:"position"`
+	IsPrimary    bool      `json:"is_primary"`
+	ContentType  string    `json:"content_type"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	Hash         string    `json:"hash"`
+	URL          string    `json:"url,omitempty"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ScreenshotLabels are the optional labels of an uploaded screenshot.
+type ScreenshotLabels struct {
+	Theme    string
+	Viewport string
+	Caption  string
+}
+
+// UpdateScreenshotRequest is the body of UpdateScreenshot. Nil fields are left
+// unchanged; IsPrimary can only promote a screenshot.
+type UpdateScreenshotRequest struct {
+	Theme     *string `json:"theme,omitempty"`
+	Viewport  *string `json:"viewport,omitempty"`
+	Caption   *string `json:"caption,omitempty"`
+	IsPrimary *bool   `json:"is_primary,omitempty"`
+}
+
+// Release is a semver release of a component. Files and PropsDefinition are
+// frozen when the release is created; they are empty in ListReleases.
+type Release struct {
+	ID              uuid.UUID       `json:"id"`
+	Version         string          `json:"version"`
+	Changelog       string          `json:"changelog"`
+	Breaking        bool            `json:"breaking"`
+	PropsDefinition json.RawMessage `json:"props_definition,omitempty"`
+	Files           []File          `json:"files,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// CreateReleaseRequest is the body of CreateRelease. Version must be greater
+// than the latest release and a major bump when props were removed or retyped.
+type CreateReleaseRequest struct {
+	Version   string `json:"version"`
+	Changelog string `json:"changelog"`
+}
+
+// DeprecateRequest is the body of DeprecateComponent. SunsetAt is a date
+// (2006-01-02) or RFC3339 timestamp; ReplacedBy is the slug of the successor.
+type DeprecateRequest struct {
+	Message    string `json:"message"`
+	SunsetAt   string `json:"sunset_at,omitempty"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
+}
+
+// ForkRequest is the body of ForkComponent. All fields are optional: Name
+// defaults to "<source> Fork" and Version forks a release instead of the
+// current code.
+type ForkRequest struct {
+	Name       string `json:"name,omitempty"`
+	Version    string `json:"version,omitempty"`
+	Workspace  string `json:"workspace,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// Workspace owns components. Internal components are visible to its members,
+// private ones to their author and the workspace owners and admins.
+type Workspace struct {
+	ID        uuid.UUID         `json:"id"`
+	Slug      string            `json:"slug"`
+	Name      string            `json:"name"`
+	Members   []WorkspaceMember `json:"members,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type WorkspaceMember struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ShareLink grants read access to one component until it expires or is
+// revoked. Token and URL are only set in the response of CreateShareLink.
+type ShareLink struct {
+	ID        uuid.UUID  `json:"id"`
+	Token     string     `json:"token,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// User is a registered account.
+type User struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Tokens are returned by Register, Login and Refresh. Send AccessToken with
+// WithToken; exchange RefreshToken for a new pair before ExpiresIn seconds pass.
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// AuthResult is the response of Register and Login.
+type AuthResult struct {
+	User User `json:"user"`
+	Tokens
+}
+
+// RegisterRequest is the body of Register.
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+}
+
+// Me is the response of GET /me.
+type Me struct {
+	User       User        `json:"user"`
+	Components []Component `json:"components"`
+}
+
+// API key scopes.
+const (
+	ScopeComponentsRead  = "components:read"
+	ScopeComponentsWrite = "components:write"
+	ScopeReview          = "review"
+)
+
+// APIKey is a credential for CI and service clients. Key is only set in the
+// responses of CreateAPIKey and RotateAPIKey; pass it with WithToken.
+type APIKey struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	Key         string     `json:"key,omitempty"`
+	UserID      uuid.UUID  `json:"user_id"`
+	WorkspaceID *uuid.UUID `json:"workspace_id,omitempty"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RotatedAt   *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// CreateAPIKeyRequest is the body of CreateAPIKey. Workspace creates a key
+// owned by that workspace; ExpiresInDays 0 never expires.
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty"`
+	Workspace     string   `json:"workspace,omitempty"`
+}
+
+// AuditEvent is one entry of the append-only audit log. For updates Before
+// and After only hold the fields that changed; Before is empty for creates
+// and After is empty for deletes.
+type AuditEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"`
+	ActorType  string          `json:"actor_type"`
+	APIKeyID   *uuid.UUID      `json:"api_key_id,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	EntitySlug string          `json:"entity_slug"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	IP         string          `json:"ip"`
+	UserAgent  string          `json:"user_agent,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// ListAuditParams mirrors the query parameters of GET /audit. Zero values are
+// left out of the query.
+type ListAuditParams struct {
+	EntityType string
+	EntityID   uuid.UUID
+	Entity     string
+	ActorID    uuid.UUID
+	Action     string
+	From       time.Time
+	To         time.Time
+	Page       int
+	Limit      int
+}
+
+// Webhook events emitted by the service. Subscribe to "*" for all of them.
+const (
+	EventComponentCreated    = "component.created"
+	EventComponentUpdated    = "component.updated"
+	EventComponentPublished  = "component.published"
+	EventComponentApproved   = "component.approved"
+	EventComponentRejected   = "component.rejected"
+	EventComponentDeprecated = "component.deprecated"
+	EventComponentDeleted    = "component.deleted"
+)
+
+// Webhook is a subscription to catalog events. Secret is only set in the
+// response of CreateWebhook; keep it to verify deliveries with VerifyWebhook.
+type Webhook struct {
+	ID           uuid.UUID  `json:"id"`
+	URL          string     `json:"url"`
+	Events       []string   `json:"events"`
+	Secret       string     `json:"secret,omitempty"`
+	Active       bool       `json:"active"`
+	FailureCount int        `json:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	CreatedBy    uuid.UUID  `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// CreateWebhookRequest is the body of CreateWebhook. An empty Secret lets the
+// server generate one.
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret,omitempty"`
+}
+
+// UpdateWebhookRequest is the body of UpdateWebhook. Nil fields are left
+// unchanged; setting Active to true re-enables a disabled webhook.
+type UpdateWebhookRequest struct {
+	URL    *string  `json:"url,omitempty"`
+	Events []string `json:"events,omitempty"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+// WebhookDelivery is one attempt log entry of sending an event to a webhook.
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	ResponseBody   string          `json:"response_body,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookEvent is the JSON body POSTed to a webhook URL. ID is the same for
+// every delivery and replay of one event, so receivers can deduplicate.
+type WebhookEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// EventStreamReset is the Type of the CatalogEvent passed to StreamEvents when
+// the server can no longer resume from LastEventID; reload the data you
+// track, since some events were missed.
+const EventStreamReset = "reset"
+
+// CatalogEvent is one event of the catalog change stream. Sequence is the
+// SSE event id; pass the last one seen as StreamEventsParams.LastEventID to
+// resume after a disconnect. Data is the component snapshot.
+type CatalogEvent struct {
+	ID            uuid.UUID       `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Data          json.RawMessage `json:"data"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// StreamEventsParams filters StreamEvents. Empty Types and Categories match
+// every event.
+type StreamEventsParams struct {
+	Types       []string
+	Categories  []string
+	LastEventID int64
+}
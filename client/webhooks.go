@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Headers sent with every webhook delivery.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// ErrInvalidSignature is returned by VerifyWebhook for deliveries that were
+// not signed with the secret or are older than the tolerance.
+var ErrInvalidSignature = errors.New("componenthub: invalid webhook signature")
+
+// CreateWebhook calls POST /webhooks. Admins only.
+func (c *Client) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*Webhook, error) {
+	var hook Webhook
+	if err := c.do(ctx, http.MethodPost, "/webhooks", nil, req, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// ListWebhooks calls GET /webhooks.
+func (c *Client) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	var hooks []Webhook
+	if err := c.do(ctx, http.MethodGet, "/webhooks", nil, nil, &hooks); err != nil {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// GetWebhook calls GET /webhooks/{id}.
+func (c *Client) GetWebhook(ctx context.Context, id uuid.UUID) (*Webhook, error) {
+	var hook Webhook
+	if err := c.do(ctx, http.MethodGet, "/webhooks/"+id.String(), nil, nil, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// UpdateWebhook calls PATCH /webhooks/{id}.
+func (c *Client) UpdateWebhook(ctx context.Context, id uuid.UUID, req UpdateWebhookRequest) (*Webhook, error) {
+	var hook Webhook
+	if err := c.do(ctx, http.MethodPatch, "/webhooks/"+id.String(), nil, req, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// DeleteWebhook calls DELETE /webhooks/{id}.
+func (c *Client) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, "/webhooks/"+id.String(), nil, nil, nil)
+}
+
+// ListWebhookDeliveries calls GET /webhooks/{id}/deliveries. An empty status
+// lists every delivery.
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id uuid.UUID, status string, page, limit int) ([]WebhookDelivery, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", status)
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var deliveries []WebhookDelivery
+	if err := c.do(ctx, http.MethodGet, "/webhooks/"+id.String()+"/deliveries", query, nil, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ReplayWebhookDelivery calls POST /webhooks/{id}/deliveries/{delivery_id}/replay
+// and returns the newly queued delivery.
+func (c *Client) ReplayWebhookDelivery(ctx context.Context, id, deliveryID uuid.UUID) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	path := "/webhooks/" + id.String() + "/deliveries/" + deliveryID.String() + "/replay"
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// VerifyWebhook checks the signature headers of a delivery against the raw
+// request body and decodes it. Deliveries whose timestamp is further than
+// tolerance from now are rejected; a tolerance of 0 skips that check.
+func VerifyWebhook(secret string, header http.Header, body []byte, tolerance time.Duration) (*WebhookEvent, error) {
+	timestamp, err := strconv.ParseInt(header.Get(WebhookTimestampHeader), 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if tolerance > 0 {
+		age := time.Since(time.Unix(timestamp, 0))
+		if age > tolerance || age < -tolerance {
+			return nil, ErrInvalidSignature
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(header.Get(WebhookSignatureHeader))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
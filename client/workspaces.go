@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func workspacePath(slug string) string {
+	return "/workspaces/" + url.PathEscape(slug)
+}
+
+// CreateWorkspace calls POST /workspaces. The caller becomes its owner.
+func (c *Client) CreateWorkspace(ctx context.Context, name string) (*Workspace, error) {
+	var workspace Workspace
+	if err := c.do(ctx, http.MethodPost, "/workspaces", nil, map[string]string{"name": name}, &workspace); err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// ListWorkspaces calls GET /workspaces and returns the caller's workspaces.
+func (c *Client) ListWorkspaces(ctx context.Context) ([]Workspace, error) {
+	var workspaces []Workspace
+	if err := c.do(ctx, http.MethodGet, "/workspaces", nil, nil, &workspaces); err != nil {
+		return nil, err
+	}
+	return workspaces, nil
+}
+
+// GetWorkspace calls GET /workspaces/{slug}, including the members.
+func (c *Client) GetWorkspace(ctx context.Context, slug string) (*Workspace, error) {
+	var workspace Workspace
+	if err := c.do(ctx, http.MethodGet, workspacePath(slug), nil, nil, &workspace); err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// AddWorkspaceMember calls POST /workspaces/{slug}/members. It also changes
+// the role of an existing member.
+func (c *Client) AddWorkspaceMember(ctx context.Context, slug string, userID uuid.UUID, role string) (*WorkspaceMember, error) {
+	var member WorkspaceMember
+	body := map[string]interface{}{"user_id": userID, "role": role}
+	if err := c.do(ctx, http.MethodPost, workspacePath(slug)+"/members", nil, body, &member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// RemoveWorkspaceMember calls DELETE /workspaces/{slug}/members/{user_id}.
+func (c *Client) RemoveWorkspaceMember(ctx context.Context, slug string, userID uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, workspacePath(slug)+"/members/"+userID.String(), nil, nil, nil)
+}
+
+// UpdateVisibility calls PATCH /components/{slug}/visibility. An empty
+// workspace keeps the component in its current workspace.
+func (c *Client) UpdateVisibility(ctx context.Context, slug, visibility, workspace string) (*Component, error) {
+	var component Component
+	body := map[string]string{"visibility": visibility, "workspace": workspace}
+	if err := c.do(ctx, http.MethodPatch, componentPath(slug)+"/visibility", nil, body, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// CreateShareLink calls POST /components/{slug}/share-links.
+func (c *Client) CreateShareLink(ctx context.Context, slug string, ttl time.Duration) (*ShareLink, error) {
+	var link ShareLink
+	body := map[string]int{"expires_in_hours": int(ttl / time.Hour)}
+	if err := c.do(ctx, http.MethodPost, componentPath(slug)+"/share-links", nil, body, &link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// ListShareLinks calls GET /components/{slug}/share-links.
+func (c *Client) ListShareLinks(ctx context.Context, slug string) ([]ShareLink, error) {
+	var links []ShareLink
+	if err := c.do(ctx, http.MethodGet, componentPath(slug)+"/share-links", nil, nil, &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// RevokeShareLink calls DELETE /components/{slug}/share-links/{id}.
+func (c *Client) RevokeShareLink(ctx context.Context, slug string, id uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, componentPath(slug)+"/share-links/"+id.String(), nil, nil, nil)
+}
+
+// GetSharedComponent calls GET /shared/{token}.
+func (c *Client) GetSharedComponent(ctx context.Context, token string) (*Component, error) {
+	var component Component
+	if err := c.do(ctx, http.MethodGet, "/shared/"+url.PathEscape(token), nil, nil, &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"service_components/client"
+	"time"
+)
+
+func runExport(hub *client.Client, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "zip", "archive format: ndjson or zip")
+	output := fs.String("o", "", "output file (default catalog-<timestamp>.<format>)")
+	fs.Parse(args)
+
+	data, err := hub.Export(context.Background(), *format)
+	if err != nil {
+		return err
+	}
+
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("catalog-%s.%s", time.Now().UTC().Format("20060102-150405"), *format)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("exported catalog to %s (%d bytes)\n", path, len(data))
+	return nil
+}
+
+func runImport(hub *client.Client, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	policy := fs.String("policy", "skip", "slug conflict policy: skip, overwrite or rename")
+	dryRun := fs.Bool("dry-run", false, "report what would change without saving")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: componenthub import [-policy skip|overwrite|rename] [-dry-run] <file>")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	report, err := hub.Import(context.Background(), data, client.ImportOptions{Policy: *policy, DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range report.Items {
+		line := fmt.Sprintf("%-10s %-9s %s", item.Kind, item.Action, item.Slug)
+		if item.NewSlug != "" {
+			line += " -> " + item.NewSlug
+		}
+		if item.Error != "" {
+			line += ": " + item.Error
+		}
+		fmt.Println(line)
+	}
+	if report.DryRun {
+		fmt.Println("dry run: no changes were saved")
+	}
+	return nil
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"service_components/client"
+	"strings"
+	"time"
+)
+
+func runSearch(hub *client.Client, cfg cliConfig, args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	tag := fs.String("tag", "", "tag filter (comma separated)")
+	category := fs.String("category", "", "category slug")
+	limit := fs.Int("limit", 20, "maximum number of results")
+	fs.Parse(args)
+
+	params := client.ListComponentsParams{
+		Query:     strings.Join(fs.Args(), " "),
+		Category:  *category,
+		Framework: cfg.Framework,
+		Limit:     *limit,
+	}
+	if *tag != "" {
+		params.Tags = strings.Split(*tag, ",")
+	}
+
+	components, err := hub.ListComponents(context.Background(), params)
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		fmt.Println("no components found")
+		return nil
+	}
+
+	for _, component := range components {
+		fmt.Printf("%-24s %-16s %s\n", component.Slug, component.Category.Slug, component.Description)
+	}
+	return nil
+}
+
+func runInfo(hub *client.Client, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: componenthub info <slug>")
+	}
+
+	component, err := hub.GetComponent(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	tags := make([]string, 0, len(component.Tags))
+	for _, tag := range component.Tags {
+		tags = append(tags, tag.Name)
+	}
+	frameworks := make([]string, 0, len(component.Variants))
+	for _, variant := range component.Variants {
+		frameworks = append(frameworks, variant.Framework)
+	}
+
+	fmt.Printf("Name:        %s\n", component.Name)
+	fmt.Printf("Slug:        %s\n", component.Slug)
+	fmt.Printf("ID:          %s\n", component.ID)
+	fmt.Printf("Version:     %s\n", componentVersion(component))
+	if component.LatestVersion != "" {
+		fmt.Printf("Released:    %s\n", component.LatestVersion)
+	}
+	if component.ForkedFrom != "" {
+		fmt.Printf("Forked from: %s@%s\n", component.ForkedFrom, component.ForkedFromVersion)
+	}
+	fmt.Printf("Category:    %s\n", component.Category.Name)
+	fmt.Printf("Tags:        %s\n", strings.Join(tags, ", "))
+	fmt.Printf("Frameworks:  %s\n", strings.Join(frameworks, ", "))
+	fmt.Printf("Status:      %s / %s\n", component.Status, component.ApprovalStatus)
+	fmt.Printf("Description: %s\n", component.Description)
+	if warning := deprecationWarning(component); warning != "" {
+		fmt.Printf("Deprecated:  %s\n", warning)
+	}
+	return nil
+}
+
+func runAdd(hub *client.Client, cfg cliConfig, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	force := fs.Bool("force", false, "overwrite files that were changed locally")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return errors.New("usage: componenthub add [-force] <slug>...")
+	}
+
+	lock, err := readLockfile()
+	if err != nil {
+		return err
+	}
+
+	for _, arg := range fs.Args() {
+		if err := addComponent(hub, cfg, lock, arg, *force); err != nil {
+			// Komponen yang sudah terpasang sebelum error tetap dicatat.
+			return errors.Join(err, lock.save())
+		}
+	}
+
+	return lock.save()
+}
+
+// addComponent memasang slug atau slug@version dan mencatatnya di lock.
+// Versi yang disebut eksplisit dikunci (Pinned) sehingga update melewatinya;
+// add tanpa versi melepas kunci itu lagi.
+func addComponent(hub *client.Client, cfg cliConfig, lock *lockfile, arg string, force bool) error {
+	slug, version, _ := strings.Cut(arg, "@")
+	component, err := fetchComponent(hub, slug, version)
+	if err != nil {
+		return err
+	}
+
+	entry, err := install(cfg, component, lock.Components[component.Slug], force)
+	if err != nil {
+		return err
+	}
+	entry.Pinned = version != ""
+	lock.Components[component.Slug] = entry
+	fmt.Printf("added %s (%s)\n", component.Slug, entry.Version)
+	if warning := deprecationWarning(component); warning != "" {
+		fmt.Fprintf(os.Stderr, "warning: %s is deprecated: %s\n", component.Slug, warning)
+	}
+	return nil
+}
+
+func runDiff(hub *client.Client, cfg cliConfig, args []string) error {
+	lock, err := readLockfile()
+	if err != nil {
+		return err
+	}
+	slugs, err := lock.slugs(args)
+	if err != nil {
+		return err
+	}
+
+	for _, slug := range slugs {
+		component, err := fetchComponent(hub, slug, "")
+		if err != nil {
+			return err
+		}
+
+		entry := lock.Components[slug]
+		if version := componentVersion(component); version != entry.Version {
+			fmt.Printf("%s: installed %s, hub has %s\n", slug, entry.Version, version)
+		}
+
+		files, err := componentFiles(entry.config(cfg), component)
+		if err != nil {
+			return err
+		}
+		for _, path := range sortedPaths(files) {
+			remote := files[path]
+			local, err := os.ReadFile(path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			if string(local) == remote {
+				continue
+			}
+			fmt.Printf("--- %s (local)\n+++ %s (hub)\n", path, path)
+			fmt.Print(lineDiff(string(local), remote))
+		}
+		for _, path := range entry.Files {
+			if _, ok := files[filepath.FromSlash(path)]; !ok {
+				fmt.Printf("%s: %s was removed from the hub\n", slug, path)
+			}
+		}
+	}
+	return nil
+}
+
+func runUpdate(hub *client.Client, cfg cliConfig, args []string) error {
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	force := fs.Bool("force", false, "overwrite or remove files that were changed locally")
+	fs.Parse(args)
+
+	lock, err := readLockfile()
+	if err != nil {
+		return err
+	}
+	slugs, err := lock.slugs(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, slug := range slugs {
+		if err := updateComponent(hub, cfg, lock, slug, *force); err != nil {
+			// Komponen yang sudah diperbarui sebelum error tetap dicatat.
+			return errors.Join(err, lock.save())
+		}
+	}
+
+	return lock.save()
+}
+
+// updateComponent memasang rilis terbaru slug, kecuali versinya dikunci
+// lewat add slug@version.
+func updateComponent(hub *client.Client, cfg cliConfig, lock *lockfile, slug string, force bool) error {
+	old := lock.Components[slug]
+	if old.Pinned {
+		fmt.Printf("%s is pinned to %s; run add %s to follow the latest release\n", slug, old.Version, slug)
+		return nil
+	}
+
+	component, err := fetchComponent(hub, slug, "")
+	if err != nil {
+		return err
+	}
+	version := componentVersion(component)
+	if version == old.Version {
+		fmt.Printf("%s is up to date\n", slug)
+		return nil
+	}
+
+	entry, err := install(old.config(cfg), component, old, force)
+	if err != nil {
+		return err
+	}
+	lock.Components[slug] = entry
+	fmt.Printf("updated %s %s -> %s\n", slug, old.Version, version)
+	return nil
+}
+
+func runReleases(hub *client.Client, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: componenthub releases <slug>")
+	}
+
+	releases, err := hub.ListReleases(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 {
+		fmt.Println("no releases")
+		return nil
+	}
+
+	for _, release := range releases {
+		breaking := ""
+		if release.Breaking {
+			breaking = " (breaking)"
+		}
+		fmt.Printf("%s  %s%s\n", release.Version, release.CreatedAt.Format("2006-01-02"), breaking)
+		for _, line := range strings.Split(release.Changelog, "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+	return nil
+}
+
+// fetchComponent mengambil versi rilis tertentu, atau rilis terbaru jika
+// version kosong. Komponen yang belum pernah dirilis diambil apa adanya.
+func fetchComponent(hub *client.Client, slug, version string) (*client.Component, error) {
+	if version != "" {
+		return hub.GetComponentVersion(context.Background(), slug, version)
+	}
+
+	component, err := hub.GetComponent(context.Background(), slug)
+	if err != nil || component.LatestVersion == "" {
+		return component, err
+	}
+	return hub.GetComponentVersion(context.Background(), slug, component.LatestVersion)
+}
+
+// install menulis file komponen ke ComponentsDir dan menghapus file milik
+// pemasangan sebelumnya (old) yang tidak ada lagi di versi ini. File yang
+// sudah diubah secara lokal (isinya berbeda dari checksum di lockfile dan dari
+// isi baru) tidak ditimpa atau dihapus kecuali force; tidak ada file yang
+// ditulis sebelum pemeriksaan itu lolos.
+func install(cfg cliConfig, component *client.Component, old lockEntry, force bool) (lockEntry, error) {
+	files, err := componentFiles(cfg, component)
+	if err != nil {
+		return lockEntry{}, err
+	}
+
+	var dropped []string
+	for _, path := range old.Files {
+		path = filepath.FromSlash(path)
+		if _, ok := files[path]; ok {
+			continue
+		}
+		// Lockfile bisa disunting tangan; file di luar ComponentsDir tidak
+		// pernah dihapus.
+		if !contained(cfg.ComponentsDir, path) {
+			fmt.Fprintf(os.Stderr, "warning: %s is outside %s and was not removed\n", path, cfg.ComponentsDir)
+			continue
+		}
+		dropped = append(dropped, path)
+	}
+
+	if !force {
+		var changed []string
+		for _, path := range append(sortedPaths(files), dropped...) {
+			local, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return lockEntry{}, err
+			}
+			if content, ok := files[path]; ok && string(local) == content {
+				continue
+			}
+			if sum, ok := old.Checksums[filepath.ToSlash(path)]; ok && sum == checksum(local) {
+				continue
+			}
+			changed = append(changed, path)
+		}
+		if len(changed) > 0 {
+			return lockEntry{}, fmt.Errorf("%s: local changes in %s would be lost; review them with diff or rerun with -force",
+				component.Slug, strings.Join(changed, ", "))
+		}
+	}
+
+	entry := lockEntry{
+		ID:        component.ID,
+		Version:   componentVersion(component),
+		Framework: cfg.Framework,
+		Checksums: map[string]string{},
+	}
+	for _, path := range sortedPaths(files) {
+		content := files[path]
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return entry, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return entry, err
+		}
+		entry.Files = append(entry.Files, filepath.ToSlash(path))
+		entry.Checksums[filepath.ToSlash(path)] = checksum([]byte(content))
+	}
+	for _, path := range dropped {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return entry, err
+		}
+	}
+	return entry, nil
+}
+
+var frameworkExtensions = map[string]string{
+	"react":          ".jsx",
+	"vue":            ".vue",
+	"svelte":         ".svelte",
+	"html":           ".html",
+	"web-components": ".js",
+}
+
+// componentFiles memetakan path tujuan di project ke isi file komponen untuk
+// framework yang dipilih. Komponen tanpa daftar file memakai varian-nya.
+// Slug dan path file berasal dari hub, jadi keduanya harus tetap berada di
+// dalam ComponentsDir.
+func componentFiles(cfg cliConfig, component *client.Component) (map[string]string, error) {
+	dir, err := componentPath(cfg.ComponentsDir, component.Slug)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{}
+
+	for _, file := range component.Files {
+		if file.Framework != cfg.Framework {
+			continue
+		}
+		path, err := componentPath(dir, file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", component.Slug, err)
+		}
+		files[path] = file.Content
+	}
+	if len(files) > 0 {
+		return files, nil
+	}
+
+	variant, ok := component.Variant(cfg.Framework)
+	if !ok {
+		return nil, fmt.Errorf("%s has no %s variant", component.Slug, cfg.Framework)
+	}
+
+	ext, ok := frameworkExtensions[cfg.Framework]
+	if !ok {
+		ext = ".txt"
+	}
+
+	files[filepath.Join(dir, component.Slug+ext)] = variant.Code
+	if variant.Styles != "" {
+		files[filepath.Join(dir, component.Slug+".css")] = variant.Styles
+	}
+	return files, nil
+}
+
+// componentPath menggabungkan dir dengan path relatif dari hub dan menolak
+// path absolut atau yang keluar dari dir lewat "..".
+func componentPath(dir, name string) (string, error) {
+	name = filepath.FromSlash(name)
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("refusing to write %q outside %s", name, dir)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// contained melaporkan apakah path berada di dalam dir.
+func contained(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && filepath.IsLocal(rel)
+}
+
+// deprecationWarning merangkum status deprecated komponen, atau string kosong.
+func deprecationWarning(component *client.Component) string {
+	if component.DeprecatedAt == nil {
+		return ""
+	}
+	warning := component.DeprecationMessage
+	if component.ReplacedBy != "" {
+		warning += " (replaced by " + component.ReplacedBy + ")"
+	}
+	if component.SunsetAt != nil {
+		warning += ", sunset " + component.SunsetAt.Format("2006-01-02")
+	}
+	return warning
+}
+
+func componentVersion(component *client.Component) string {
+	if component.Version != "" {
+		return component.Version
+	}
+	return component.UpdatedAt.UTC().Format(time.RFC3339)
+}
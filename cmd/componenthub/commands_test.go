@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"service_components/client"
+	"strings"
+	"testing"
+)
+
+func TestComponentPath(t *testing.T) {
+	dir := filepath.Join("src", "components", "button")
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"button.jsx", filepath.Join(dir, "button.jsx"), true},
+		{"hooks/use-button.js", filepath.Join(dir, "hooks", "use-button.js"), true},
+		{"a/../button.css", filepath.Join(dir, "button.css"), true},
+		{"../card/card.jsx", "", false},
+		{"a/../../card.jsx", "", false},
+		{"..", "", false},
+		{"/etc/passwd", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, err := componentPath(dir, tt.name)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("componentPath(%q) = %q, %v; want %q, ok %v", tt.name, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+// newComponent membuat komponen react dengan files (path -> isi).
+func newComponent(slug, version string, files map[string]string) *client.Component {
+	component := &client.Component{Slug: slug, Version: version, LatestVersion: version}
+	for _, path := range sortedPaths(files) {
+		component.Files = append(component.Files, client.File{Framework: "react", Path: path, Content: files[path]})
+	}
+	return component
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestInstall(t *testing.T) {
+	cfg := cliConfig{ComponentsDir: t.TempDir(), Framework: "react"}
+	buttonJSX := filepath.Join(cfg.ComponentsDir, "button", "button.jsx")
+	buttonCSS := filepath.Join(cfg.ComponentsDir, "button", "button.css")
+
+	v1 := newComponent("button", "1.0.0", map[string]string{"button.jsx": "v1", "button.css": ".v1 {}"})
+	entry, err := install(cfg, v1, lockEntry{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Version != "1.0.0" || len(entry.Files) != 2 || entry.Checksums[filepath.ToSlash(buttonJSX)] != checksum([]byte("v1")) {
+		t.Fatalf("entry %+v", entry)
+	}
+
+	// Perubahan lokal menghentikan install tanpa menulis apa pun.
+	os.WriteFile(buttonCSS, []byte(".local {}"), 0o644)
+	v2 := newComponent("button", "2.0.0", map[string]string{"button.jsx": "v2"})
+	if _, err := install(cfg, v2, entry, false); err == nil || !strings.Contains(err.Error(), "local changes") {
+		t.Fatalf("error %v, want local changes", err)
+	}
+	if readFile(t, buttonJSX) != "v1" {
+		t.Error("button.jsx written despite local changes")
+	}
+
+	// -force menimpa dan menghapus file yang tidak ada lagi di versi baru.
+	updated, err := install(cfg, v2, entry, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readFile(t, buttonJSX) != "v2" {
+		t.Error("button.jsx not updated")
+	}
+	if _, err := os.Stat(buttonCSS); !os.IsNotExist(err) {
+		t.Errorf("dropped button.css still exists: %v", err)
+	}
+	if !reflect.DeepEqual(updated.Files, []string{filepath.ToSlash(buttonJSX)}) {
+		t.Errorf("files %v", updated.Files)
+	}
+
+	// File di luar ComponentsDir dari lockfile yang disunting tidak dihapus.
+	outside := filepath.Join(t.TempDir(), "keep.txt")
+	os.WriteFile(outside, []byte("keep"), 0o644)
+	updated.Files = append(updated.Files, filepath.ToSlash(outside))
+	if _, err := install(cfg, v2, updated, true); err != nil {
+		t.Fatal(err)
+	}
+	if readFile(t, outside) != "keep" {
+		t.Error("file outside the components directory was removed")
+	}
+
+	escape := newComponent("button", "3.0.0", map[string]string{"../evil.js": "x"})
+	if _, err := install(cfg, escape, updated, true); err == nil {
+		t.Error("path outside the component directory accepted")
+	}
+}
+
+// fakeHub melayani GET /api/v1/components/{slug}[?version=] dari releases
+// (slug -> versi -> komponen). Rilis terakhir di latest.
+type fakeHub struct {
+	releases map[string]map[string]*client.Component
+	latest   map[string]string
+}
+
+func (h *fakeHub) serve(t *testing.T) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := strings.TrimPrefix(r.URL.Path, "/api/v1/components/")
+		version := r.URL.Query().Get("version")
+		if version == "" {
+			version = h.latest[slug]
+		}
+		component, ok := h.releases[slug][version]
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success":false,"data":null,"error":"Component Not Found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": component, "error": nil})
+	}))
+	t.Cleanup(server.Close)
+	return client.New(server.URL + "/api/v1")
+}
+
+func (h *fakeHub) release(slug, version string) {
+	if h.releases[slug] == nil {
+		h.releases[slug] = map[string]*client.Component{}
+	}
+	h.releases[slug][version] = newComponent(slug, version, map[string]string{slug + ".jsx": slug + " " + version})
+	h.latest[slug] = version
+}
+
+// inProject menjalankan test di direktori project sementara, tempat
+// lockfile ditulis.
+func inProject(t *testing.T) cliConfig {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	return cliConfig{ComponentsDir: "src/components", Framework: "react"}
+}
+
+func installedVersions(t *testing.T) map[string]string {
+	t.Helper()
+	lock, err := readLockfile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := map[string]string{}
+	for slug, entry := range lock.Components {
+		versions[slug] = entry.Version
+		if entry.Pinned {
+			versions[slug] += " (pinned)"
+		}
+	}
+	return versions
+}
+
+func TestAddRecordsComponentsBeforeFailure(t *testing.T) {
+	cfg := inProject(t)
+	hub := &fakeHub{releases: map[string]map[string]*client.Component{}, latest: map[string]string{}}
+	hub.release("button", "1.0.0")
+	hub.release("card", "1.0.0")
+
+	err := runAdd(hub.serve(t), cfg, []string{"button", "missing", "card"})
+	if err == nil {
+		t.Fatal("add of a missing component succeeded")
+	}
+	if got, want := installedVersions(t), map[string]string{"button": "1.0.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("lockfile %v, want %v", got, want)
+	}
+	if _, err := os.Stat(filepath.Join("src", "components", "card")); !os.IsNotExist(err) {
+		t.Error("component after the failure was installed")
+	}
+}
+
+func TestUpdateRespectsPins(t *testing.T) {
+	cfg := inProject(t)
+	hub := &fakeHub{releases: map[string]map[string]*client.Component{}, latest: map[string]string{}}
+	hub.release("button", "1.0.0")
+	hub.release("button", "1.1.0")
+	hub.release("card", "1.0.0")
+	c := hub.serve(t)
+
+	if err := runAdd(c, cfg, []string{"button@1.0.0", "card"}); err != nil {
+		t.Fatal(err)
+	}
+	hub.release("button", "2.0.0")
+	hub.release("card", "2.0.0")
+
+	if err := runUpdate(c, cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"button": "1.0.0 (pinned)", "card": "2.0.0"}
+	if got := installedVersions(t); !reflect.DeepEqual(got, want) {
+		t.Errorf("after update: %v, want %v", got, want)
+	}
+	if got := readFile(t, filepath.Join("src", "components", "button", "button.jsx")); got != "button 1.0.0" {
+		t.Errorf("pinned button.jsx = %q", got)
+	}
+
+	// add tanpa versi melepas kunci dan memasang rilis terbaru.
+	if err := runAdd(c, cfg, []string{"button"}); err != nil {
+		t.Fatal(err)
+	}
+	hub.release("button", "2.1.0")
+	if err := runUpdate(c, cfg, []string{"button"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := installedVersions(t)["button"]; got != "2.1.0" {
+		t.Errorf("unpinned button = %q, want 2.1.0", got)
+	}
+}
+
+func TestUpdateRecordsComponentsBeforeFailure(t *testing.T) {
+	cfg := inProject(t)
+	hub := &fakeHub{releases: map[string]map[string]*client.Component{}, latest: map[string]string{}}
+	hub.release("alert", "1.0.0")
+	hub.release("button", "1.0.0")
+	c := hub.serve(t)
+	if err := runAdd(c, cfg, []string{"alert", "button"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// alert diperbarui, lalu button gagal karena perubahan lokal.
+	hub.release("alert", "2.0.0")
+	hub.release("button", "2.0.0")
+	os.WriteFile(filepath.Join("src", "components", "button", "button.jsx"), []byte("local"), 0o644)
+	if err := runUpdate(c, cfg, nil); err == nil {
+		t.Fatal("update over local changes succeeded")
+	}
+	want := map[string]string{"alert": "2.0.0", "button": "1.0.0"}
+	if got := installedVersions(t); !reflect.DeepEqual(got, want) {
+		t.Errorf("lockfile %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+const (
+	configFile = "componenthub.json"
+	lockFile   = "componenthub-lock.json"
+)
+
+type cliConfig struct {
+	HubURL        string `json:"hub_url"`
+	ComponentsDir string `json:"components_dir"`
+	Framework     string `json:"framework"`
+
+	// Token tidak dibaca dari componenthub.json karena file itu biasanya
+	// ikut di-commit bersama project.
+	Token string `json:"-"`
+}
+
+// loadConfig membaca componenthub.json di direktori kerja (jika ada), lalu
+// environment variable COMPONENTHUB_URL, COMPONENTHUB_DIR dan
+// COMPONENTHUB_FRAMEWORK sebagai override. Access token hanya dari
+// COMPONENTHUB_TOKEN.
+func loadConfig() cliConfig {
+	cfg := cliConfig{
+		HubURL:        "http://localhost:8080/api/v1",
+		ComponentsDir: "src/components",
+		Framework:     "react",
+	}
+
+	if data, err := os.ReadFile(configFile); err == nil {
+		_ = json.Unmarshal(data, &cfg)
+	}
+
+	if v := os.Getenv("COMPONENTHUB_URL"); v != "" {
+		cfg.HubURL = v
+	}
+	if v := os.Getenv("COMPONENTHUB_DIR"); v != "" {
+		cfg.ComponentsDir = v
+	}
+	if v := os.Getenv("COMPONENTHUB_FRAMEWORK"); v != "" {
+		cfg.Framework = v
+	}
+	cfg.Token = os.Getenv("COMPONENTHUB_TOKEN")
+
+	return cfg
+}
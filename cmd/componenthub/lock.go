@@ -0,0 +1,91 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// lockEntry mencatat komponen yang sudah dipasang ke project. Version berisi
+// versi rilis yang dipasang, atau updated_at untuk komponen yang belum pernah
+// dirilis. Checksums menyimpan sha256 isi setiap file saat dipasang, supaya
+// update bisa membedakan file yang diubah secara lokal. Pinned menandai
+// versi yang dipilih lewat add slug@version; update tidak menyentuhnya.
+type lockEntry struct {
+	ID        uuid.UUID         `json:"id"`
+	Version   string            `json:"version"`
+	Pinned    bool              `json:"pinned,omitempty"`
+	Framework string            `json:"framework"`
+	Files     []string          `json:"files"`
+	Checksums map[string]string `json:"checksums,omitempty"`
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// config mengembalikan cfg dengan framework yang dipakai saat komponen ini
+// dipasang, supaya diff dan update tidak berpindah framework.
+func (e lockEntry) config(cfg cliConfig) cliConfig {
+	if e.Framework != "" {
+		cfg.Framework = e.Framework
+	}
+	return cfg
+}
+
+type lockfile struct {
+	Components map[string]lockEntry `json:"components"`
+}
+
+func readLockfile() (*lockfile, error) {
+	lock := &lockfile{Components: map[string]lockEntry{}}
+
+	data, err := os.ReadFile(lockFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, err
+	}
+	if lock.Components == nil {
+		lock.Components = map[string]lockEntry{}
+	}
+	return lock, nil
+}
+
+func (l *lockfile) save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(lockFile, append(data, '\n'), 0o644)
+}
+
+// slugs mengembalikan slug yang diminta, atau seluruh isi lockfile jika kosong.
+func (l *lockfile) slugs(requested []string) ([]string, error) {
+	if len(requested) > 0 {
+		for _, slug := range requested {
+			if _, ok := l.Components[slug]; !ok {
+				return nil, errors.New(slug + " is not installed")
+			}
+		}
+		return requested, nil
+	}
+
+	slugs := make([]string, 0, len(l.Components))
+	for slug := range l.Components {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs, nil
+}
@@ -0,0 +1,87 @@
+// Command componenthub pulls components from a ComponentHub service into a
+// frontend project and keeps track of them through a lockfile.
+//
+//	componenthub search [-tag react] [-category ui-kit] [keyword]
+//	componenthub info <slug>
+//	componenthub add [-force] <slug>[@version]...
+//	componenthub releases <slug>
+//	componenthub diff [slug...]
+//	componenthub update [-force] [slug...]
+//	componenthub export [-format zip|ndjson] [-o file]
+//	componenthub import [-policy skip|overwrite|rename] [-dry-run] <file>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"service_components/client"
+)
+
+const usage = `Usage: componenthub [-hub URL] [-dir DIR] [-framework NAME] [-token TOKEN] <command> [args]
+
+Commands:
+  search [keyword]   search components (-tag, -category, -limit)
+  info <slug>        show component details
+  add <slug>...      write component code into the components directory
+                     (latest release, or pin one with slug@1.2.0)
+                     -force overwrites files that were changed locally
+  releases <slug>    list releases and their changelog
+  diff [slug...]     compare installed components with the hub
+  update [slug...]   update installed components to the latest version and
+                     remove files the new version dropped (-force);
+                     components pinned with add slug@version are skipped
+  export             back up the whole catalog (-format, -o)
+  import <file>      restore a catalog backup (-policy, -dry-run)
+`
+
+func main() {
+	cfg := loadConfig()
+
+	flag.StringVar(&cfg.HubURL, "hub", cfg.HubURL, "ComponentHub API base URL")
+	flag.StringVar(&cfg.ComponentsDir, "dir", cfg.ComponentsDir, "directory components are written to")
+	flag.StringVar(&cfg.Framework, "framework", cfg.Framework, "framework variant to install (react, vue, svelte, html, web-components)")
+	flag.StringVar(&cfg.Token, "token", cfg.Token, "access token for private and internal components (default $COMPONENTHUB_TOKEN)")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var opts []client.Option
+	if cfg.Token != "" {
+		opts = append(opts, client.WithToken(cfg.Token))
+	}
+	hub := client.New(cfg.HubURL, opts...)
+	args := flag.Args()[1:]
+
+	var err error
+	switch flag.Arg(0) {
+	case "search":
+		err = runSearch(hub, cfg, args)
+	case "info":
+		err = runInfo(hub, args)
+	case "add":
+		err = runAdd(hub, cfg, args)
+	case "releases":
+		err = runReleases(hub, args)
+	case "diff":
+		err = runDiff(hub, cfg, args)
+	case "update":
+		err = runUpdate(hub, cfg, args)
+	case "export":
+		err = runExport(hub, args)
+	case "import":
+		err = runImport(hub, args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
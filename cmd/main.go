@@ -1,60 +1,64 @@
 package main
 
 import (
+	"context"
 	"log"
 
+	"service_components/internal/auth"
 	"service_components/internal/config"
 	"service_components/internal/database"
-	"service_components/internal/handler"
+	"service_components/internal/events"
+	"service_components/internal/graphql"
+	"service_components/internal/grpcapi"
+	"service_components/internal/logging"
+	"service_components/internal/mail"
+	"service_components/internal/metrics"
 	"service_components/internal/model"
-
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
-
-	_ "service_components/docs"
-
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+	"service_components/internal/oidc"
+	"service_components/internal/openapi"
+	"service_components/internal/ratelimit"
+	"service_components/internal/router"
+	"service_components/internal/storage"
+	"service_components/internal/webhook"
 )
 
-// @title ComponentHub API
-// @version 1.0
-// @description This is the API for the ComponentHub marketplace.
-// @host localhost:8080
-// @BasePath /api/v1
 func main() {
 	cfg := config.LoadConfig()
+	logging.InitLogging(cfg)
 	database.ConnectDB(cfg)
+	storage.InitStorage(cfg)
+	auth.InitAuth(cfg)
+	mail.InitMail(cfg)
+	oidc.InitOIDC(cfg)
+	ratelimit.InitRateLimit(cfg)
+	webhook.InitWebhook(cfg)
+	events.InitEvents(cfg, database.DB)
+	graphql.InitGraphQL(cfg)
+	openapi.InitOpenAPI(cfg)
+	metrics.InitMetrics(cfg, database.DB)
 	database.Seeder()
-	err := database.DB.AutoMigrate(&model.Category{}, &model.Tag{}, &model.Component{})
+	err := database.DB.AutoMigrate(&model.Category{}, &model.Tag{}, &model.Component{}, &model.ComponentVariant{}, &model.ComponentFile{}, &model.Asset{}, &model.Screenshot{}, &model.ComponentRelease{}, &model.Workspace{}, &model.WorkspaceMember{}, &model.ShareLink{}, &model.User{}, &model.Session{}, &model.PasswordReset{}, &model.UserIdentity{}, &model.OIDCLogin{}, &model.APIKey{}, &model.AuditEvent{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.OutboxEvent{})
 	if err != nil {
 		log.Fatalf("FATAL: Failed to migrate database: %v", err)
 	}
-	
-	router := gin.Default()
-	router.Use(cors.Default())
-	api := router.Group("/api/v1")
-	{
-		api.GET("/health", handler.HealthCheck)
-		api.POST("/components", handler.CreateComponent)
-		api.GET("/components", handler.GetAllComponents)
-		api.GET("/components/:slug", handler.GetComponentBySlug)
-		api.PATCH("/components/:slug", handler.UpdateComponentBySlug)
-		api.DELETE("/components/:slug", handler.DeleteComponentBySlug)
-		api.POST("/components/:slug/tags", handler.AddComponentTag)
-
-		api.POST("/categories", handler.CreateCategory)
-		api.GET("/categories", handler.GetAllCategories)
+	if err := events.Migrate(database.DB); err != nil {
+		log.Fatalf("FATAL: Failed to migrate outbox: %v", err)
+	}
+	go events.Start(context.Background(), database.DB, events.Default)
+	if events.Memory != nil {
+		go events.Tail(context.Background(), database.DB, events.Memory, cfg.EventStreamBuffer)
+	}
+	go webhook.Start(context.Background(), database.DB)
 
-		api.PATCH("/components/:slug/status", handler.UpdateComponentStatus)
-		api.PATCH("/components/:slug/approval", handler.UpdateComponentApproval)
+	engine := router.New(cfg)
 
-		api.POST("/tags", handler.CreateTag)
-		api.GET("/tags", handler.GetAllTags)
+	// gRPC memakai router yang sama untuk menjalankan endpoint REST.
+	if cfg.GRPCPort != "" {
+		go grpcapi.Serve(cfg.GRPCPort, engine)
+	}
+	if cfg.MetricsEnabled && cfg.MetricsPort != "" {
+		go metrics.Serve(cfg.MetricsPort)
 	}
 
-	// Swagger documentation endpoint
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	router.Run(":8080")
+	engine.Run(":8080")
 }
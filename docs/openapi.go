@@ -0,0 +1,9 @@
+package docs
+
+import _ "embed"
+
+// OpenAPI adalah isi openapi.yaml, spesifikasi OpenAPI 3 yang dipakai untuk
+// validasi request dan response.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
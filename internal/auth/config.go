@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"service_components/internal/config"
+	"time"
+)
+
+var (
+	AccessTokenTTL   = 15 * time.Minute
+	RefreshTokenTTL  = 30 * 24 * time.Hour
+	PasswordResetTTL = time.Hour
+)
+
+func InitAuth(cfg *config.Config) {
+	AccessTokenTTL = cfg.AccessTokenTTL
+	RefreshTokenTTL = cfg.RefreshTokenTTL
+	PasswordResetTTL = cfg.PasswordResetTTL
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$") {
+		t.Errorf("hash = %q, want argon2id PHC string with OWASP parameters", hash)
+	}
+	parts := strings.Split(hash, "$")
+	salt, _ := base64.RawStdEncoding.DecodeString(parts[4])
+	key, _ := base64.RawStdEncoding.DecodeString(parts[5])
+	if len(salt) != argonSaltLen || len(key) != argonKeyLen {
+		t.Errorf("salt %d bytes, key %d bytes", len(salt), len(key))
+	}
+
+	// Salt acak: password yang sama menghasilkan hash berbeda.
+	again, err := HashPassword("correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == hash {
+		t.Error("two hashes of the same password are equal")
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		password string
+		match    bool
+	}{
+		{"correct-horse", true},
+		{"Correct-horse", false},
+		{"correct-horse ", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		match, err := CheckPassword(hash, tt.password)
+		if err != nil || match != tt.match {
+			t.Errorf("CheckPassword(%q) = %v, %v; want %v", tt.password, match, err, tt.match)
+		}
+	}
+}
+
+// Parameter dibaca dari hash, jadi hash dengan parameter lain tetap bisa
+// diverifikasi.
+func TestCheckPasswordReadsParameters(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte("correct-horse"), salt, 1, 64, 2, 16)
+	hash := fmt.Sprintf("$argon2id$v=19$m=64,t=1,p=2$%s$%s",
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+
+	if match, err := CheckPassword(hash, "correct-horse"); err != nil || !match {
+		t.Errorf("CheckPassword = %v, %v; want true", match, err)
+	}
+	if match, err := CheckPassword(hash, "wrong-horse"); err != nil || match {
+		t.Errorf("wrong password: CheckPassword = %v, %v; want false", match, err)
+	}
+}
+
+func TestCheckPasswordInvalidHash(t *testing.T) {
+	hashes := []string{
+		"",
+		"plaintext",
+		"$2a$10$abcdefghijklmnopqrstuv",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5",
+		"$argon2id$v=19$m=64,t=1$c2FsdHNhbHQ$a2V5a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$not base64$a2V5a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$not base64",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ",
+	}
+	for _, hash := range hashes {
+		if match, err := CheckPassword(hash, "correct-horse"); match || !errors.Is(err, ErrInvalidHash) {
+			t.Errorf("CheckPassword(%q) = %v, %v; want ErrInvalidHash", hash, match, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// NewToken membuat token acak yang aman dipakai di URL dan header.
+func NewToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// HashToken mengembalikan hash sha256 token. Hanya hash yang disimpan di
+// database sehingga token tidak bisa dipakai jika database bocor.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyPrefix membedakan API key dari access token sesi.
+const APIKeyPrefix = "chk_"
+
+// NewAPIKey membuat API key baru dengan prefix chk_.
+func NewAPIKey() (string, error) {
+	token, err := NewToken()
+	if err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + token, nil
+}
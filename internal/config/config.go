@@ -3,12 +3,116 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	DatabaseURL string
+
+	// Level log JSON (debug, info, warn, error). Query GORM yang lebih lama
+	// dari SlowQueryThreshold dicatat sebagai warning; semua query dicatat
+	// pada level debug.
+	LogLevel           string
+	SlowQueryThreshold time.Duration
+
+	// Storage asset komponen: "local" (default) atau "s3".
+	StorageDriver string
+	StoragePath   string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	MaxUploadSize int64
+	URLSigningKey string
+	SignedURLTTL  time.Duration
+
+	// Masa berlaku token login dan reset password.
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	PasswordResetTTL time.Duration
+
+	// Email transaksional: MailDriver "smtp" atau "log" (hanya dengan
+	// DevMode). PasswordResetURL adalah halaman reset di frontend; token
+	// ditambahkan di belakangnya.
+	DevMode          bool
+	MailDriver       string
+	MailFrom         string
+	SMTPHost         string
+	SMTPPort         string
+	SMTPUsername     string
+	SMTPPassword     string
+	PasswordResetURL string
+
+	// Login SSO lewat OpenID Connect, aktif jika OIDCIssuerURL diisi.
+	// OIDCRoleMapping memetakan group dari identity provider ke role hub.
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+	OIDCScopes       []string
+	OIDCGroupsClaim  string
+	OIDCRoleMapping  map[string]string
+
+	// Rate limit per grup route (read, write, auth). Store "memory" atau
+	// "redis" (REDIS_URL). TrustedProxies menentukan kapan X-Forwarded-For
+	// dipercaya untuk IP client.
+	RateLimitEnabled bool
+	RateLimitStore   string
+	RedisURL         string
+	RateLimits       map[string]RateLimit
+	TrustedProxies   []string
+
+	// Pengiriman webhook: jumlah percobaan per delivery, timeout per request
+	// dan jumlah kegagalan berturut-turut sebelum webhook dinonaktifkan.
+	WebhookMaxAttempts  int
+	WebhookTimeout      time.Duration
+	WebhookDisableAfter int
+
+	// Publisher domain event dari outbox: kombinasi "webhook", "memory" dan
+	// "nats" (NATS_URL, subject diawali NATSSubjectPrefix).
+	EventPublishers   []string
+	NATSURL           string
+	NATSSubjectPrefix string
+
+	// Jumlah event terakhir yang disimpan publisher "memory" untuk resume
+	// stream SSE (Last-Event-ID), dan jeda heartbeat stream.
+	EventStreamBuffer    int
+	EventStreamHeartbeat time.Duration
+
+	// Batas kedalaman dan biaya query GraphQL.
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	// URL publik service untuk link absolut (homepage registry). Jika
+	// kosong, diturunkan dari request.
+	PublicURL string
+
+	// Port server gRPC, terpisah dari HTTP. GRPC_PORT yang diset kosong
+	// mematikan server gRPC.
+	GRPCPort string
+
+	// Validasi terhadap docs/openapi.yaml: off, requests, atau test
+	// (request dan response).
+	OpenAPIValidation string
+
+	// Endpoint /metrics Prometheus. Jika MetricsPort diisi, metrics hanya
+	// dilayani di port itu; MetricsToken mewajibkan header
+	// Authorization: Bearer <token>.
+	MetricsEnabled bool
+	MetricsPort    string
+	MetricsToken   string
+}
+
+// RateLimit mengizinkan Burst request sekaligus, diisi ulang PerMinute
+// request per menit.
+type RateLimit struct {
+	PerMinute int
+	Burst     int
 }
 
 func LoadConfig() *Config {
@@ -24,5 +128,122 @@ func LoadConfig() *Config {
 
 	return &Config{
 		DatabaseURL: dbURL,
+
+		LogLevel:           getEnv("LOG_LEVEL", "info"),
+		SlowQueryThreshold: time.Duration(getEnvInt("SLOW_QUERY_MS", 200)) * time.Millisecond,
+
+		StorageDriver: getEnv("STORAGE_DRIVER", "local"),
+		StoragePath:   getEnv("STORAGE_PATH", "./storage"),
+		S3Endpoint:    os.Getenv("S3_ENDPOINT"),
+		S3Region:      getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:      os.Getenv("S3_BUCKET"),
+		S3AccessKey:   os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:   os.Getenv("S3_SECRET_KEY"),
+		MaxUploadSize: int64(getEnvInt("ASSET_MAX_SIZE_MB", 5)) << 20,
+		URLSigningKey: os.Getenv("URL_SIGNING_KEY"),
+		SignedURLTTL:  time.Duration(getEnvInt("SIGNED_URL_TTL_MINUTES", 15)) * time.Minute,
+
+		AccessTokenTTL:   time.Duration(getEnvInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute,
+		RefreshTokenTTL:  time.Duration(getEnvInt("REFRESH_TOKEN_TTL_DAYS", 30)) * 24 * time.Hour,
+		PasswordResetTTL: time.Duration(getEnvInt("PASSWORD_RESET_TTL_MINUTES", 60)) * time.Minute,
+
+		DevMode:          getEnv("DEV_MODE", "false") == "true",
+		MailDriver:       os.Getenv("MAIL_DRIVER"),
+		MailFrom:         os.Getenv("MAIL_FROM"),
+		SMTPHost:         os.Getenv("SMTP_HOST"),
+		SMTPPort:         getEnv("SMTP_PORT", "587"),
+		SMTPUsername:     os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
+		PasswordResetURL: os.Getenv("PASSWORD_RESET_URL"),
+
+		OIDCIssuerURL:    os.Getenv("OIDC_ISSUER_URL"),
+		OIDCClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		OIDCClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		OIDCRedirectURL:  getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/v1/auth/oidc/callback"),
+		OIDCScopes:       strings.Fields(getEnv("OIDC_SCOPES", "openid email profile")),
+		OIDCGroupsClaim:  getEnv("OIDC_GROUPS_CLAIM", "groups"),
+		OIDCRoleMapping:  getEnvMap("OIDC_ROLE_MAPPING"),
+
+		RateLimitEnabled: getEnv("RATE_LIMIT_ENABLED", "true") == "true",
+		RateLimitStore:   getEnv("RATE_LIMIT_STORE", "memory"),
+		RedisURL:         getEnv("REDIS_URL", "redis://localhost:6379/0"),
+		RateLimits: map[string]RateLimit{
+			"read":  {PerMinute: getEnvInt("RATE_LIMIT_READ", 600), Burst: getEnvInt("RATE_LIMIT_READ_BURST", 100)},
+			"write": {PerMinute: getEnvInt("RATE_LIMIT_WRITE", 120), Burst: getEnvInt("RATE_LIMIT_WRITE_BURST", 30)},
+			"auth":  {PerMinute: getEnvInt("RATE_LIMIT_AUTH", 10), Burst: getEnvInt("RATE_LIMIT_AUTH_BURST", 5)},
+		},
+		TrustedProxies: strings.FieldsFunc(os.Getenv("TRUSTED_PROXIES"), func(r rune) bool { return r == ',' || r == ' ' }),
+
+		WebhookMaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookTimeout:      time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		WebhookDisableAfter: getEnvInt("WEBHOOK_DISABLE_AFTER", 20),
+
+		EventPublishers:   strings.FieldsFunc(getEnv("EVENT_PUBLISHERS", "webhook,memory"), func(r rune) bool { return r == ',' || r == ' ' }),
+		NATSURL:           getEnv("NATS_URL", "nats://localhost:4222"),
+		NATSSubjectPrefix: getEnv("NATS_SUBJECT_PREFIX", "componenthub"),
+
+		EventStreamBuffer:    getEnvInt("EVENT_STREAM_BUFFER", 1000),
+		EventStreamHeartbeat: time.Duration(getEnvInt("EVENT_STREAM_HEARTBEAT_SECONDS", 15)) * time.Second,
+
+		GraphQLMaxDepth:      getEnvInt("GRAPHQL_MAX_DEPTH", 10),
+		GraphQLMaxComplexity: getEnvInt("GRAPHQL_MAX_COMPLEXITY", 2000),
+
+		PublicURL: strings.TrimSuffix(os.Getenv("PUBLIC_URL"), "/"),
+
+		GRPCPort: lookupEnv("GRPC_PORT", "9090"),
+
+		OpenAPIValidation: getEnv("OPENAPI_VALIDATION", "requests"),
+
+		MetricsEnabled: getEnv("METRICS_ENABLED", "true") == "true",
+		MetricsPort:    os.Getenv("METRICS_PORT"),
+		MetricsToken:   os.Getenv("METRICS_TOKEN"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// lookupEnv seperti getEnv, tetapi variabel yang diset kosong tetap kosong;
+// fallback hanya dipakai jika variabel tidak diset sama sekali.
+func lookupEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("invalid %s=%q, using %d", key, v, fallback)
+		return fallback
+	}
+	return n
+}
+
+// getEnvMap membaca pasangan "key=value" yang dipisah koma, misalnya
+// OIDC_ROLE_MAPPING=hub-admins=admin,hub-reviewers=reviewer.
+func getEnvMap(key string) map[string]string {
+	values := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, "=")
+		if i <= 0 {
+			log.Printf("invalid %s entry %q, expected name=value", key, pair)
+			continue
+		}
+		values[strings.TrimSpace(pair[:i])] = strings.TrimSpace(pair[i+1:])
 	}
+	return values
 }
@@ -1,9 +1,10 @@
 package database
 
 import (
-	"fmt"
 	"log"
+	"log/slog"
 	"service_components/internal/config"
+	"service_components/internal/logging"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -14,11 +15,13 @@ var DB *gorm.DB
 func ConnectDB(cfg *config.Config) {
 	var err error
 
-	DB, err = gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{})
+	DB, err = gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{
+		Logger: logging.NewGormLogger(cfg.SlowQueryThreshold),
+	})
 
 	if err != nil {
 		log.Fatalf("FATAL: Failed to connectioni database: %v", err)
 	}
 
-	fmt.Println("Connection Succes")
+	slog.Info("Connection Succes")
 }
@@ -1,7 +1,7 @@
 package database
 
 import (
-	"log"
+	"log/slog"
 	"service_components/internal/model"
 
 	"github.com/google/uuid"
@@ -16,7 +16,7 @@ func Seeder() {
 
 	for _, category := range categories {
 		if err := DB.Where("slug = ?", category.Slug).FirstOrCreate(&category).Error; err != nil {
-			log.Printf("Gagal menambahkan kategori: %s", err)
+			slog.Error("Gagal menambahkan kategori", "slug", category.Slug, "error", err)
 		}
 	}
 
@@ -28,7 +28,7 @@ func Seeder() {
 
 	for _, tag := range tags {
 		if err := DB.Where("slug = ?", tag.Slug).FirstOrCreate(&tag).Error; err != nil {
-			log.Printf("Gagal menambahkan tag: %s", err)
+			slog.Error("Gagal menambahkan tag", "slug", tag.Slug, "error", err)
 		}
 	}
 
@@ -58,9 +58,9 @@ func Seeder() {
 
 	for _, component := range components {
 		if err := DB.Where("slug = ?", component.Slug).FirstOrCreate(&component).Error; err != nil {
-			log.Printf("Gagal menambahkan komponen: %s", err)
+			slog.Error("Gagal menambahkan komponen", "slug", component.Slug, "error", err)
 		}
 	}
 
-	log.Println("Seeder selesai dijalankan.")
+	slog.Info("Seeder selesai dijalankan")
 }
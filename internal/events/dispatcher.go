@@ -0,0 +1,153 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"service_components/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	pollInterval = time.Second
+	batchSize    = 100
+
+	baseBackoff = time.Second
+	maxBackoff  = 5 * time.Minute
+
+	// Event yang sudah terpublikasi disimpan sebentar untuk debugging, lalu
+	// dibersihkan.
+	retention     = 7 * 24 * time.Hour
+	cleanupPeriod = time.Hour
+
+	// dispatcherLock adalah key advisory lock Postgres supaya hanya satu
+	// instance yang mengklaim batch pada satu waktu.
+	dispatcherLock = 0x6f7574626f78
+
+	// claimLease adalah lama klaim sebuah batch. Jika instance mati sebelum
+	// selesai, event yang belum terpublikasi diambil lagi setelah klaim lewat.
+	claimLease = time.Minute
+)
+
+// Start menjalankan dispatcher sampai ctx selesai.
+func Start(ctx context.Context, db *gorm.DB, publisher EventPublisher) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	lastCleanup := time.Time{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := Dispatch(ctx, db, publisher); err != nil {
+				slog.ErrorContext(ctx, "outbox dispatch failed", "error", err)
+			}
+			if time.Since(lastCleanup) > cleanupPeriod {
+				lastCleanup = time.Now()
+				cutoff := time.Now().UTC().Add(-retention)
+				if err := db.Where("published_at < ?", cutoff).Delete(&model.OutboxEvent{}).Error; err != nil {
+					slog.ErrorContext(ctx, "outbox cleanup failed", "error", err)
+				}
+			}
+		}
+	}
+}
+
+func backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// Dispatch mempublikasikan satu batch event yang belum terpublikasi sesuai
+// urutan. Jika publikasi event sebuah komponen gagal, event berikutnya untuk
+// komponen itu ditahan sampai event tersebut berhasil.
+//
+// Batch diklaim dulu dalam transaksi singkat; publikasi berjalan di luar
+// transaksi dan advisory lock supaya publisher yang lambat tidak menahan
+// koneksi database maupun dispatcher di instance lain.
+func Dispatch(ctx context.Context, db *gorm.DB, publisher EventPublisher) error {
+	pending, err := claim(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	blocked := map[uuid.UUID]bool{}
+	for i := range pending {
+		row := &pending[i]
+		now := time.Now().UTC()
+		if blocked[row.AggregateID] {
+			// Lepas klaim; event ini tetap tertahan di query claim selama
+			// event sebelumnya menunggu backoff.
+			if err := db.Model(row).Update("next_attempt_at", now).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := publisher.Publish(ctx, fromOutbox(row)); err != nil {
+			blocked[row.AggregateID] = true
+			row.Attempts++
+			err = db.Model(row).Updates(map[string]interface{}{
+				"attempts":        row.Attempts,
+				"last_error":      err.Error(),
+				"next_attempt_at": now.Add(backoff(row.Attempts)),
+			}).Error
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		// Syarat published_at IS NULL mencegah nomor publikasi kedua jika
+		// klaim sempat kedaluwarsa dan instance lain juga mempublikasikannya.
+		err := db.Model(row).Where("published_at IS NULL").Updates(map[string]interface{}{
+			"published_at":  now,
+			"published_seq": gorm.Expr("nextval('" + publishedSeq + "')"),
+			"last_error":    "",
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// claim mengambil satu batch event yang siap dipublikasikan dan mengklaimnya
+// dengan memajukan next_attempt_at sejauh claimLease. Event yang punya event
+// lebih awal untuk komponen yang sama yang masih diklaim atau menunggu backoff
+// dilewati di query, jadi urutan per komponen tetap terjaga.
+func claim(ctx context.Context, db *gorm.DB) ([]model.OutboxEvent, error) {
+	var pending []model.OutboxEvent
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", dispatcherLock).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		err := tx.Where("published_at IS NULL AND next_attempt_at <= ?", now).
+			Where(`NOT EXISTS (SELECT 1 FROM outbox_events earlier WHERE earlier.aggregate_id = outbox_events.aggregate_id
+				AND earlier.published_at IS NULL AND earlier.id < outbox_events.id AND earlier.next_attempt_at > ?)`, now).
+			Order("id asc").Limit(batchSize).Find(&pending).Error
+		if err != nil || len(pending) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(pending))
+		for i := range pending {
+			ids[i] = pending[i].ID
+		}
+		return tx.Model(&model.OutboxEvent{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(claimLease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
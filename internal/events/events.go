@@ -0,0 +1,113 @@
+// Package events memakai pola transactional outbox: domain event ditulis ke
+// tabel outbox di transaksi yang sama dengan perubahannya, lalu dispatcher
+// mempublikasikannya at-least-once ke EventPublisher. Event untuk satu
+// aggregate (komponen) selalu dipublikasikan sesuai urutan penulisannya.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"service_components/internal/config"
+	"service_components/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Event adalah domain event yang dipublikasikan. ID sama untuk setiap
+// publikasi ulang event yang sama, sehingga konsumen bisa deduplikasi.
+type Event struct {
+	ID            uuid.UUID       `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Data          json.RawMessage `json:"data"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// EventPublisher mengirim event ke tujuan di luar transaksi. Publish yang
+// mengembalikan error akan diulang, jadi implementasi harus tahan duplikat.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+var (
+	Default EventPublisher = Multi{}
+	// Memory adalah publisher in-process untuk subscriber di dalam service
+	// (stream SSE), diisi jika "memory" ada di EVENT_PUBLISHERS. Memory tidak
+	// dipanggil dispatcher: setiap instance mengisinya sendiri lewat Tail.
+	Memory *MemoryPublisher
+
+	// StreamHeartbeat adalah jeda ping di stream SSE yang sedang idle.
+	StreamHeartbeat = 15 * time.Second
+)
+
+func InitEvents(cfg *config.Config, db *gorm.DB) {
+	if cfg.EventStreamHeartbeat > 0 {
+		StreamHeartbeat = cfg.EventStreamHeartbeat
+	}
+
+	var publishers Multi
+	for _, name := range cfg.EventPublishers {
+		switch name {
+		case "webhook":
+			publishers = append(publishers, WebhookPublisher{DB: db})
+		case "memory":
+			Memory = NewMemoryPublisher(cfg.EventStreamBuffer)
+		case "nats":
+			publisher, err := NewNATSPublisher(cfg.NATSURL, cfg.NATSSubjectPrefix)
+			if err != nil {
+				log.Fatalf("FATAL: invalid NATS_URL: %v", err)
+			}
+			publishers = append(publishers, publisher)
+		default:
+			log.Fatalf("FATAL: unknown event publisher %q in EVENT_PUBLISHERS", name)
+		}
+	}
+	Default = publishers
+}
+
+// Record menulis event ke outbox. Panggil dengan transaksi perubahan supaya
+// event tersimpan jika dan hanya jika perubahannya tersimpan.
+func Record(tx *gorm.DB, eventType, aggregateType string, aggregateID uuid.UUID, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&model.OutboxEvent{
+		EventID:       uuid.New(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       payload,
+		NextAttemptAt: time.Now().UTC(),
+	}).Error
+}
+
+func fromOutbox(row *model.OutboxEvent) Event {
+	return Event{
+		ID:            row.EventID,
+		Sequence:      row.ID,
+		Type:          row.Type,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		Data:          json.RawMessage(row.Payload),
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+// Multi mempublikasikan ke beberapa publisher berurutan dan berhenti di error
+// pertama. Publisher sebelumnya akan menerima event yang sama lagi saat retry.
+type Multi []EventPublisher
+
+func (m Multi) Publish(ctx context.Context, event Event) error {
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryPublisher meneruskan event ke subscriber di proses yang sama dan
+// menyimpan event terakhir di buffer berukuran tetap supaya subscriber yang
+// tersambung ulang bisa melanjutkan dari event terakhir yang diterimanya.
+// Subscriber yang lambat diputus ketika buffer channel-nya penuh, supaya
+// dispatcher tidak ikut tertahan; subscriber bisa melanjutkan lewat Resume.
+type MemoryPublisher struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+
+	// history adalah ring buffer; start menunjuk event tertua.
+	history []Event
+	start   int
+	size    int
+	seen    map[uuid.UUID]struct{}
+}
+
+func NewMemoryPublisher(size int) *MemoryPublisher {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryPublisher{
+		subscribers: map[chan Event]struct{}{},
+		history:     make([]Event, 0, size),
+		size:        size,
+		seen:        map[uuid.UUID]struct{}{},
+	}
+}
+
+// Publish menyimpan event ke buffer dan meneruskannya ke subscriber. Event
+// yang dipublikasikan ulang (retry outbox) diabaikan selama masih di buffer.
+func (m *MemoryPublisher) Publish(_ context.Context, event Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.seen[event.ID]; ok {
+		return nil
+	}
+
+	if len(m.history) < m.size {
+		m.history = append(m.history, event)
+	} else {
+		delete(m.seen, m.history[m.start].ID)
+		m.history[m.start] = event
+		m.start = (m.start + 1) % m.size
+	}
+	m.seen[event.ID] = struct{}{}
+
+	for ch := range m.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(m.subscribers, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+// Subscribe mendaftarkan subscriber baru tanpa replay. Panggil fungsi yang
+// dikembalikan untuk berhenti berlangganan.
+func (m *MemoryPublisher) Subscribe(buffer int) (<-chan Event, func()) {
+	_, _, ch, cancel := m.Resume(0, buffer)
+	return ch, cancel
+}
+
+// Resume mendaftarkan subscriber dan mengembalikan event di buffer setelah
+// event dengan sequence lastSequence, dalam urutan publikasi. ok false berarti
+// event itu sudah tidak ada di buffer sehingga sebagian event terlewat.
+// lastSequence 0 berarti tanpa replay. Channel ditutup ketika subscriber
+// terlalu lambat atau setelah fungsi cancel dipanggil.
+func (m *MemoryPublisher) Resume(lastSequence int64, buffer int) ([]Event, bool, <-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var replay []Event
+	ok := lastSequence == 0
+	if !ok {
+		for i := range m.history {
+			event := m.history[(m.start+i)%len(m.history)]
+			if ok {
+				replay = append(replay, event)
+			} else if event.Sequence == lastSequence {
+				ok = true
+			}
+		}
+	}
+	m.subscribers[ch] = struct{}{}
+
+	return replay, ok, ch, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, ok := m.subscribers[ch]; ok {
+			delete(m.subscribers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const natsTimeout = 5 * time.Second
+
+// NATSPublisher mempublikasikan event ke server NATS dengan protokol teks
+// NATS core, ke subject "<prefix>.<type>" (mis. componenthub.component.created).
+// Setiap publish ditutup PING/PONG supaya error dari server terlihat sebelum
+// event ditandai terpublikasi. Jika server mendukung header, event ID dikirim
+// sebagai Nats-Msg-Id sehingga JetStream bisa membuang duplikat. TLS belum
+// didukung.
+type NATSPublisher struct {
+	addr   string
+	prefix string
+	auth   map[string]string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+	headers bool
+}
+
+type natsInfo struct {
+	Headers     bool `json:"headers"`
+	TLSRequired bool `json:"tls_required"`
+}
+
+// NewNATSPublisher menerima URL nats://[user:pass@|token@]host[:port].
+func NewNATSPublisher(rawURL, prefix string) (*NATSPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" || u.Host == "" {
+		return nil, fmt.Errorf("expected nats://host:port, got %q", rawURL)
+	}
+
+	publisher := &NATSPublisher{addr: u.Host, prefix: strings.TrimSuffix(prefix, "."), auth: map[string]string{}}
+	if u.Port() == "" {
+		publisher.addr = u.Host + ":4222"
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			publisher.auth["user"] = u.User.Username()
+			publisher.auth["pass"] = password
+		} else {
+			publisher.auth["auth_token"] = u.User.Username()
+		}
+	}
+	return publisher, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	subject := event.Type
+	if p.prefix != "" {
+		subject = p.prefix + "." + event.Type
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	var frame string
+	if p.headers {
+		header := "NATS/1.0\r\nNats-Msg-Id: " + event.ID.String() + "\r\n\r\n"
+		frame = fmt.Sprintf("HPUB %s %d %d\r\n%s%s\r\nPING\r\n", subject, len(header), len(header)+len(body), header, body)
+	} else {
+		frame = fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(body), body)
+	}
+	if err := p.roundTrip(ctx, frame); err != nil {
+		p.close()
+		return err
+	}
+	return nil
+}
+
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: natsTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return err
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	conn.SetReadDeadline(time.Now().Add(natsTimeout))
+	line, err := p.readLine()
+	if err != nil {
+		p.close()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		p.close()
+		return fmt.Errorf("nats: unexpected greeting %q", line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		p.close()
+		return fmt.Errorf("nats: invalid INFO: %w", err)
+	}
+	if info.TLSRequired {
+		p.close()
+		return errors.New("nats: server requires TLS, which is not supported")
+	}
+	p.headers = info.Headers
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "componenthub",
+		"lang":     "go",
+		"protocol": 1,
+		"headers":  info.Headers,
+	}
+	for key, value := range p.auth {
+		options[key] = value
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		p.close()
+		return err
+	}
+	if err := p.roundTrip(ctx, "CONNECT "+string(connect)+"\r\nPING\r\n"); err != nil {
+		p.close()
+		return err
+	}
+	return nil
+}
+
+// roundTrip menulis frame yang diakhiri PING lalu menunggu PONG. PING dari
+// server dibalas, dan -ERR dikembalikan sebagai error.
+func (p *NATSPublisher) roundTrip(ctx context.Context, frame string) error {
+	deadline := time.Now().Add(natsTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	p.conn.SetDeadline(deadline)
+
+	if _, err := p.conn.Write([]byte(frame)); err != nil {
+		return err
+	}
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		// +OK dan INFO susulan diabaikan.
+	}
+}
+
+func (p *NATSPublisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *NATSPublisher) close() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn = nil
+	p.reader = nil
+}
@@ -0,0 +1,257 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// natsExchange adalah satu frame yang diharapkan dari client beserta balasan
+// mentah server.
+type natsExchange struct {
+	request string
+	reply   string
+}
+
+// fakeNATS menerima satu koneksi per elemen conns, mengirim greeting, lalu
+// memeriksa byte yang dikirim client persis sama dengan frame golden.
+func fakeNATS(t *testing.T, greeting string, conns ...[]natsExchange) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	accepted := make(chan net.Conn, len(conns))
+	t.Cleanup(func() {
+		ln.Close()
+		<-done
+		close(accepted)
+		for conn := range accepted {
+			conn.Close()
+		}
+	})
+
+	go func() {
+		defer close(done)
+		for _, exchanges := range conns {
+			conn, err := ln.Accept()
+			if err != nil {
+				t.Errorf("accept: %v", err)
+				return
+			}
+			accepted <- conn
+			io.WriteString(conn, greeting)
+			for _, ex := range exchanges {
+				got := make([]byte, len(ex.request))
+				if _, err := io.ReadFull(conn, got); err != nil {
+					t.Errorf("read %q: %v", ex.request, err)
+					break
+				}
+				if string(got) != ex.request {
+					t.Errorf("frame\n got %q\nwant %q", got, ex.request)
+					break
+				}
+				io.WriteString(conn, ex.reply)
+			}
+		}
+	}()
+	return ln.Addr().String()
+}
+
+const (
+	infoHeaders   = `INFO {"server_id":"test","version":"2.10.0","headers":true,"max_payload":1048576}` + "\r\n"
+	infoNoHeaders = `INFO {"server_id":"test","version":"1.4.0","max_payload":1048576}` + "\r\n"
+
+	connectHeaders   = `CONNECT {"headers":true,"lang":"go","name":"componenthub","pedantic":false,"protocol":1,"verbose":false}` + "\r\nPING\r\n"
+	connectNoHeaders = `CONNECT {"headers":false,"lang":"go","name":"componenthub","pedantic":false,"protocol":1,"verbose":false}` + "\r\nPING\r\n"
+
+	testEventBody = `{"id":"11111111-1111-1111-1111-111111111111","sequence":7,"type":"component.created","aggregate_type":"component","aggregate_id":"22222222-2222-2222-2222-222222222222","data":{"slug":"button"},"created_at":"2024-01-01T00:00:00Z"}`
+
+	hpubFrame = "HPUB componenthub.component.created 63 292\r\n" +
+		"NATS/1.0\r\nNats-Msg-Id: 11111111-1111-1111-1111-111111111111\r\n\r\n" +
+		testEventBody + "\r\nPING\r\n"
+	pubFrame = "PUB componenthub.component.created 229\r\n" + testEventBody + "\r\nPING\r\n"
+)
+
+var testEvent = Event{
+	ID:            uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+	Sequence:      7,
+	Type:          "component.created",
+	AggregateType: "component",
+	AggregateID:   uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+	Data:          json.RawMessage(`{"slug":"button"}`),
+	CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestNATSPublishWithHeaders(t *testing.T) {
+	addr := fakeNATS(t, infoHeaders, []natsExchange{
+		{connectHeaders, "PONG\r\n"},
+		{hpubFrame, "PONG\r\n"},
+		// Publish kedua memakai koneksi yang sama tanpa CONNECT ulang.
+		{hpubFrame, "+OK\r\nPONG\r\n"},
+	})
+	publisher, err := NewNATSPublisher("nats://"+addr, "componenthub.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := publisher.Publish(context.Background(), testEvent); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestNATSPublishWithoutHeaders(t *testing.T) {
+	addr := fakeNATS(t, infoNoHeaders, []natsExchange{
+		{connectNoHeaders, "PONG\r\n"},
+		{pubFrame, "PONG\r\n"},
+	})
+	publisher, _ := NewNATSPublisher("nats://"+addr, "componenthub")
+	if err := publisher.Publish(context.Background(), testEvent); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNATSPublishWithoutPrefix(t *testing.T) {
+	addr := fakeNATS(t, infoNoHeaders, []natsExchange{
+		{connectNoHeaders, "PONG\r\n"},
+		{"PUB component.created 229\r\n" + testEventBody + "\r\nPING\r\n", "PONG\r\n"},
+	})
+	publisher, _ := NewNATSPublisher("nats://"+addr, "")
+	if err := publisher.Publish(context.Background(), testEvent); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNATSConnectAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		userinfo string
+		connect  string
+	}{
+		{"user and password", "app:s3cr3t@", `CONNECT {"headers":false,"lang":"go","name":"componenthub","pass":"s3cr3t","pedantic":false,"protocol":1,"user":"app","verbose":false}` + "\r\nPING\r\n"},
+		{"token", "t0ken@", `CONNECT {"auth_token":"t0ken","headers":false,"lang":"go","name":"componenthub","pedantic":false,"protocol":1,"verbose":false}` + "\r\nPING\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := fakeNATS(t, infoNoHeaders, []natsExchange{
+				{tt.connect, "PONG\r\n"},
+				{pubFrame, "PONG\r\n"},
+			})
+			publisher, _ := NewNATSPublisher("nats://"+tt.userinfo+addr, "componenthub")
+			if err := publisher.Publish(context.Background(), testEvent); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNATSAnswersServerPing(t *testing.T) {
+	addr := fakeNATS(t, infoHeaders, []natsExchange{
+		{connectHeaders, "PONG\r\n"},
+		{hpubFrame, "PING\r\n"},
+		{"PONG\r\n", "PONG\r\n"},
+	})
+	publisher, _ := NewNATSPublisher("nats://"+addr, "componenthub")
+	if err := publisher.Publish(context.Background(), testEvent); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNATSServerErrorReconnects(t *testing.T) {
+	addr := fakeNATS(t, infoHeaders,
+		[]natsExchange{
+			{connectHeaders, "PONG\r\n"},
+			{hpubFrame, "-ERR 'Maximum Payload Violation'\r\n"},
+		},
+		// Setelah error koneksi ditutup, jadi publish berikutnya membuka
+		// koneksi baru dan mengirim CONNECT lagi.
+		[]natsExchange{
+			{connectHeaders, "PONG\r\n"},
+			{hpubFrame, "PONG\r\n"},
+		},
+	)
+	publisher, _ := NewNATSPublisher("nats://"+addr, "componenthub")
+
+	err := publisher.Publish(context.Background(), testEvent)
+	if err == nil || err.Error() != "nats: 'Maximum Payload Violation'" {
+		t.Fatalf("err = %v", err)
+	}
+	if publisher.conn != nil {
+		t.Error("koneksi tidak ditutup setelah -ERR")
+	}
+	if err := publisher.Publish(context.Background(), testEvent); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNATSConnectErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		greeting  string
+		exchanges []natsExchange
+		err       string
+	}{
+		{"authorization", infoNoHeaders, []natsExchange{{connectNoHeaders, "-ERR 'Authorization Violation'\r\n"}}, "nats: 'Authorization Violation'"},
+		{"tls required", `INFO {"tls_required":true}` + "\r\n", nil, "nats: server requires TLS, which is not supported"},
+		{"unexpected greeting", "+OK\r\n", nil, `nats: unexpected greeting "+OK"`},
+		{"invalid info", "INFO {\r\n", nil, "nats: invalid INFO: unexpected end of JSON input"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := fakeNATS(t, tt.greeting, tt.exchanges)
+			publisher, _ := NewNATSPublisher("nats://"+addr, "componenthub")
+			err := publisher.Publish(context.Background(), testEvent)
+			if err == nil || err.Error() != tt.err {
+				t.Fatalf("err = %v, want %q", err, tt.err)
+			}
+			if publisher.conn != nil {
+				t.Error("koneksi tidak ditutup")
+			}
+		})
+	}
+}
+
+func TestNewNATSPublisher(t *testing.T) {
+	tests := []struct {
+		url    string
+		addr   string
+		prefix string
+		auth   map[string]string
+		err    bool
+	}{
+		{url: "nats://localhost", addr: "localhost:4222", prefix: "componenthub", auth: map[string]string{}},
+		{url: "nats://nats:4223", addr: "nats:4223", prefix: "componenthub", auth: map[string]string{}},
+		{url: "nats://app:pw@nats", addr: "nats:4222", prefix: "componenthub", auth: map[string]string{"user": "app", "pass": "pw"}},
+		{url: "nats://tok@nats", addr: "nats:4222", prefix: "componenthub", auth: map[string]string{"auth_token": "tok"}},
+		{url: "tls://nats:4222", err: true},
+		{url: "nats://", err: true},
+	}
+	for _, tt := range tests {
+		publisher, err := NewNATSPublisher(tt.url, "componenthub.")
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected error", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.url, err)
+			continue
+		}
+		if publisher.addr != tt.addr || publisher.prefix != tt.prefix || len(publisher.auth) != len(tt.auth) {
+			t.Errorf("%s: addr %q prefix %q auth %v", tt.url, publisher.addr, publisher.prefix, publisher.auth)
+		}
+		for key, value := range tt.auth {
+			if publisher.auth[key] != value {
+				t.Errorf("%s: auth[%s] = %q, want %q", tt.url, key, publisher.auth[key], value)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"service_components/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// publishedSeq memberi nomor urut publikasi event outbox. Dispatcher hanya
+// berjalan di satu instance sekaligus (advisory lock) dan mengambil nomor di
+// dalam transaksi yang memegang lock, jadi nomor yang terlihat setelah commit
+// selalu lebih besar dari nomor yang sudah terlihat sebelumnya. Karena itu
+// Tail cukup menyimpan nomor terakhir sebagai cursor.
+const publishedSeq = "outbox_published_seq"
+
+// Migrate membuat sequence publikasi outbox. Panggil setelah AutoMigrate.
+func Migrate(db *gorm.DB) error {
+	return db.Exec("CREATE SEQUENCE IF NOT EXISTS " + publishedSeq).Error
+}
+
+// Tail mengikuti event yang sudah dipublikasikan dispatcher di tabel outbox
+// dan meneruskannya ke publisher sampai ctx selesai. Tail berjalan di setiap
+// instance, sehingga subscriber lokal (stream SSE lewat Memory) menerima
+// semua event, apa pun instance yang sedang memegang lock dispatcher.
+// Saat mulai, backlog event terakhir diteruskan dulu supaya klien yang
+// tersambung ulang ke instance lain tetap bisa melanjutkan lewat
+// Last-Event-ID.
+func Tail(ctx context.Context, db *gorm.DB, publisher EventPublisher, backlog int) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var cursor int64
+	primed := false
+	for {
+		var err error
+		if !primed {
+			cursor, err = prime(ctx, db, publisher, backlog)
+			primed = err == nil
+		} else {
+			cursor, err = tail(ctx, db, publisher, cursor)
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "outbox tail failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prime meneruskan backlog event terakhir yang sudah dipublikasikan, dari
+// yang terlama, dan mengembalikan cursor setelahnya.
+func prime(ctx context.Context, db *gorm.DB, publisher EventPublisher, backlog int) (int64, error) {
+	var recent []model.OutboxEvent
+	err := db.WithContext(ctx).Where("published_seq IS NOT NULL").
+		Order("published_seq desc").Limit(max(backlog, 1)).Find(&recent).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var cursor int64
+	for i := len(recent) - 1; i >= 0; i-- {
+		if err := publisher.Publish(ctx, fromOutbox(&recent[i])); err != nil {
+			return 0, err
+		}
+		cursor = *recent[i].PublishedSeq
+	}
+	return cursor, nil
+}
+
+// tail meneruskan event yang dipublikasikan setelah cursor sesuai urutan
+// publikasinya dan mengembalikan cursor terbaru.
+func tail(ctx context.Context, db *gorm.DB, publisher EventPublisher, cursor int64) (int64, error) {
+	for {
+		var rows []model.OutboxEvent
+		err := db.WithContext(ctx).Where("published_seq > ?", cursor).
+			Order("published_seq asc").Limit(batchSize).Find(&rows).Error
+		if err != nil {
+			return cursor, err
+		}
+		for i := range rows {
+			if err := publisher.Publish(ctx, fromOutbox(&rows[i])); err != nil {
+				return cursor, err
+			}
+			cursor = *rows[i].PublishedSeq
+		}
+		if len(rows) < batchSize {
+			return cursor, nil
+		}
+	}
+}
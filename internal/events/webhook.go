@@ -0,0 +1,23 @@
+package events
+
+import (
+	"context"
+	"service_components/internal/webhook"
+
+	"gorm.io/gorm"
+)
+
+// WebhookPublisher mengantrikan event sebagai delivery ke webhook yang
+// berlangganan. Pengiriman HTTP-nya dilakukan worker webhook.
+type WebhookPublisher struct {
+	DB *gorm.DB
+}
+
+func (p WebhookPublisher) Publish(ctx context.Context, event Event) error {
+	return webhook.Enqueue(p.DB.WithContext(ctx), webhook.Payload{
+		ID:        event.ID,
+		Event:     event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Data,
+	})
+}
@@ -0,0 +1,163 @@
+package graphql
+
+// Location adalah posisi token di query (1-based), dipakai di pesan error.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Document adalah hasil parse satu query GraphQL.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+type Operation struct {
+	Type       string // query atau mutation
+	Name       string
+	Variables  []*VariableDefinition
+	Directives []*Directive
+	Selections []Selection
+	Loc        Location
+}
+
+type VariableDefinition struct {
+	Name    string
+	Type    TypeRef
+	Default Value
+	Loc     Location
+}
+
+// TypeRef adalah referensi tipe di definisi variable: Named, [Elem] atau
+// Elem! .
+type TypeRef struct {
+	Named   string
+	Elem    *TypeRef
+	NonNull bool
+}
+
+func (t TypeRef) String() string {
+	s := t.Named
+	if t.Elem != nil {
+		s = "[" + t.Elem.String() + "]"
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	Selections    []Selection
+	Loc           Location
+}
+
+// Selection adalah *Field, *FragmentSpread atau *InlineFragment.
+type Selection interface {
+	location() Location
+}
+
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  []*Argument
+	Directives []*Directive
+	Selections []Selection
+	Loc        Location
+}
+
+// ResponseKey adalah nama field di hasil: alias jika ada.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Loc        Location
+}
+
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	Selections    []Selection
+	Loc           Location
+}
+
+func (f *Field) location() Location          { return f.Loc }
+func (f *FragmentSpread) location() Location { return f.Loc }
+func (f *InlineFragment) location() Location { return f.Loc }
+
+type Argument struct {
+	Name  string
+	Value Value
+	Loc   Location
+}
+
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+	Loc       Location
+}
+
+// Value adalah literal di query.
+type Value interface {
+	location() Location
+}
+
+type (
+	Variable struct {
+		Name string
+		Loc  Location
+	}
+	IntValue struct {
+		Raw string
+		Loc Location
+	}
+	FloatValue struct {
+		Raw string
+		Loc Location
+	}
+	StringValue struct {
+		Value string
+		Loc   Location
+	}
+	BooleanValue struct {
+		Value bool
+		Loc   Location
+	}
+	NullValue struct{ Loc Location }
+	EnumValue struct {
+		Value string
+		Loc   Location
+	}
+	ListValue struct {
+		Values []Value
+		Loc    Location
+	}
+	ObjectValue struct {
+		Fields []*ObjectField
+		Loc    Location
+	}
+)
+
+type ObjectField struct {
+	Name  string
+	Value Value
+}
+
+func (v *Variable) location() Location     { return v.Loc }
+func (v *IntValue) location() Location     { return v.Loc }
+func (v *FloatValue) location() Location   { return v.Loc }
+func (v *StringValue) location() Location  { return v.Loc }
+func (v *BooleanValue) location() Location { return v.Loc }
+func (v *NullValue) location() Location    { return v.Loc }
+func (v *EnumValue) location() Location    { return v.Loc }
+func (v *ListValue) location() Location    { return v.Loc }
+func (v *ObjectValue) location() Location  { return v.Loc }
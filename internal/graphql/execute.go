@@ -0,0 +1,374 @@
+// Package graphql adalah engine GraphQL minimal: parser, validasi (termasuk
+// batas kedalaman dan kompleksitas query), introspection dan executor. Field
+// di-resolve per level untuk semua object sekaligus, sehingga resolver bisa
+// memuat relasi secara batch tanpa N+1 query.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime/debug"
+)
+
+// Error adalah error di response GraphQL.
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Result adalah response GraphQL. Data tidak ditulis jika query gagal
+// sebelum dieksekusi (syntax, validasi, batas query), dan ditulis null jika
+// eksekusi gagal di root.
+type Result struct {
+	Data     interface{}
+	Errors   []*Error
+	Executed bool
+}
+
+func (r *Result) MarshalJSON() ([]byte, error) {
+	body := map[string]interface{}{}
+	if r.Executed {
+		body["data"] = r.Data
+	}
+	if len(r.Errors) > 0 {
+		body["errors"] = r.Errors
+	}
+	return json.Marshal(body)
+}
+
+// OrderedMap adalah object hasil yang mempertahankan urutan field sesuai
+// query.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if m.values == nil {
+		m.values = map[string]interface{}{}
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *OrderedMap) Get(key string) interface{} {
+	return m.values[key]
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Context diteruskan ke setiap resolver. Data berisi state per request milik
+// aplikasi, misalnya pemanggil dan Loader.
+type Context struct {
+	context.Context
+	Data      interface{}
+	Operation string
+}
+
+type Params struct {
+	Schema        *Schema
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+	Context       context.Context
+	Data          interface{}
+	// ReadOnly menolak mutation, untuk request GET.
+	ReadOnly bool
+}
+
+// Do mem-parse, memvalidasi dan mengeksekusi satu query.
+func Do(params Params) *Result {
+	doc, err := Parse(params.Query)
+	if err != nil {
+		return &Result{Errors: []*Error{asError(err)}}
+	}
+	plan, errs := prepare(params.Schema, doc, params.OperationName, params.Variables)
+	if errs != nil {
+		return &Result{Errors: errs}
+	}
+	if params.ReadOnly && plan.operation.Type != "query" {
+		return &Result{Errors: []*Error{{Message: "Can only perform a mutation operation from a POST request.", Locations: []Location{plan.operation.Loc}}}}
+	}
+
+	ctx := params.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	e := &executor{
+		schema: params.Schema,
+		doc:    doc,
+		plan:   plan,
+		ctx:    &Context{Context: ctx, Data: params.Data, Operation: plan.operation.Type},
+	}
+	data := e.selectionSet(plan.root, []interface{}{nil}, plan.operation.Selections, [][]interface{}{nil})[0]
+	result := &Result{Executed: true, Errors: e.errors}
+	if _, ok := data.(bubble); !ok {
+		result.Data = data
+	}
+	return result
+}
+
+func asError(err error) *Error {
+	var graphqlErr *Error
+	if errors.As(err, &graphqlErr) {
+		return graphqlErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+// bubble menandai null di posisi non-null: object induknya ikut menjadi null.
+type bubble struct{}
+
+// reportedNull adalah null karena error yang sudah dicatat, supaya NonNull
+// tidak mencatat error kedua.
+type reportedNull struct{}
+
+type executor struct {
+	schema *Schema
+	doc    *Document
+	plan   *prepared
+	ctx    *Context
+	errors []*Error
+}
+
+func (e *executor) report(err error, field *Field, path []interface{}) {
+	graphqlErr := asError(err)
+	reported := *graphqlErr
+	reported.Locations = []Location{field.Loc}
+	reported.Path = path
+	e.errors = append(e.errors, &reported)
+}
+
+// selectionSet mengeksekusi selection untuk semua source di level yang sama
+// sekaligus. Hasilnya *OrderedMap per source, atau bubble jika field non-null
+// di dalamnya bernilai null.
+func (e *executor) selectionSet(t *Object, sources []interface{}, selections []Selection, paths [][]interface{}) []interface{} {
+	results := make([]interface{}, len(sources))
+	objects := make([]*OrderedMap, len(sources))
+	for i := range sources {
+		objects[i] = &OrderedMap{}
+		results[i] = objects[i]
+	}
+
+	groups, err := collectFields(e.schema, e.doc, t, selections, e.plan.variables)
+	if err != nil {
+		e.errors = append(e.errors, err)
+		return results
+	}
+
+	for _, group := range groups {
+		field := group.fields[0]
+		if field.Name == "__typename" {
+			for _, object := range objects {
+				object.Set(group.key, t.Name)
+			}
+			continue
+		}
+		definition := e.schema.fieldDefinition(t, field.Name)
+
+		fieldPaths := make([][]interface{}, len(sources))
+		for i := range sources {
+			fieldPaths[i] = appendPath(paths[i], group.key)
+		}
+
+		values, err := e.resolve(definition, sources, e.plan.args[field])
+		if err != nil {
+			e.report(err, field, fieldPaths[0])
+			values = make([]interface{}, len(sources))
+			for i := range values {
+				values[i] = reportedNull{}
+			}
+		}
+
+		var merged []Selection
+		for _, f := range group.fields {
+			merged = append(merged, f.Selections...)
+		}
+		completed := e.complete(definition.Type, field, merged, fieldPaths, values)
+		for i, value := range completed {
+			switch value.(type) {
+			case bubble:
+				results[i] = bubble{}
+			case reportedNull:
+				objects[i].Set(group.key, nil)
+			default:
+				objects[i].Set(group.key, value)
+			}
+		}
+	}
+	return results
+}
+
+func (e *executor) resolve(definition *FieldDefinition, sources []interface{}, args map[string]interface{}) (values []interface{}, err error) {
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("graphql resolver panicked", "field", definition.Name, "error", r, "stack", string(debug.Stack()))
+			values, err = nil, errors.New("Internal server error")
+		}
+	}()
+	values, err = definition.Resolve(e.ctx, sources, args)
+	if err == nil && len(values) != len(sources) {
+		return nil, fmt.Errorf("resolver of %s returned %d values for %d sources", definition.Name, len(values), len(sources))
+	}
+	return values, err
+}
+
+// complete mengubah nilai hasil resolver menjadi nilai response sesuai tipe.
+// Object di semua posisi (termasuk elemen list) dieksekusi bersama sebagai
+// satu batch.
+func (e *executor) complete(t Type, field *Field, selections []Selection, paths [][]interface{}, values []interface{}) []interface{} {
+	if nonNull, ok := t.(*NonNull); ok {
+		completed := e.complete(nonNull.OfType, field, selections, paths, values)
+		for i, value := range completed {
+			switch value.(type) {
+			case reportedNull:
+				completed[i] = bubble{}
+			case nil:
+				e.report(fmt.Errorf("Cannot return null for non-nullable field %s.", field.Name), field, paths[i])
+				completed[i] = bubble{}
+			}
+		}
+		return completed
+	}
+
+	completed := make([]interface{}, len(values))
+	var present []int
+	for i, value := range values {
+		switch value.(type) {
+		case reportedNull:
+			completed[i] = value
+		default:
+			if !isNil(value) {
+				present = append(present, i)
+			}
+		}
+	}
+
+	switch t := t.(type) {
+	case *List:
+		// Ratakan semua elemen dari semua list supaya dieksekusi sekali.
+		var items []interface{}
+		var itemPaths [][]interface{}
+		owner := map[int][2]int{}
+		for _, i := range present {
+			list := reflect.ValueOf(values[i])
+			if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+				e.report(fmt.Errorf("Expected a list for field %s.", field.Name), field, paths[i])
+				completed[i] = reportedNull{}
+				continue
+			}
+			completed[i] = make([]interface{}, list.Len())
+			for j := 0; j < list.Len(); j++ {
+				owner[len(items)] = [2]int{i, j}
+				items = append(items, list.Index(j).Interface())
+				itemPaths = append(itemPaths, appendPath(paths[i], j))
+			}
+		}
+		done := e.complete(t.OfType, field, selections, itemPaths, items)
+		for k, item := range done {
+			position := owner[k]
+			list, ok := completed[position[0]].([]interface{})
+			if !ok {
+				continue
+			}
+			switch item.(type) {
+			case bubble:
+				completed[position[0]] = reportedNull{}
+			case reportedNull:
+				list[position[1]] = nil
+			default:
+				list[position[1]] = item
+			}
+		}
+	case *Object:
+		sources := make([]interface{}, len(present))
+		sourcePaths := make([][]interface{}, len(present))
+		for k, i := range present {
+			sources[k] = values[i]
+			sourcePaths[k] = paths[i]
+		}
+		done := e.selectionSet(t, sources, selections, sourcePaths)
+		for k, i := range present {
+			if _, ok := done[k].(bubble); ok {
+				completed[i] = reportedNull{}
+			} else {
+				completed[i] = done[k]
+			}
+		}
+	case *Scalar:
+		for _, i := range present {
+			value, err := t.Serialize(values[i])
+			if err != nil {
+				e.report(err, field, paths[i])
+				completed[i] = reportedNull{}
+				continue
+			}
+			completed[i] = value
+		}
+	case *Enum:
+		for _, i := range present {
+			name := fmt.Sprint(values[i])
+			if !enumHas(t, name) {
+				e.report(fmt.Errorf("Enum %q cannot represent value: %q", t.Name, name), field, paths[i])
+				completed[i] = reportedNull{}
+				continue
+			}
+			completed[i] = name
+		}
+	}
+	return completed
+}
+
+func appendPath(path []interface{}, segment interface{}) []interface{} {
+	next := make([]interface{}, len(path)+1)
+	copy(next, path)
+	next[len(path)] = segment
+	return next
+}
+
+// isNil juga menganggap pointer, map dan slice nil sebagai null.
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
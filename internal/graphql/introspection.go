@@ -0,0 +1,271 @@
+package graphql
+
+import (
+	"strings"
+)
+
+// Introspection mengikuti tipe meta di spesifikasi GraphQL (__Schema,
+// __Type, ...) dan diimplementasikan sebagai object biasa di atas struct
+// schema ini, supaya GraphiQL dan code generator bisa membaca schema.
+
+type directiveDefinition struct {
+	Name        string
+	Description string
+	Locations   []string
+	Args        []*ArgumentDefinition
+}
+
+var directives = []*directiveDefinition{
+	{
+		Name:        "include",
+		Description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+		Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		Args:        []*ArgumentDefinition{{Name: "if", Description: "Included when true.", Type: &NonNull{OfType: Boolean}}},
+	},
+	{
+		Name:        "skip",
+		Description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		Args:        []*ArgumentDefinition{{Name: "if", Description: "Skipped when true.", Type: &NonNull{OfType: Boolean}}},
+	},
+	{
+		Name:        "deprecated",
+		Description: "Marks an element of a GraphQL schema as no longer supported.",
+		Locations:   []string{"FIELD_DEFINITION", "ARGUMENT_DEFINITION", "INPUT_FIELD_DEFINITION", "ENUM_VALUE"},
+		Args:        []*ArgumentDefinition{{Name: "reason", Type: String, DefaultValue: "No longer supported"}},
+	},
+}
+
+// fieldDefinition mencari field di tipe t, termasuk __schema dan __type di
+// root query.
+func (s *Schema) fieldDefinition(t *Object, name string) *FieldDefinition {
+	if t == s.Query {
+		switch name {
+		case "__schema":
+			return s.schemaField
+		case "__type":
+			return s.typeField
+		}
+	}
+	return t.Field(name)
+}
+
+func nonNull(t Type) Type { return &NonNull{OfType: t} }
+func listOf(t Type) Type  { return &List{OfType: t} }
+
+func addIntrospection(s *Schema) {
+	typeKind := &Enum{
+		Name:        "__TypeKind",
+		Description: "An enum describing what kind of type a given `__Type` is.",
+	}
+	for _, kind := range []string{"SCALAR", "OBJECT", "INTERFACE", "UNION", "ENUM", "INPUT_OBJECT", "LIST", "NON_NULL"} {
+		typeKind.Values = append(typeKind.Values, &EnumValueDefinition{Name: kind})
+	}
+	directiveLocation := &Enum{
+		Name:        "__DirectiveLocation",
+		Description: "A Directive can be adjacent to many parts of the GraphQL language, a __DirectiveLocation describes one such possible adjacencies.",
+	}
+	for _, location := range []string{
+		"QUERY", "MUTATION", "SUBSCRIPTION", "FIELD", "FRAGMENT_DEFINITION", "FRAGMENT_SPREAD", "INLINE_FRAGMENT", "VARIABLE_DEFINITION",
+		"SCHEMA", "SCALAR", "OBJECT", "FIELD_DEFINITION", "ARGUMENT_DEFINITION", "INTERFACE", "UNION", "ENUM", "ENUM_VALUE", "INPUT_OBJECT", "INPUT_FIELD_DEFINITION",
+	} {
+		directiveLocation.Values = append(directiveLocation.Values, &EnumValueDefinition{Name: location})
+	}
+
+	schemaType := &Object{Name: "__Schema", Description: "A GraphQL Schema defines the capabilities of a GraphQL server."}
+	typeType := &Object{Name: "__Type", Description: "The fundamental unit of any GraphQL Schema is the type."}
+	fieldType := &Object{Name: "__Field", Description: "Object and Interface types are described by a list of Fields, each of which has a name, potentially a list of arguments, and a return type."}
+	inputValueType := &Object{Name: "__InputValue", Description: "Arguments provided to Fields or Directives and the input fields of an InputObject are represented as Input Values which describe their type and optionally a default value."}
+	enumValueType := &Object{Name: "__EnumValue", Description: "One possible value for a given Enum."}
+	directiveType := &Object{Name: "__Directive", Description: "A Directive provides a way to describe alternate runtime execution and type validation behavior in a GraphQL document."}
+
+	includeDeprecated := []*ArgumentDefinition{{Name: "includeDeprecated", Type: Boolean, DefaultValue: false}}
+	optional := func(value string) interface{} {
+		if value == "" {
+			return nil
+		}
+		return value
+	}
+
+	schemaType.Fields = []*FieldDefinition{
+		{Name: "description", Type: String, Resolve: Property(func(*Schema) interface{} { return nil })},
+		{Name: "types", Type: nonNull(listOf(nonNull(typeType))), Resolve: Property(func(schema *Schema) interface{} {
+			types := make([]Type, len(schema.types))
+			for i, t := range schema.types {
+				types[i] = t
+			}
+			return types
+		})},
+		{Name: "queryType", Type: nonNull(typeType), Resolve: Property(func(schema *Schema) interface{} { return schema.Query })},
+		{Name: "mutationType", Type: typeType, Resolve: Property(func(schema *Schema) interface{} {
+			if schema.Mutation == nil {
+				return nil
+			}
+			return schema.Mutation
+		})},
+		{Name: "subscriptionType", Type: typeType, Resolve: Property(func(*Schema) interface{} { return nil })},
+		{Name: "directives", Type: nonNull(listOf(nonNull(directiveType))), Resolve: Property(func(*Schema) interface{} { return directives })},
+	}
+
+	typeType.Fields = []*FieldDefinition{
+		{Name: "kind", Type: nonNull(typeKind), Resolve: Property(func(t Type) interface{} {
+			switch t.(type) {
+			case *Scalar:
+				return "SCALAR"
+			case *Object:
+				return "OBJECT"
+			case *Enum:
+				return "ENUM"
+			case *InputObject:
+				return "INPUT_OBJECT"
+			case *List:
+				return "LIST"
+			case *NonNull:
+				return "NON_NULL"
+			}
+			return nil
+		})},
+		{Name: "name", Type: String, Resolve: Property(func(t Type) interface{} {
+			if named, ok := t.(NamedType); ok {
+				return named.TypeName()
+			}
+			return nil
+		})},
+		{Name: "description", Type: String, Resolve: Property(func(t Type) interface{} {
+			if named, ok := t.(NamedType); ok {
+				return optional(named.TypeDescription())
+			}
+			return nil
+		})},
+		{Name: "specifiedByURL", Type: String, Resolve: Property(func(Type) interface{} { return nil })},
+		{Name: "fields", Type: listOf(nonNull(fieldType)), Args: includeDeprecated, Resolve: perSource(func(t Type, args map[string]interface{}) interface{} {
+			object, ok := t.(*Object)
+			if !ok {
+				return nil
+			}
+			fields := []*FieldDefinition{}
+			for _, field := range object.Fields {
+				if !strings.HasPrefix(field.Name, "__") && (field.DeprecationReason == "" || args["includeDeprecated"] == true) {
+					fields = append(fields, field)
+				}
+			}
+			return fields
+		})},
+		{Name: "interfaces", Type: listOf(nonNull(typeType)), Resolve: Property(func(t Type) interface{} {
+			if _, ok := t.(*Object); ok {
+				return []Type{}
+			}
+			return nil
+		})},
+		{Name: "possibleTypes", Type: listOf(nonNull(typeType)), Resolve: Property(func(Type) interface{} { return nil })},
+		{Name: "enumValues", Type: listOf(nonNull(enumValueType)), Args: includeDeprecated, Resolve: perSource(func(t Type, args map[string]interface{}) interface{} {
+			enum, ok := t.(*Enum)
+			if !ok {
+				return nil
+			}
+			values := []*EnumValueDefinition{}
+			for _, value := range enum.Values {
+				if value.DeprecationReason == "" || args["includeDeprecated"] == true {
+					values = append(values, value)
+				}
+			}
+			return values
+		})},
+		{Name: "inputFields", Type: listOf(nonNull(inputValueType)), Args: includeDeprecated, Resolve: Property(func(t Type) interface{} {
+			if input, ok := t.(*InputObject); ok {
+				return input.Fields
+			}
+			return nil
+		})},
+		{Name: "ofType", Type: typeType, Resolve: Property(func(t Type) interface{} {
+			switch t := t.(type) {
+			case *List:
+				return t.OfType
+			case *NonNull:
+				return t.OfType
+			}
+			return nil
+		})},
+		{Name: "isOneOf", Type: Boolean, Resolve: Property(func(t Type) interface{} {
+			if _, ok := t.(*InputObject); ok {
+				return false
+			}
+			return nil
+		})},
+	}
+
+	fieldType.Fields = []*FieldDefinition{
+		{Name: "name", Type: nonNull(String), Resolve: Property(func(f *FieldDefinition) interface{} { return f.Name })},
+		{Name: "description", Type: String, Resolve: Property(func(f *FieldDefinition) interface{} { return optional(f.Description) })},
+		{Name: "args", Type: nonNull(listOf(nonNull(inputValueType))), Args: includeDeprecated, Resolve: Property(func(f *FieldDefinition) interface{} {
+			if f.Args == nil {
+				return []*ArgumentDefinition{}
+			}
+			return f.Args
+		})},
+		{Name: "type", Type: nonNull(typeType), Resolve: Property(func(f *FieldDefinition) interface{} { return f.Type })},
+		{Name: "isDeprecated", Type: nonNull(Boolean), Resolve: Property(func(f *FieldDefinition) interface{} { return f.DeprecationReason != "" })},
+		{Name: "deprecationReason", Type: String, Resolve: Property(func(f *FieldDefinition) interface{} { return optional(f.DeprecationReason) })},
+	}
+
+	inputValueType.Fields = []*FieldDefinition{
+		{Name: "name", Type: nonNull(String), Resolve: Property(func(a *ArgumentDefinition) interface{} { return a.Name })},
+		{Name: "description", Type: String, Resolve: Property(func(a *ArgumentDefinition) interface{} { return optional(a.Description) })},
+		{Name: "type", Type: nonNull(typeType), Resolve: Property(func(a *ArgumentDefinition) interface{} { return a.Type })},
+		{Name: "defaultValue", Type: String, Resolve: Property(func(a *ArgumentDefinition) interface{} {
+			if a.DefaultValue == nil {
+				return nil
+			}
+			return printValue(a.Type, a.DefaultValue)
+		})},
+		{Name: "isDeprecated", Type: nonNull(Boolean), Resolve: Property(func(*ArgumentDefinition) interface{} { return false })},
+		{Name: "deprecationReason", Type: String, Resolve: Property(func(*ArgumentDefinition) interface{} { return nil })},
+	}
+
+	enumValueType.Fields = []*FieldDefinition{
+		{Name: "name", Type: nonNull(String), Resolve: Property(func(v *EnumValueDefinition) interface{} { return v.Name })},
+		{Name: "description", Type: String, Resolve: Property(func(v *EnumValueDefinition) interface{} { return optional(v.Description) })},
+		{Name: "isDeprecated", Type: nonNull(Boolean), Resolve: Property(func(v *EnumValueDefinition) interface{} { return v.DeprecationReason != "" })},
+		{Name: "deprecationReason", Type: String, Resolve: Property(func(v *EnumValueDefinition) interface{} { return optional(v.DeprecationReason) })},
+	}
+
+	directiveType.Fields = []*FieldDefinition{
+		{Name: "name", Type: nonNull(String), Resolve: Property(func(d *directiveDefinition) interface{} { return d.Name })},
+		{Name: "description", Type: String, Resolve: Property(func(d *directiveDefinition) interface{} { return optional(d.Description) })},
+		{Name: "isRepeatable", Type: nonNull(Boolean), Resolve: Property(func(*directiveDefinition) interface{} { return false })},
+		{Name: "locations", Type: nonNull(listOf(nonNull(directiveLocation))), Resolve: Property(func(d *directiveDefinition) interface{} { return d.Locations })},
+		{Name: "args", Type: nonNull(listOf(nonNull(inputValueType))), Args: includeDeprecated, Resolve: Property(func(d *directiveDefinition) interface{} { return d.Args })},
+	}
+
+	s.schemaField = &FieldDefinition{
+		Name: "__schema",
+		Type: nonNull(schemaType),
+		Resolve: func(_ *Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+			return []interface{}{s}, nil
+		},
+	}
+	s.typeField = &FieldDefinition{
+		Name: "__type",
+		Type: typeType,
+		Args: []*ArgumentDefinition{{Name: "name", Type: nonNull(String)}},
+		Resolve: func(_ *Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+			if t := s.Type(args["name"].(string)); t != nil {
+				return []interface{}{t}, nil
+			}
+			return []interface{}{nil}, nil
+		},
+	}
+	s.add(schemaType)
+	s.add(directiveType)
+}
+
+// perSource membuat ResolveFunc dari fungsi per source yang memakai argumen.
+func perSource[S any](fn func(source S, args map[string]interface{}) interface{}) ResolveFunc {
+	return func(_ *Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+		values := make([]interface{}, len(sources))
+		for i, source := range sources {
+			values[i] = fn(source.(S), args)
+		}
+		return values, nil
+	}
+}
@@ -0,0 +1,56 @@
+package graphql
+
+// Loader memuat nilai per key secara batch dan menyimpannya selama satu
+// request (pola dataloader). Resolver memanggil LoadMany dengan key dari semua
+// source sekaligus, sehingga relasi N object dimuat dengan satu query, dan key
+// yang sudah dimuat di level lain tidak dimuat ulang. Eksekusi query berjalan
+// berurutan, jadi Loader tidak perlu aman untuk goroutine.
+type Loader[K comparable, V any] struct {
+	fetch   func(keys []K) (map[K]V, error)
+	cache   map[K]V
+	fetched map[K]bool
+}
+
+// NewLoader membuat Loader. fetch boleh tidak mengembalikan key yang tidak
+// ditemukan.
+func NewLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, cache: map[K]V{}, fetched: map[K]bool{}}
+}
+
+// LoadMany mengembalikan nilai untuk setiap key yang ditemukan. Hanya key yang
+// belum pernah dimuat yang diteruskan ke fetch, masing-masing sekali.
+func (l *Loader[K, V]) LoadMany(keys []K) (map[K]V, error) {
+	var missing []K
+	for _, key := range keys {
+		if !l.fetched[key] {
+			l.fetched[key] = true
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		values, err := l.fetch(missing)
+		if err != nil {
+			for _, key := range missing {
+				delete(l.fetched, key)
+			}
+			return nil, err
+		}
+		for key, value := range values {
+			l.cache[key] = value
+		}
+	}
+
+	found := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, ok := l.cache[key]; ok {
+			found[key] = value
+		}
+	}
+	return found, nil
+}
+
+// Prime menyimpan nilai yang sudah diketahui, misalnya hasil mutation.
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.cache[key] = value
+	l.fetched[key] = true
+}
@@ -0,0 +1,633 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	loc   Location
+}
+
+// lexer memecah query menjadi token. Koma, spasi dan komentar (#) diabaikan
+// seperti di spesifikasi GraphQL.
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func (l *lexer) errorf(loc Location, format string, args ...interface{}) error {
+	return &Error{Message: "Syntax Error: " + fmt.Sprintf(format, args...), Locations: []Location{loc}}
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n; i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		ch := l.src[l.pos]
+		if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',' {
+			l.advance(1)
+			continue
+		}
+		if ch == '#' {
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+			continue
+		}
+		break
+	}
+
+	loc := Location{Line: l.line, Column: l.col}
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, loc: loc}, nil
+	}
+
+	ch := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		return token{kind: tokenPunct, value: "...", loc: loc}, nil
+	case strings.IndexByte("!$&():=@[]{}|", ch) >= 0:
+		l.advance(1)
+		return token{kind: tokenPunct, value: string(ch), loc: loc}, nil
+	case ch == '_' || isLetter(ch):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], loc: loc}, nil
+	case ch == '-' || isDigit(ch):
+		return l.number(loc)
+	case ch == '"':
+		return l.string(loc)
+	}
+	return token{}, l.errorf(loc, "unexpected character %q", ch)
+}
+
+func (l *lexer) number(loc Location) (token, error) {
+	start := l.pos
+	kind := tokenInt
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.advance(1)
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return token{}, l.errorf(loc, "invalid number")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.advance(1)
+		if digits() == 0 {
+			return token{}, l.errorf(loc, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if digits() == 0 {
+			return token{}, l.errorf(loc, "invalid number")
+		}
+	}
+	return token{kind: kind, value: l.src[start:l.pos], loc: loc}, nil
+}
+
+func (l *lexer) string(loc Location) (token, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		return l.blockString(loc)
+	}
+	l.advance(1)
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		ch := l.src[l.pos]
+		switch {
+		case ch == '"':
+			l.advance(1)
+			return token{kind: tokenString, value: b.String(), loc: loc}, nil
+		case ch == '\n' || ch == '\r':
+			return token{}, l.errorf(loc, "unterminated string")
+		case ch == '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, l.errorf(loc, "unterminated string")
+			}
+			escape := l.src[l.pos+1]
+			replacement, ok := map[byte]string{'"': `"`, '\\': `\`, '/': "/", 'b': "\b", 'f': "\f", 'n': "\n", 'r': "\r", 't': "\t"}[escape]
+			if ok {
+				b.WriteString(replacement)
+				l.advance(2)
+				continue
+			}
+			if escape != 'u' || l.pos+6 > len(l.src) {
+				return token{}, l.errorf(loc, "invalid escape sequence")
+			}
+			code, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 32)
+			if err != nil {
+				return token{}, l.errorf(loc, "invalid unicode escape")
+			}
+			b.WriteRune(rune(code))
+			l.advance(6)
+		default:
+			_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteString(l.src[l.pos : l.pos+size])
+			l.advance(size)
+		}
+	}
+	return token{}, l.errorf(loc, "unterminated string")
+}
+
+// blockString membaca """...""" dan membuang indentasi bersama seperti di
+// spesifikasi.
+func (l *lexer) blockString(loc Location) (token, error) {
+	l.advance(3)
+	start := l.pos
+	for l.pos < len(l.src) {
+		if strings.HasPrefix(l.src[l.pos:], `\"""`) {
+			l.advance(4)
+			continue
+		}
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			raw := strings.ReplaceAll(l.src[start:l.pos], `\"""`, `"""`)
+			l.advance(3)
+			return token{kind: tokenString, value: dedentBlock(raw), loc: loc}, nil
+		}
+		l.advance(1)
+	}
+	return token{}, l.errorf(loc, "unterminated block string")
+}
+
+func dedentBlock(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = strings.TrimLeft(lines[i], " \t")
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isLetter(ch byte) bool { return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' }
+func isDigit(ch byte) bool  { return ch >= '0' && ch <= '9' }
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+// Parse mem-parse executable document (operation dan fragment).
+func Parse(query string) (*Document, error) {
+	p := &parser{lex: &lexer{src: strings.TrimPrefix(query, "\uFEFF"), line: 1, col: 1}}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: map[string]*Fragment{}}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"), p.tok.kind == tokenName && (p.tok.value == "query" || p.tok.value == "mutation" || p.tok.value == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.tok.kind == tokenName && p.tok.value == "fragment":
+			fragment, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[fragment.Name]; ok {
+				return nil, &Error{Message: fmt.Sprintf("There can be only one fragment named %q.", fragment.Name), Locations: []Location{fragment.Loc}}
+			}
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, &Error{Message: "Document does not contain any operation"}
+	}
+	return doc, nil
+}
+
+func (p *parser) read() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokenPunct && p.tok.value == punct
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return p.lex.errorf(p.tok.loc, "unexpected end of document")
+	}
+	return p.lex.errorf(p.tok.loc, "unexpected %q", p.tok.value)
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.peek(punct) {
+		if p.tok.kind == tokenEOF {
+			return p.lex.errorf(p.tok.loc, "expected %q, found end of document", punct)
+		}
+		return p.lex.errorf(p.tok.loc, "expected %q, found %q", punct, p.tok.value)
+	}
+	return p.read()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.read()
+}
+
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Type: "query", Loc: p.tok.loc}
+	if p.tok.kind == tokenName {
+		op.Type = p.tok.value
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName {
+			op.Name = p.tok.value
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+		}
+		if p.peek("(") {
+			variables, err := p.variableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = variables
+		}
+		directives, err := p.directives()
+		if err != nil {
+			return nil, err
+		}
+		op.Directives = directives
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = selections
+	return op, nil
+}
+
+func (p *parser) variableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var definitions []*VariableDefinition
+	for !p.peek(")") {
+		definition := &VariableDefinition{Loc: p.tok.loc}
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		definition.Name = name
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if definition.Type, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		if p.peek("=") {
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			if definition.Default, err = p.value(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.directives(); err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, p.read()
+}
+
+func (p *parser) typeRef() (TypeRef, error) {
+	var ref TypeRef
+	if p.peek("[") {
+		if err := p.read(); err != nil {
+			return ref, err
+		}
+		elem, err := p.typeRef()
+		if err != nil {
+			return ref, err
+		}
+		ref.Elem = &elem
+		if err := p.expect("]"); err != nil {
+			return ref, err
+		}
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return ref, err
+		}
+		ref.Named = name
+	}
+	if p.peek("!") {
+		ref.NonNull = true
+		return ref, p.read()
+	}
+	return ref, nil
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	fragment := &Fragment{Loc: p.tok.loc}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, p.lex.errorf(fragment.Loc, "fragment cannot be named \"on\"")
+	}
+	fragment.Name = name
+	if p.tok.kind != tokenName || p.tok.value != "on" {
+		return nil, p.unexpected()
+	}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	if fragment.TypeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if fragment.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if fragment.Selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return fragment, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []Selection
+	for !p.peek("}") {
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	if len(selections) == 0 {
+		return nil, p.unexpected()
+	}
+	return selections, p.read()
+}
+
+func (p *parser) selection() (Selection, error) {
+	loc := p.tok.loc
+	if p.peek("...") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			spread := &FragmentSpread{Name: p.tok.value, Loc: loc}
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			directives, err := p.directives()
+			spread.Directives = directives
+			return spread, err
+		}
+
+		inline := &InlineFragment{Loc: loc}
+		if p.tok.kind == tokenName && p.tok.value == "on" {
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			inline.TypeCondition = name
+		}
+		var err error
+		if inline.Directives, err = p.directives(); err != nil {
+			return nil, err
+		}
+		if inline.Selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+		return inline, nil
+	}
+
+	field := &Field{Loc: loc}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	field.Name = name
+	if p.peek(":") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		field.Alias = name
+		if field.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		if field.Arguments, err = p.arguments(); err != nil {
+			return nil, err
+		}
+	}
+	if field.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if field.Selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) arguments() ([]*Argument, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var arguments []*Argument
+	for !p.peek(")") {
+		argument := &Argument{Loc: p.tok.loc}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		argument.Name = name
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if argument.Value, err = p.value(false); err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+	if len(arguments) == 0 {
+		return nil, p.unexpected()
+	}
+	return arguments, p.read()
+}
+
+func (p *parser) directives() ([]*Directive, error) {
+	var directives []*Directive
+	for p.peek("@") {
+		directive := &Directive{Loc: p.tok.loc}
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		directive.Name = name
+		if p.peek("(") {
+			if directive.Arguments, err = p.arguments(); err != nil {
+				return nil, err
+			}
+		}
+		directives = append(directives, directive)
+	}
+	return directives, nil
+}
+
+// value membaca literal. const true melarang variable (nilai default).
+func (p *parser) value(constant bool) (Value, error) {
+	tok := p.tok
+	loc := tok.loc
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return &Variable{Name: name, Loc: loc}, err
+	case p.peek("["):
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		list := &ListValue{Loc: loc}
+		for !p.peek("]") {
+			value, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list.Values = append(list.Values, value)
+		}
+		return list, p.read()
+	case p.peek("{"):
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		object := &ObjectValue{Loc: loc}
+		for !p.peek("}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			value, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			object.Fields = append(object.Fields, &ObjectField{Name: name, Value: value})
+		}
+		return object, p.read()
+	case tok.kind == tokenInt:
+		return &IntValue{Raw: tok.value, Loc: loc}, p.read()
+	case tok.kind == tokenFloat:
+		return &FloatValue{Raw: tok.value, Loc: loc}, p.read()
+	case tok.kind == tokenString:
+		return &StringValue{Value: tok.value, Loc: loc}, p.read()
+	case tok.kind == tokenName:
+		var value Value
+		switch tok.value {
+		case "true", "false":
+			value = &BooleanValue{Value: tok.value == "true", Loc: loc}
+		case "null":
+			value = &NullValue{Loc: loc}
+		default:
+			value = &EnumValue{Value: tok.value, Loc: loc}
+		}
+		return value, p.read()
+	}
+	return nil, p.unexpected()
+}
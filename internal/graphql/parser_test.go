@@ -0,0 +1,253 @@
+package graphql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *Document
+	}{
+		{
+			name:  "shorthand query",
+			query: "{ hello }",
+			want: &Document{
+				Operations: []*Operation{{
+					Type:       "query",
+					Selections: []Selection{&Field{Name: "hello", Loc: Location{1, 3}}},
+					Loc:        Location{1, 1},
+				}},
+				Fragments: map[string]*Fragment{},
+			},
+		},
+		{
+			name:  "variables, alias, arguments and directives",
+			query: `query Q($id: ID!, $tags: [String!] = ["a"]) { c: component(id: $id) @include(if: true) { name } }`,
+			want: &Document{
+				Operations: []*Operation{{
+					Type: "query",
+					Name: "Q",
+					Variables: []*VariableDefinition{
+						{Name: "id", Type: TypeRef{Named: "ID", NonNull: true}, Loc: Location{1, 9}},
+						{
+							Name:    "tags",
+							Type:    TypeRef{Elem: &TypeRef{Named: "String", NonNull: true}},
+							Default: &ListValue{Values: []Value{&StringValue{Value: "a", Loc: Location{1, 39}}}, Loc: Location{1, 38}},
+							Loc:     Location{1, 19},
+						},
+					},
+					Selections: []Selection{&Field{
+						Alias:      "c",
+						Name:       "component",
+						Arguments:  []*Argument{{Name: "id", Value: &Variable{Name: "id", Loc: Location{1, 64}}, Loc: Location{1, 60}}},
+						Directives: []*Directive{{Name: "include", Arguments: []*Argument{{Name: "if", Value: &BooleanValue{Value: true, Loc: Location{1, 82}}, Loc: Location{1, 78}}}, Loc: Location{1, 69}}},
+						Selections: []Selection{&Field{Name: "name", Loc: Location{1, 90}}},
+						Loc:        Location{1, 47},
+					}},
+					Loc: Location{1, 1},
+				}},
+				Fragments: map[string]*Fragment{},
+			},
+		},
+		{
+			name:  "fragments",
+			query: "query { ...F ... on Query { a } ... @skip(if: false) { b } } fragment F on Query { c }",
+			want: &Document{
+				Operations: []*Operation{{
+					Type: "query",
+					Selections: []Selection{
+						&FragmentSpread{Name: "F", Loc: Location{1, 9}},
+						&InlineFragment{TypeCondition: "Query", Selections: []Selection{&Field{Name: "a", Loc: Location{1, 29}}}, Loc: Location{1, 14}},
+						&InlineFragment{
+							Directives: []*Directive{{Name: "skip", Arguments: []*Argument{{Name: "if", Value: &BooleanValue{Value: false, Loc: Location{1, 47}}, Loc: Location{1, 43}}}, Loc: Location{1, 37}}},
+							Selections: []Selection{&Field{Name: "b", Loc: Location{1, 56}}},
+							Loc:        Location{1, 33},
+						},
+					},
+					Loc: Location{1, 1},
+				}},
+				Fragments: map[string]*Fragment{
+					"F": {Name: "F", TypeCondition: "Query", Selections: []Selection{&Field{Name: "c", Loc: Location{1, 84}}}, Loc: Location{1, 62}},
+				},
+			},
+		},
+		{
+			name:  "comments, commas, line breaks and BOM",
+			query: "\uFEFF# list\nmutation M {\n  a,, # trailing\n  b\n}",
+			want: &Document{
+				Operations: []*Operation{{
+					Type:       "mutation",
+					Name:       "M",
+					Selections: []Selection{&Field{Name: "a", Loc: Location{3, 3}}, &Field{Name: "b", Loc: Location{4, 3}}},
+					Loc:        Location{2, 1},
+				}},
+				Fragments: map[string]*Fragment{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) mismatch", tt.query)
+				for i := range min(len(got.Operations), len(tt.want.Operations)) {
+					t.Logf("operation %d\n got %+v\nwant %+v", i, got.Operations[i], tt.want.Operations[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	tests := []struct {
+		literal string
+		want    Value
+	}{
+		{"0", &IntValue{Raw: "0"}},
+		{"-12", &IntValue{Raw: "-12"}},
+		{"1.5", &FloatValue{Raw: "1.5"}},
+		{"-1.5e-3", &FloatValue{Raw: "-1.5e-3"}},
+		{"2E10", &FloatValue{Raw: "2E10"}},
+		{`"plain"`, &StringValue{Value: "plain"}},
+		{`""`, &StringValue{Value: ""}},
+		{`"esc \" \\ \/ \b \f \n \r \t"`, &StringValue{Value: "esc \" \\ / \b \f \n \r \t"}},
+		{`"\u00e9\u4E2D"`, &StringValue{Value: "é中"}},
+		{`"héllo 🙂"`, &StringValue{Value: "héllo 🙂"}},
+		{"\"\"\"\n    first\n      indented\n\n    last\n  \"\"\"", &StringValue{Value: "first\n  indented\n\nlast"}},
+		{`"""raw \n and \""" quote"""`, &StringValue{Value: `raw \n and """ quote`}},
+		{"true", &BooleanValue{Value: true}},
+		{"false", &BooleanValue{Value: false}},
+		{"null", &NullValue{}},
+		{"PUBLISHED", &EnumValue{Value: "PUBLISHED"}},
+		{"$v", &Variable{Name: "v"}},
+		{"[]", &ListValue{}},
+		{`[1, "x", [true]]`, &ListValue{Values: []Value{&IntValue{Raw: "1"}, &StringValue{Value: "x"}, &ListValue{Values: []Value{&BooleanValue{Value: true}}}}}},
+		{"{}", &ObjectValue{}},
+		{"{a: 1 b: {c: $v}}", &ObjectValue{Fields: []*ObjectField{
+			{Name: "a", Value: &IntValue{Raw: "1"}},
+			{Name: "b", Value: &ObjectValue{Fields: []*ObjectField{{Name: "c", Value: &Variable{Name: "v"}}}}},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.literal, func(t *testing.T) {
+			doc, err := Parse("{ f(a: " + tt.literal + ") }")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := withoutLocations(doc.Operations[0].Selections[0].(*Field).Arguments[0].Value)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %s (%T), want %s (%T)", printAST(got), got, printAST(tt.want), tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeRefString(t *testing.T) {
+	doc, err := Parse("query ($a: Int, $b: Int!, $c: [Int], $d: [Int!]!, $e: [[String]!]) { a }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Int", "Int!", "[Int]", "[Int!]!", "[[String]!]"}
+	for i, definition := range doc.Operations[0].Variables {
+		if got := definition.Type.String(); got != want[i] {
+			t.Errorf("$%s: %s, want %s", definition.Name, got, want[i])
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		message string
+		loc     *Location
+	}{
+		{"", "Document does not contain any operation", nil},
+		{"fragment F on Query { a }", "Document does not contain any operation", nil},
+		{"{ hello", "Syntax Error: unexpected end of document", &Location{1, 8}},
+		{"{}", `Syntax Error: unexpected "}"`, &Location{1, 2}},
+		{"{ a }}", `Syntax Error: unexpected "}"`, &Location{1, 6}},
+		{"{ a ^ }", `Syntax Error: unexpected character '^'`, &Location{1, 5}},
+		{"{ a() }", `Syntax Error: unexpected ")"`, &Location{1, 5}},
+		{"{ a(x: 1 }", `Syntax Error: unexpected "}"`, &Location{1, 10}},
+		{"{ a(x: [1, 2) }", `Syntax Error: unexpected ")"`, &Location{1, 13}},
+		{"{ a(x: {b 1}) }", `Syntax Error: expected ":", found "1"`, &Location{1, 11}},
+		{"query Q($a Int) { a }", `Syntax Error: expected ":", found "Int"`, &Location{1, 12}},
+		{"query Q($a: Int = $b) { a }", `Syntax Error: unexpected "$"`, &Location{1, 19}},
+		{"query Q($a: [Int) { a }", `Syntax Error: expected "]", found ")"`, &Location{1, 17}},
+		{"query Q($a: Int", `Syntax Error: expected "$", found end of document`, &Location{1, 16}},
+		{"{ a(x: 1.) }", "Syntax Error: invalid number", &Location{1, 8}},
+		{"{ a(x: -) }", "Syntax Error: invalid number", &Location{1, 8}},
+		{"{ a(x: 1e) }", "Syntax Error: invalid number", &Location{1, 8}},
+		{`{ a(x: "abc) }`, "Syntax Error: unterminated string", &Location{1, 8}},
+		{"{ a(x: \"ab\nc\") }", "Syntax Error: unterminated string", &Location{1, 8}},
+		{`{ a(x: "a\qb") }`, "Syntax Error: invalid escape sequence", &Location{1, 8}},
+		{`{ a(x: "\u12") }`, "Syntax Error: invalid unicode escape", &Location{1, 8}},
+		{`{ a(x: "\u12G4") }`, "Syntax Error: invalid unicode escape", &Location{1, 8}},
+		{`{ a(x: """abc) }`, "Syntax Error: unterminated block string", &Location{1, 8}},
+		{"fragment on on Query { a } { a }", `Syntax Error: fragment cannot be named "on"`, &Location{1, 1}},
+		{"fragment F Query { a } { a }", `Syntax Error: unexpected "Query"`, &Location{1, 12}},
+		{"fragment F on Query { a } fragment F on Query { b } { a }", `There can be only one fragment named "F".`, &Location{1, 27}},
+		{"{\n  a\n  b(\n}", `Syntax Error: unexpected "}"`, &Location{4, 1}},
+		{"schema { query: Query }", `Syntax Error: unexpected "schema"`, &Location{1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			var got *Error
+			if !errors.As(err, &got) {
+				t.Fatalf("err = %v, want *Error", err)
+			}
+			if got.Message != tt.message {
+				t.Errorf("message = %q, want %q", got.Message, tt.message)
+			}
+			var wantLocations []Location
+			if tt.loc != nil {
+				wantLocations = []Location{*tt.loc}
+			}
+			if !reflect.DeepEqual(got.Locations, wantLocations) {
+				t.Errorf("locations = %v, want %v", got.Locations, wantLocations)
+			}
+		})
+	}
+}
+
+// withoutLocations menyalin literal dengan semua Loc dikosongkan supaya
+// tabel test tidak perlu menghitung kolom.
+func withoutLocations(value Value) Value {
+	switch v := value.(type) {
+	case *Variable:
+		return &Variable{Name: v.Name}
+	case *IntValue:
+		return &IntValue{Raw: v.Raw}
+	case *FloatValue:
+		return &FloatValue{Raw: v.Raw}
+	case *StringValue:
+		return &StringValue{Value: v.Value}
+	case *BooleanValue:
+		return &BooleanValue{Value: v.Value}
+	case *NullValue:
+		return &NullValue{}
+	case *EnumValue:
+		return &EnumValue{Value: v.Value}
+	case *ListValue:
+		list := &ListValue{}
+		for _, item := range v.Values {
+			list.Values = append(list.Values, withoutLocations(item))
+		}
+		return list
+	case *ObjectValue:
+		object := &ObjectValue{}
+		for _, field := range v.Fields {
+			object.Fields = append(object.Fields, &ObjectField{Name: field.Name, Value: withoutLocations(field.Value)})
+		}
+		return object
+	}
+	return value
+}
@@ -0,0 +1,356 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Type adalah tipe GraphQL: *Scalar, *Enum, *Object, *InputObject, *List
+// atau *NonNull.
+type Type interface {
+	String() string
+}
+
+// NamedType adalah tipe yang punya nama dan terdaftar di schema.
+type NamedType interface {
+	Type
+	TypeName() string
+	TypeDescription() string
+}
+
+// Scalar mengubah nilai Go menjadi JSON (Serialize) dan nilai input dari
+// variable atau literal menjadi nilai Go (Parse). Literal dikirim ke Parse
+// dengan bentuk yang sama seperti hasil decode JSON; angka sebagai
+// json.Number.
+type Scalar struct {
+	Name        string
+	Description string
+	Serialize   func(value interface{}) (interface{}, error)
+	Parse       func(value interface{}) (interface{}, error)
+}
+
+type Enum struct {
+	Name        string
+	Description string
+	Values      []*EnumValueDefinition
+}
+
+type EnumValueDefinition struct {
+	Name              string
+	Description       string
+	DeprecationReason string
+}
+
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*FieldDefinition
+
+	fields map[string]*FieldDefinition
+}
+
+type InputObject struct {
+	Name        string
+	Description string
+	Fields      []*ArgumentDefinition
+}
+
+type List struct{ OfType Type }
+
+type NonNull struct{ OfType Type }
+
+func (t *Scalar) String() string      { return t.Name }
+func (t *Enum) String() string        { return t.Name }
+func (t *Object) String() string      { return t.Name }
+func (t *InputObject) String() string { return t.Name }
+func (t *List) String() string        { return "[" + t.OfType.String() + "]" }
+func (t *NonNull) String() string     { return t.OfType.String() + "!" }
+
+func (t *Scalar) TypeName() string             { return t.Name }
+func (t *Enum) TypeName() string               { return t.Name }
+func (t *Object) TypeName() string             { return t.Name }
+func (t *InputObject) TypeName() string        { return t.Name }
+func (t *Scalar) TypeDescription() string      { return t.Description }
+func (t *Enum) TypeDescription() string        { return t.Description }
+func (t *Object) TypeDescription() string      { return t.Description }
+func (t *InputObject) TypeDescription() string { return t.Description }
+
+// Field mencari definisi field berdasarkan nama. Index-nya dibuat oleh
+// NewSchema.
+func (t *Object) Field(name string) *FieldDefinition {
+	return t.fields[name]
+}
+
+// ResolveFunc me-resolve satu field untuk sekumpulan source sekaligus dan
+// mengembalikan satu nilai per source dengan urutan yang sama. Semua object
+// di level yang sama di-resolve dalam satu panggilan, jadi resolver bisa
+// memuat relasi dengan satu query (lihat Loader).
+type ResolveFunc func(ctx *Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error)
+
+// ComplexityFunc menghitung biaya field dari argumennya dan biaya
+// sub-selection-nya.
+type ComplexityFunc func(args map[string]interface{}, childComplexity int) int
+
+type FieldDefinition struct {
+	Name              string
+	Description       string
+	Type              Type
+	Args              []*ArgumentDefinition
+	Resolve           ResolveFunc
+	Complexity        ComplexityFunc
+	DeprecationReason string
+}
+
+type ArgumentDefinition struct {
+	Name        string
+	Description string
+	Type        Type
+	// DefaultValue dipakai jika argumen tidak diisi; nil berarti tanpa
+	// default.
+	DefaultValue interface{}
+}
+
+// Property membuat ResolveFunc dari fungsi per source, untuk field yang
+// nilainya sudah ada di source.
+func Property[S any](fn func(source S) interface{}) ResolveFunc {
+	return func(_ *Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+		values := make([]interface{}, len(sources))
+		for i, source := range sources {
+			values[i] = fn(source.(S))
+		}
+		return values, nil
+	}
+}
+
+// Schema adalah root Query dan Mutation beserta semua tipe yang terjangkau
+// darinya.
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+
+	types []NamedType
+	named map[string]NamedType
+
+	schemaField *FieldDefinition
+	typeField   *FieldDefinition
+}
+
+// NewSchema mengumpulkan tipe dari root dan menambahkan field introspection.
+// Nama tipe ganda adalah kesalahan program, jadi menyebabkan panic.
+func NewSchema(query, mutation *Object) *Schema {
+	s := &Schema{Query: query, Mutation: mutation, named: map[string]NamedType{}}
+	for _, scalar := range []*Scalar{Int, Float, String, Boolean, ID} {
+		s.add(scalar)
+	}
+	s.add(query)
+	if mutation != nil {
+		s.add(mutation)
+	}
+	addIntrospection(s)
+	return s
+}
+
+func (s *Schema) add(t Type) {
+	switch t := t.(type) {
+	case *List:
+		s.add(t.OfType)
+		return
+	case *NonNull:
+		s.add(t.OfType)
+		return
+	}
+
+	named := t.(NamedType)
+	if existing, ok := s.named[named.TypeName()]; ok {
+		if existing != named {
+			panic(fmt.Sprintf("graphql: duplicate type %s", named.TypeName()))
+		}
+		return
+	}
+	s.named[named.TypeName()] = named
+	s.types = append(s.types, named)
+
+	switch t := t.(type) {
+	case *Object:
+		t.fields = map[string]*FieldDefinition{}
+		for _, field := range t.Fields {
+			t.fields[field.Name] = field
+			s.add(field.Type)
+			for _, arg := range field.Args {
+				s.add(arg.Type)
+			}
+		}
+	case *InputObject:
+		for _, field := range t.Fields {
+			s.add(field.Type)
+		}
+	}
+}
+
+// Type mencari tipe berdasarkan nama.
+func (s *Schema) Type(name string) NamedType {
+	return s.named[name]
+}
+
+// unwrap melepas List dan NonNull sampai tipe bernama.
+func unwrap(t Type) NamedType {
+	for {
+		switch inner := t.(type) {
+		case *List:
+			t = inner.OfType
+		case *NonNull:
+			t = inner.OfType
+		default:
+			return t.(NamedType)
+		}
+	}
+}
+
+func isInput(t Type) bool {
+	switch unwrap(t).(type) {
+	case *Scalar, *Enum, *InputObject:
+		return true
+	}
+	return false
+}
+
+// Scalar bawaan GraphQL.
+var (
+	Int = &Scalar{
+		Name:        "Int",
+		Description: "The `Int` scalar type represents non-fractional signed whole numeric values between -(2^31) and 2^31 - 1.",
+		Serialize: func(value interface{}) (interface{}, error) {
+			n, ok := toInt64(value)
+			if !ok || n > math.MaxInt32 || n < math.MinInt32 {
+				return nil, fmt.Errorf("Int cannot represent value: %v", value)
+			}
+			return n, nil
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			number, ok := value.(json.Number)
+			if !ok {
+				return nil, fmt.Errorf("Int cannot represent non-integer value: %s", describe(value))
+			}
+			n, err := strconv.ParseInt(string(number), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %s", number)
+			}
+			return int(n), nil
+		},
+	}
+	Float = &Scalar{
+		Name:        "Float",
+		Description: "The `Float` scalar type represents signed double-precision fractional values as specified by IEEE 754.",
+		Serialize: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case float64:
+				return v, nil
+			case float32:
+				return float64(v), nil
+			}
+			if n, ok := toInt64(value); ok {
+				return float64(n), nil
+			}
+			return nil, fmt.Errorf("Float cannot represent value: %v", value)
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			number, ok := value.(json.Number)
+			if !ok {
+				return nil, fmt.Errorf("Float cannot represent non numeric value: %s", describe(value))
+			}
+			return number.Float64()
+		},
+	}
+	String = &Scalar{
+		Name:        "String",
+		Description: "The `String` scalar type represents textual data, represented as UTF-8 character sequences.",
+		Serialize: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case string:
+				return v, nil
+			case fmt.Stringer:
+				return v.String(), nil
+			}
+			return nil, fmt.Errorf("String cannot represent value: %v", value)
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("String cannot represent a non string value: %s", describe(value))
+			}
+			return s, nil
+		},
+	}
+	Boolean = &Scalar{
+		Name:        "Boolean",
+		Description: "The `Boolean` scalar type represents `true` or `false`.",
+		Serialize: func(value interface{}) (interface{}, error) {
+			b, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("Boolean cannot represent value: %v", value)
+			}
+			return b, nil
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			b, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %s", describe(value))
+			}
+			return b, nil
+		},
+	}
+	ID = &Scalar{
+		Name:        "ID",
+		Description: "The `ID` scalar type represents a unique identifier, serialized as a string.",
+		Serialize: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case string:
+				return v, nil
+			case fmt.Stringer:
+				return v.String(), nil
+			}
+			if n, ok := toInt64(value); ok {
+				return strconv.FormatInt(n, 10), nil
+			}
+			return nil, fmt.Errorf("ID cannot represent value: %v", value)
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case string:
+				return v, nil
+			case json.Number:
+				if _, err := v.Int64(); err == nil {
+					return string(v), nil
+				}
+			}
+			return nil, fmt.Errorf("ID cannot represent value: %s", describe(value))
+		},
+	}
+)
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// describe menampilkan nilai input di pesan error.
+func describe(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(raw)
+}
@@ -0,0 +1,426 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"service_components/internal/config"
+	"strings"
+)
+
+// Batas query. Field introspection (__schema, __type) tidak ikut dihitung
+// supaya tooling seperti GraphiQL tetap bisa membaca schema.
+var (
+	MaxDepth      = 10
+	MaxComplexity = 2000
+)
+
+func InitGraphQL(cfg *config.Config) {
+	if cfg.GraphQLMaxDepth > 0 {
+		MaxDepth = cfg.GraphQLMaxDepth
+	}
+	if cfg.GraphQLMaxComplexity > 0 {
+		MaxComplexity = cfg.GraphQLMaxComplexity
+	}
+}
+
+// fieldGroup adalah field dengan response key yang sama di satu selection
+// set; sub-selection-nya digabung.
+type fieldGroup struct {
+	key    string
+	fields []*Field
+}
+
+// prepared adalah operation yang sudah divalidasi beserta argumen setiap
+// field.
+type prepared struct {
+	operation *Operation
+	root      *Object
+	variables map[string]interface{}
+	args      map[*Field]map[string]interface{}
+}
+
+type validator struct {
+	schema    *Schema
+	doc       *Document
+	variables map[string]interface{}
+	defined   map[string]bool
+	args      map[*Field]map[string]interface{}
+	errors    []*Error
+	depth     int
+}
+
+func (v *validator) errorf(loc Location, format string, args ...interface{}) {
+	v.errors = append(v.errors, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}})
+}
+
+// prepare memilih operation, mengisi variable dan memvalidasi query terhadap
+// schema serta batas depth dan complexity.
+func prepare(schema *Schema, doc *Document, operationName string, rawVariables map[string]interface{}) (*prepared, []*Error) {
+	var operation *Operation
+	for _, op := range doc.Operations {
+		if operationName == "" || op.Name == operationName {
+			if operation != nil {
+				return nil, []*Error{{Message: "Must provide operation name if query contains multiple operations."}}
+			}
+			operation = op
+		}
+	}
+	if operation == nil {
+		return nil, []*Error{{Message: fmt.Sprintf("Unknown operation named %q.", operationName)}}
+	}
+
+	var root *Object
+	switch operation.Type {
+	case "query":
+		root = schema.Query
+	case "mutation":
+		root = schema.Mutation
+	}
+	if root == nil {
+		return nil, []*Error{{Message: fmt.Sprintf("Schema is not configured for %s operations.", operation.Type), Locations: []Location{operation.Loc}}}
+	}
+
+	v := &validator{schema: schema, doc: doc, defined: map[string]bool{}, args: map[*Field]map[string]interface{}{}}
+	v.fragmentCycles()
+	v.coerceVariables(operation, rawVariables)
+	if len(v.errors) > 0 {
+		return nil, v.errors
+	}
+
+	complexity := v.selectionSet(root, operation.Selections, 1, false)
+	if len(v.errors) > 0 {
+		return nil, v.errors
+	}
+	if MaxDepth > 0 && v.depth > MaxDepth {
+		return nil, []*Error{{Message: fmt.Sprintf("Query depth %d exceeds the maximum of %d.", v.depth, MaxDepth), Extensions: map[string]interface{}{"code": "QUERY_TOO_DEEP"}}}
+	}
+	if MaxComplexity > 0 && complexity > MaxComplexity {
+		return nil, []*Error{{Message: fmt.Sprintf("Query complexity %d exceeds the maximum of %d.", complexity, MaxComplexity), Extensions: map[string]interface{}{"code": "QUERY_TOO_COMPLEX"}}}
+	}
+	return &prepared{operation: operation, root: root, variables: v.variables, args: v.args}, nil
+}
+
+func (v *validator) coerceVariables(operation *Operation, raw map[string]interface{}) {
+	v.variables = map[string]interface{}{}
+	for _, definition := range operation.Variables {
+		if v.defined[definition.Name] {
+			v.errorf(definition.Loc, "There can be only one variable named \"$%s\".", definition.Name)
+			continue
+		}
+		v.defined[definition.Name] = true
+
+		t, err := v.typeFromRef(definition.Type)
+		if err != nil {
+			v.errorf(definition.Loc, "Variable \"$%s\": %v", definition.Name, err)
+			continue
+		}
+
+		value, ok := raw[definition.Name]
+		if !ok {
+			if definition.Default != nil {
+				coerced, _, err := coerceLiteral(t, definition.Default, nil)
+				if err != nil {
+					v.errorf(definition.Loc, "Variable \"$%s\" has invalid default value: %v", definition.Name, err)
+					continue
+				}
+				v.variables[definition.Name] = coerced
+			} else if _, nonNull := t.(*NonNull); nonNull {
+				v.errorf(definition.Loc, "Variable \"$%s\" of required type \"%s\" was not provided.", definition.Name, t)
+			}
+			continue
+		}
+		coerced, err := coerceInput(t, value)
+		if err != nil {
+			v.errorf(definition.Loc, "Variable \"$%s\" got invalid value %s; %v", definition.Name, describe(value), err)
+			continue
+		}
+		v.variables[definition.Name] = coerced
+	}
+}
+
+func (v *validator) typeFromRef(ref TypeRef) (Type, error) {
+	var t Type
+	if ref.Elem != nil {
+		elem, err := v.typeFromRef(*ref.Elem)
+		if err != nil {
+			return nil, err
+		}
+		t = &List{OfType: elem}
+	} else {
+		named := v.schema.Type(ref.Named)
+		if named == nil {
+			return nil, fmt.Errorf("unknown type %q", ref.Named)
+		}
+		if !isInput(named) {
+			return nil, fmt.Errorf("type %q is not an input type", ref.Named)
+		}
+		t = named
+	}
+	if ref.NonNull {
+		t = &NonNull{OfType: t}
+	}
+	return t, nil
+}
+
+// fragmentCycles menolak fragment yang menyebar dirinya sendiri, langsung
+// maupun lewat fragment lain.
+func (v *validator) fragmentCycles() {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var visit func(fragment *Fragment) bool
+	visit = func(fragment *Fragment) bool {
+		switch state[fragment.Name] {
+		case visiting:
+			v.errorf(fragment.Loc, "Cannot spread fragment %q within itself.", fragment.Name)
+			return false
+		case done:
+			return true
+		}
+		state[fragment.Name] = visiting
+		for _, name := range spreads(fragment.Selections) {
+			if next, ok := v.doc.Fragments[name]; ok && !visit(next) {
+				return false
+			}
+		}
+		state[fragment.Name] = done
+		return true
+	}
+	for _, fragment := range v.doc.Fragments {
+		if !visit(fragment) {
+			return
+		}
+	}
+}
+
+func variablesIn(value Value) []string {
+	switch v := value.(type) {
+	case *Variable:
+		return []string{v.Name}
+	case *ListValue:
+		var names []string
+		for _, item := range v.Values {
+			names = append(names, variablesIn(item)...)
+		}
+		return names
+	case *ObjectValue:
+		var names []string
+		for _, field := range v.Fields {
+			names = append(names, variablesIn(field.Value)...)
+		}
+		return names
+	}
+	return nil
+}
+
+func spreads(selections []Selection) []string {
+	var names []string
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *Field:
+			names = append(names, spreads(s.Selections)...)
+		case *FragmentSpread:
+			names = append(names, s.Name)
+		case *InlineFragment:
+			names = append(names, spreads(s.Selections)...)
+		}
+	}
+	return names
+}
+
+// selectionSet memvalidasi selection di tipe t dan mengembalikan complexity-nya.
+func (v *validator) selectionSet(t *Object, selections []Selection, depth int, introspection bool) int {
+	groups, err := collectFields(v.schema, v.doc, t, selections, v.variables)
+	if err != nil {
+		v.errors = append(v.errors, err)
+		return 0
+	}
+
+	complexity := 0
+	for _, group := range groups {
+		first := group.fields[0]
+		for _, field := range group.fields[1:] {
+			if field.Name != first.Name {
+				v.errorf(field.Loc, "Fields %q conflict because %q and %q are different fields.", group.key, first.Name, field.Name)
+			}
+		}
+		if first.Name == "__typename" {
+			for _, field := range group.fields {
+				if len(field.Selections) > 0 {
+					v.errorf(field.Loc, "Field \"__typename\" must not have a selection since type \"String!\" has no subfields.")
+				}
+			}
+			continue
+		}
+
+		definition := v.schema.fieldDefinition(t, first.Name)
+		if definition == nil {
+			v.errorf(first.Loc, "Cannot query field %q on type %q.", first.Name, t.Name)
+			continue
+		}
+
+		var args map[string]interface{}
+		invalidArgs := false
+		for i, field := range group.fields {
+			for _, argument := range field.Arguments {
+				for _, name := range variablesIn(argument.Value) {
+					if !v.defined[name] {
+						v.errorf(argument.Loc, "Variable \"$%s\" is not defined.", name)
+					}
+				}
+			}
+			coerced, err := coerceArguments(definition.Args, field.Arguments, v.variables)
+			if err != nil {
+				v.errorf(field.Loc, "Field %q: %v", field.Name, err)
+				invalidArgs = true
+				continue
+			}
+			if i > 0 && !reflect.DeepEqual(coerced, args) {
+				v.errorf(field.Loc, "Fields %q conflict because they have differing arguments.", group.key)
+			}
+			args = coerced
+			v.args[field] = coerced
+		}
+
+		var merged []Selection
+		for _, field := range group.fields {
+			merged = append(merged, field.Selections...)
+		}
+		meta := introspection || strings.HasPrefix(first.Name, "__")
+		if !meta && depth > v.depth {
+			v.depth = depth
+		}
+
+		child := 0
+		if object, ok := unwrap(definition.Type).(*Object); ok {
+			if len(merged) == 0 {
+				v.errorf(first.Loc, "Field %q of type %q must have a selection of subfields.", first.Name, definition.Type)
+				continue
+			}
+			// Query yang sudah terlalu dalam tidak perlu ditelusuri lagi.
+			if meta || MaxDepth <= 0 || depth <= MaxDepth {
+				child = v.selectionSet(object, merged, depth+1, meta)
+			}
+		} else if len(merged) > 0 {
+			v.errorf(first.Loc, "Field %q must not have a selection since type %q has no subfields.", first.Name, definition.Type)
+			continue
+		}
+
+		// Query yang argumennya tidak valid pasti ditolak; ComplexityFunc
+		// tidak dipanggil dengan argumen yang belum dikoersi.
+		if meta || invalidArgs {
+			continue
+		}
+		if definition.Complexity != nil {
+			complexity += definition.Complexity(args, child)
+		} else {
+			complexity += 1 + child
+		}
+	}
+	return complexity
+}
+
+// collectFields meratakan fragment dan mengelompokkan field berdasarkan
+// response key, dengan urutan kemunculan pertama. Field dengan @skip(if:
+// true) atau @include(if: false) dibuang.
+func collectFields(schema *Schema, doc *Document, t *Object, selections []Selection, variables map[string]interface{}) ([]*fieldGroup, *Error) {
+	var groups []*fieldGroup
+	index := map[string]*fieldGroup{}
+	visited := map[string]bool{}
+
+	var collect func(selections []Selection) *Error
+	collect = func(selections []Selection) *Error {
+		for _, selection := range selections {
+			var directives []*Directive
+			switch s := selection.(type) {
+			case *Field:
+				directives = s.Directives
+			case *FragmentSpread:
+				directives = s.Directives
+			case *InlineFragment:
+				directives = s.Directives
+			}
+			include, err := shouldInclude(directives, variables)
+			if err != nil {
+				return err
+			}
+			if !include {
+				continue
+			}
+
+			switch s := selection.(type) {
+			case *Field:
+				key := s.ResponseKey()
+				group, ok := index[key]
+				if !ok {
+					group = &fieldGroup{key: key}
+					index[key] = group
+					groups = append(groups, group)
+				}
+				group.fields = append(group.fields, s)
+			case *FragmentSpread:
+				fragment, ok := doc.Fragments[s.Name]
+				if !ok {
+					return &Error{Message: fmt.Sprintf("Unknown fragment %q.", s.Name), Locations: []Location{s.Loc}}
+				}
+				if visited[s.Name] {
+					continue
+				}
+				visited[s.Name] = true
+				if err := typeCondition(schema, t, fragment.TypeCondition, s.Loc); err != nil {
+					return err
+				}
+				if err := collect(fragment.Selections); err != nil {
+					return err
+				}
+			case *InlineFragment:
+				if s.TypeCondition != "" {
+					if err := typeCondition(schema, t, s.TypeCondition, s.Loc); err != nil {
+						return err
+					}
+				}
+				if err := collect(s.Selections); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := collect(selections); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// typeCondition memastikan fragment berlaku untuk tipe t. Schema ini tidak
+// punya interface atau union, jadi kondisinya harus tipe t sendiri.
+func typeCondition(schema *Schema, t *Object, condition string, loc Location) *Error {
+	if schema.Type(condition) == nil {
+		return &Error{Message: fmt.Sprintf("Unknown type %q.", condition), Locations: []Location{loc}}
+	}
+	if condition != t.Name {
+		return &Error{Message: fmt.Sprintf("Fragment cannot be spread here as objects of type %q can never be of type %q.", t.Name, condition), Locations: []Location{loc}}
+	}
+	return nil
+}
+
+func shouldInclude(directives []*Directive, variables map[string]interface{}) (bool, *Error) {
+	for _, directive := range directives {
+		if directive.Name != "skip" && directive.Name != "include" {
+			return false, &Error{Message: fmt.Sprintf("Unknown directive \"@%s\".", directive.Name), Locations: []Location{directive.Loc}}
+		}
+		args, err := coerceArguments(directiveIf, directive.Arguments, variables)
+		if err != nil {
+			return false, &Error{Message: fmt.Sprintf("Directive \"@%s\": %v", directive.Name, err), Locations: []Location{directive.Loc}}
+		}
+		condition := args["if"].(bool)
+		if directive.Name == "skip" && condition || directive.Name == "include" && !condition {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+var directiveIf = []*ArgumentDefinition{{Name: "if", Type: &NonNull{OfType: Boolean}}}
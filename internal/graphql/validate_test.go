@@ -0,0 +1,275 @@
+package graphql
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// newTestSchema membuat schema kecil yang mencakup scalar, enum, input
+// object, list, non-null, relasi rekursif dan complexity per field.
+func newTestSchema() *Schema {
+	status := &Enum{Name: "Status", Values: []*EnumValueDefinition{{Name: "DRAFT"}, {Name: "PUBLISHED"}}}
+	filter := &InputObject{Name: "Filter", Fields: []*ArgumentDefinition{
+		{Name: "search", Type: String},
+		{Name: "status", Type: status, DefaultValue: "PUBLISHED"},
+		{Name: "tags", Type: listOf(nonNull(String))},
+	}}
+	user := &Object{Name: "User", Fields: []*FieldDefinition{{Name: "name", Type: nonNull(String)}}}
+	// Seperti pageSize di handler: first null dianggap 10.
+	perItem := func(args map[string]interface{}, child int) int {
+		first, ok := args["first"].(int)
+		if !ok {
+			first = 10
+		}
+		return 1 + first*child
+	}
+
+	component := &Object{Name: "Component"}
+	component.Fields = []*FieldDefinition{
+		{Name: "id", Type: nonNull(ID)},
+		{Name: "name", Type: nonNull(String)},
+		{Name: "status", Type: status},
+		{Name: "owner", Type: user},
+		{
+			Name:       "related",
+			Type:       nonNull(listOf(nonNull(component))),
+			Args:       []*ArgumentDefinition{{Name: "first", Type: Int, DefaultValue: 5}},
+			Complexity: perItem,
+		},
+	}
+
+	query := &Object{Name: "Query", Fields: []*FieldDefinition{
+		{Name: "hello", Type: String, Args: []*ArgumentDefinition{{Name: "name", Type: String, DefaultValue: "world"}}},
+		{Name: "version", Type: String},
+		{Name: "component", Type: component, Args: []*ArgumentDefinition{{Name: "id", Type: nonNull(ID)}}},
+		{
+			Name: "components",
+			Type: nonNull(listOf(nonNull(component))),
+			Args: []*ArgumentDefinition{
+				{Name: "first", Type: Int, DefaultValue: 10},
+				{Name: "filter", Type: filter},
+			},
+			Complexity: perItem,
+		},
+	}}
+	mutation := &Object{Name: "Mutation", Fields: []*FieldDefinition{
+		{Name: "rename", Type: component, Args: []*ArgumentDefinition{{Name: "id", Type: nonNull(ID)}, {Name: "name", Type: nonNull(String)}}},
+	}}
+	return NewSchema(query, mutation)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		variables map[string]interface{}
+		errors    []string
+	}{
+		{name: "field", query: "{ hello }"},
+		{name: "nested selection", query: "{ component(id: 1) { id name owner { name } } }"},
+		{name: "typename", query: "{ __typename component(id: \"c1\") { __typename } }"},
+		{name: "fragments", query: "{ ...Q ... on Query { version } } fragment Q on Query { hello }"},
+		{name: "same alias and arguments", query: `{ a: hello(name: "x") a: hello(name: "x") b: hello }`},
+		{name: "mutation", query: `mutation { rename(id: 1, name: "x") { id } }`},
+		{name: "variable", query: "query ($id: ID!) { component(id: $id) { id } }", variables: map[string]interface{}{"id": "c1"}},
+		{name: "variable default", query: "query ($n: Int = 3) { components(first: $n) { id } }"},
+		{name: "enum and input object", query: `{ components(filter: {search: "btn", status: DRAFT, tags: "ui"}) { id status } }`},
+		{name: "skip with variable", query: "query ($s: Boolean!) { nope @skip(if: $s) hello }", variables: map[string]interface{}{"s": true}},
+		{name: "include false", query: "{ nope @include(if: false) hello }"},
+		{name: "named operation", query: "query A { hello } query B { version }", operation: "B"},
+
+		{name: "unknown field", query: "{ nope }", errors: []string{`Cannot query field "nope" on type "Query".`}},
+		{name: "unknown nested field", query: "{ component(id: 1) { owner { email } } }", errors: []string{`Cannot query field "email" on type "User".`}},
+		{name: "missing subselection", query: "{ component(id: 1) }", errors: []string{`Field "component" of type "Component" must have a selection of subfields.`}},
+		{name: "selection on scalar", query: "{ hello { length } }", errors: []string{`Field "hello" must not have a selection since type "String" has no subfields.`}},
+		{name: "selection on typename", query: "{ __typename { x } }", errors: []string{`Field "__typename" must not have a selection since type "String!" has no subfields.`}},
+		{name: "missing required argument", query: "{ component { id } }", errors: []string{`Field "component": argument "id": required value of type ID! was not provided`}},
+		{name: "null required argument", query: "{ component(id: null) { id } }", errors: []string{`Field "component": argument "id": expected value of type ID!, found null`}},
+		{name: "unknown argument", query: "{ hello(nope: 1) }", errors: []string{`Field "hello": unknown argument "nope"`}},
+		{name: "duplicate argument", query: `{ hello(name: "a", name: "b") }`, errors: []string{`Field "hello": there can be only one argument named "name"`}},
+		{name: "wrong scalar", query: "{ hello(name: 1) }", errors: []string{`Field "hello": argument "name": String cannot represent a non string value: 1`}},
+		{name: "enum as string", query: "{ hello(name: WORLD) }", errors: []string{`Field "hello": argument "name": String cannot represent an enum value: WORLD`}},
+		{name: "int overflow", query: "{ components(first: 3000000000) { id } }", errors: []string{`Field "components": argument "first": Int cannot represent non 32-bit signed integer value: 3000000000`}},
+		{name: "float as int", query: "{ components(first: 1.5) { id } }", errors: []string{`Field "components": argument "first": Int cannot represent non 32-bit signed integer value: 1.5`}},
+		{name: "unknown enum value", query: "{ components(filter: {status: DELETED}) { id } }", errors: []string{`Field "components": argument "filter": field Filter.status: value DELETED does not exist in "Status" enum`}},
+		{name: "string as enum", query: `{ components(filter: {status: "DRAFT"}) { id } }`, errors: []string{`Field "components": argument "filter": field Filter.status: value "DRAFT" does not exist in "Status" enum`}},
+		{name: "unknown input field", query: `{ components(filter: {colour: "red"}) { id } }`, errors: []string{`Field "components": argument "filter": field "colour" is not defined by type Filter`}},
+		{name: "scalar as input object", query: `{ components(filter: "btn") { id } }`, errors: []string{`Field "components": argument "filter": expected value of type Filter, found "btn"`}},
+		{name: "null in non-null list", query: `{ components(filter: {tags: ["a", null]}) { id } }`, errors: []string{`Field "components": argument "filter": field Filter.tags: expected value of type String!, found null`}},
+		{name: "conflicting fields", query: "{ x: hello x: version }", errors: []string{`Fields "x" conflict because "hello" and "version" are different fields.`}},
+		{name: "conflicting arguments", query: `{ hello(name: "a") hello(name: "b") }`, errors: []string{`Fields "hello" conflict because they have differing arguments.`}},
+		{name: "undefined variable", query: "{ hello(name: $n) }", errors: []string{`Variable "$n" is not defined.`}},
+		{name: "duplicate variable", query: "query ($a: String, $a: String) { hello }", errors: []string{`There can be only one variable named "$a".`}},
+		{name: "unknown variable type", query: "query ($a: Nope) { hello }", errors: []string{`Variable "$a": unknown type "Nope"`}},
+		{name: "output variable type", query: "query ($a: Component) { hello }", errors: []string{`Variable "$a": type "Component" is not an input type`}},
+		{name: "missing required variable", query: "query ($id: ID!) { component(id: $id) { id } }", errors: []string{`Variable "$id" of required type "ID!" was not provided.`}},
+		{
+			name:      "invalid variable value",
+			query:     "query ($n: Int) { components(first: $n) { id } }",
+			variables: map[string]interface{}{"n": "x"},
+			errors:    []string{`Variable "$n" got invalid value "x"; Int cannot represent non-integer value: "x"`},
+		},
+		{
+			name:      "invalid input object variable",
+			query:     "query ($f: Filter) { components(filter: $f) { id } }",
+			variables: map[string]interface{}{"f": map[string]interface{}{"tags": []interface{}{"a", json.Number("1")}}},
+			errors:    []string{`Variable "$f" got invalid value {"tags":["a",1]}; field Filter.tags: at index 1: String cannot represent a non string value: 1`},
+		},
+		{name: "invalid variable default", query: `query ($n: Int = "x") { components(first: $n) { id } }`, errors: []string{`Variable "$n" has invalid default value: Int cannot represent non-integer value: "x"`}},
+		{name: "fragment cycle", query: "{ ...A } fragment A on Query { hello ...A }", errors: []string{`Cannot spread fragment "A" within itself.`}},
+		{name: "unknown fragment", query: "{ ...Nope }", errors: []string{`Unknown fragment "Nope".`}},
+		{name: "unknown type condition", query: "{ ... on Nope { hello } }", errors: []string{`Unknown type "Nope".`}},
+		{name: "wrong type condition", query: "{ ... on Component { id } }", errors: []string{`Fragment cannot be spread here as objects of type "Query" can never be of type "Component".`}},
+		{name: "unknown directive", query: "{ hello @deprecated }", errors: []string{`Unknown directive "@deprecated".`}},
+		{name: "directive without if", query: "{ hello @skip }", errors: []string{`Directive "@skip": argument "if": required value of type Boolean! was not provided`}},
+		{name: "anonymous operation among many", query: "query A { hello } query B { version }", errors: []string{"Must provide operation name if query contains multiple operations."}},
+		{name: "unknown operation", query: "query A { hello }", operation: "C", errors: []string{`Unknown operation named "C".`}},
+		{name: "subscription", query: "subscription { hello }", errors: []string{"Schema is not configured for subscription operations."}},
+		{
+			name:   "errors are collected",
+			query:  "{ nope component(id: 1) { email } hello(name: 1) }",
+			errors: []string{`Cannot query field "nope" on type "Query".`, `Cannot query field "email" on type "Component".`, `Field "hello": argument "name": String cannot represent a non string value: 1`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, errs := prepare(newTestSchema(), doc, tt.operation, tt.variables)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Message)
+			}
+			if !reflect.DeepEqual(got, tt.errors) {
+				t.Errorf("errors\n got %q\nwant %q", got, tt.errors)
+			}
+		})
+	}
+}
+
+func TestValidateErrorLocations(t *testing.T) {
+	doc, err := Parse("{\n  hello\n  nope\n}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, errs := prepare(newTestSchema(), doc, "", nil)
+	if len(errs) != 1 || !reflect.DeepEqual(errs[0].Locations, []Location{{3, 3}}) {
+		t.Errorf("errors = %+v, want one at 3:3", errs)
+	}
+}
+
+func TestCoercedArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      map[string]interface{}
+	}{
+		{
+			name:  "defaults",
+			query: "{ components { id } }",
+			want:  map[string]interface{}{"first": 10},
+		},
+		{
+			name:  "input object defaults and list coercion",
+			query: `{ components(first: 2, filter: {search: "btn", tags: "ui"}) { id } }`,
+			want: map[string]interface{}{"first": 2, "filter": map[string]interface{}{
+				"search": "btn", "status": "PUBLISHED", "tags": []interface{}{"ui"},
+			}},
+		},
+		{
+			name:      "variables",
+			query:     "query ($n: Int, $s: String) { components(first: $n, filter: {search: $s}) { id } }",
+			variables: map[string]interface{}{"n": json.Number("4")},
+			// $s tidak diisi, jadi search tidak ada; first memakai nilai $n.
+			want: map[string]interface{}{"first": 4, "filter": map[string]interface{}{"status": "PUBLISHED"}},
+		},
+		{
+			name:      "explicit null variable",
+			query:     "query ($n: Int) { components(first: $n) { id } }",
+			variables: map[string]interface{}{"n": nil},
+			want:      map[string]interface{}{"first": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			plan, errs := prepare(newTestSchema(), doc, "", tt.variables)
+			if errs != nil {
+				t.Fatalf("errors: %v", errs[0])
+			}
+			field := doc.Operations[0].Selections[0].(*Field)
+			if got := plan.args[field]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("args = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplexitySkipsInvalidArguments(t *testing.T) {
+	query := &Object{Name: "Query", Fields: []*FieldDefinition{{
+		Name: "items",
+		Type: listOf(String),
+		Args: []*ArgumentDefinition{{Name: "first", Type: nonNull(Int)}},
+		Complexity: func(args map[string]interface{}, child int) int {
+			t.Errorf("Complexity dipanggil dengan argumen %v", args)
+			return 1
+		},
+	}}}
+	doc, err := Parse(`{ items(first: "ten") }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, errs := prepare(NewSchema(query, nil), doc, "", nil)
+	if len(errs) != 1 {
+		t.Errorf("errors = %v, want 1", errs)
+	}
+}
+
+func TestQueryLimits(t *testing.T) {
+	maxDepth, maxComplexity := MaxDepth, MaxComplexity
+	t.Cleanup(func() { MaxDepth, MaxComplexity = maxDepth, maxComplexity })
+	MaxDepth, MaxComplexity = 3, 100
+
+	tests := []struct {
+		name  string
+		query string
+		code  string
+		err   string
+	}{
+		{name: "depth at limit", query: "{ component(id: 1) { related { id } } }"},
+		{name: "too deep", query: "{ component(id: 1) { related { related { id } } } }", code: "QUERY_TOO_DEEP", err: "Query depth 4 exceeds the maximum of 3."},
+		{name: "fragments count toward depth", query: "{ component(id: 1) { ...C } } fragment C on Component { related { related { id } } }", code: "QUERY_TOO_DEEP", err: "Query depth 4 exceeds the maximum of 3."},
+		{name: "introspection is not counted", query: "{ __schema { types { fields { type { ofType { name } } } } } }"},
+		// 1 + 10 * (1 + 5*2) = 111
+		{name: "too complex", query: "{ components { related { id name } } }", code: "QUERY_TOO_COMPLEX", err: "Query complexity 111 exceeds the maximum of 100."},
+		// 1 + 9 * (1 + 5*2) = 100
+		{name: "complexity at limit", query: "{ components(first: 9) { related { id name } } }"},
+		{name: "complexity uses variables", query: "query ($n: Int = 60) { components(first: $n) { id name } }", code: "QUERY_TOO_COMPLEX", err: "Query complexity 121 exceeds the maximum of 100."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, errs := prepare(newTestSchema(), doc, "", nil)
+			if tt.err == "" {
+				if errs != nil {
+					t.Fatalf("errors: %v", errs[0])
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Message != tt.err || errs[0].Extensions["code"] != tt.code {
+				t.Errorf("errors = %+v, want %s %q", errs, tt.code, tt.err)
+			}
+		})
+	}
+}
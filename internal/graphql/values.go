@@ -0,0 +1,347 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// coerceInput mengubah nilai variable (hasil decode JSON dengan UseNumber)
+// menjadi nilai Go sesuai tipe input.
+func coerceInput(t Type, value interface{}) (interface{}, error) {
+	if nonNull, ok := t.(*NonNull); ok {
+		if value == nil {
+			return nil, fmt.Errorf("expected non-null value of type %s", t)
+		}
+		return coerceInput(nonNull.OfType, value)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		items, ok := value.([]interface{})
+		if !ok {
+			item, err := coerceInput(t.OfType, value)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+		coerced := make([]interface{}, len(items))
+		for i, item := range items {
+			var err error
+			if coerced[i], err = coerceInput(t.OfType, item); err != nil {
+				return nil, fmt.Errorf("at index %d: %w", i, err)
+			}
+		}
+		return coerced, nil
+	case *InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object of type %s, found %s", t.Name, describe(value))
+		}
+		for name := range fields {
+			if inputField(t, name) == nil {
+				return nil, fmt.Errorf("field %q is not defined by type %s", name, t.Name)
+			}
+		}
+		coerced := map[string]interface{}{}
+		for _, field := range t.Fields {
+			raw, ok := fields[field.Name]
+			if !ok {
+				if err := applyDefault(coerced, field); err != nil {
+					return nil, fmt.Errorf("field %s.%s: %w", t.Name, field.Name, err)
+				}
+				continue
+			}
+			item, err := coerceInput(field.Type, raw)
+			if err != nil {
+				return nil, fmt.Errorf("field %s.%s: %w", t.Name, field.Name, err)
+			}
+			coerced[field.Name] = item
+		}
+		return coerced, nil
+	case *Enum:
+		name, ok := value.(string)
+		if !ok || !enumHas(t, name) {
+			return nil, fmt.Errorf("value %s does not exist in %q enum", describe(value), t.Name)
+		}
+		return name, nil
+	case *Scalar:
+		return t.Parse(value)
+	}
+	return nil, fmt.Errorf("%s is not an input type", t)
+}
+
+// coerceLiteral mengubah literal di query menjadi nilai Go sesuai tipe.
+// present false berarti nilai tidak ada (variable yang tidak diisi), sehingga
+// default argumen yang dipakai.
+func coerceLiteral(t Type, value Value, variables map[string]interface{}) (interface{}, bool, error) {
+	if variable, ok := value.(*Variable); ok {
+		coerced, present := variables[variable.Name]
+		if _, nonNull := t.(*NonNull); nonNull && coerced == nil {
+			if !present {
+				return nil, false, fmt.Errorf("variable \"$%s\" of required type %s was not provided", variable.Name, t)
+			}
+			return nil, true, fmt.Errorf("variable \"$%s\" must not be null", variable.Name)
+		}
+		return coerced, present, nil
+	}
+
+	if nonNull, ok := t.(*NonNull); ok {
+		if _, null := value.(*NullValue); null {
+			return nil, true, fmt.Errorf("expected value of type %s, found null", t)
+		}
+		return coerceLiteral(nonNull.OfType, value, variables)
+	}
+	if _, null := value.(*NullValue); null {
+		return nil, true, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		list, ok := value.(*ListValue)
+		if !ok {
+			item, _, err := coerceLiteral(t.OfType, value, variables)
+			if err != nil {
+				return nil, true, err
+			}
+			return []interface{}{item}, true, nil
+		}
+		coerced := make([]interface{}, len(list.Values))
+		for i, item := range list.Values {
+			var err error
+			if coerced[i], _, err = coerceLiteral(t.OfType, item, variables); err != nil {
+				return nil, true, err
+			}
+		}
+		return coerced, true, nil
+	case *InputObject:
+		object, ok := value.(*ObjectValue)
+		if !ok {
+			return nil, true, fmt.Errorf("expected value of type %s, found %s", t.Name, printAST(value))
+		}
+		given := map[string]Value{}
+		for _, field := range object.Fields {
+			if inputField(t, field.Name) == nil {
+				return nil, true, fmt.Errorf("field %q is not defined by type %s", field.Name, t.Name)
+			}
+			given[field.Name] = field.Value
+		}
+		coerced := map[string]interface{}{}
+		for _, field := range t.Fields {
+			literal, ok := given[field.Name]
+			if ok {
+				item, present, err := coerceLiteral(field.Type, literal, variables)
+				if err != nil {
+					return nil, true, fmt.Errorf("field %s.%s: %w", t.Name, field.Name, err)
+				}
+				if present {
+					coerced[field.Name] = item
+					continue
+				}
+			}
+			if err := applyDefault(coerced, field); err != nil {
+				return nil, true, fmt.Errorf("field %s.%s: %w", t.Name, field.Name, err)
+			}
+		}
+		return coerced, true, nil
+	case *Enum:
+		enum, ok := value.(*EnumValue)
+		if !ok || !enumHas(t, enum.Value) {
+			return nil, true, fmt.Errorf("value %s does not exist in %q enum", printAST(value), t.Name)
+		}
+		return enum.Value, true, nil
+	case *Scalar:
+		if _, enum := value.(*EnumValue); enum {
+			return nil, true, fmt.Errorf("%s cannot represent an enum value: %s", t.Name, printAST(value))
+		}
+		raw, err := literalValue(value, variables)
+		if err != nil {
+			return nil, true, err
+		}
+		coerced, err := t.Parse(raw)
+		return coerced, true, err
+	}
+	return nil, true, fmt.Errorf("%s is not an input type", t)
+}
+
+// literalValue mengubah literal menjadi bentuk yang sama dengan hasil decode
+// JSON, untuk diteruskan ke Scalar.Parse.
+func literalValue(value Value, variables map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case *Variable:
+		return variables[v.Name], nil
+	case *IntValue:
+		return json.Number(v.Raw), nil
+	case *FloatValue:
+		return json.Number(v.Raw), nil
+	case *StringValue:
+		return v.Value, nil
+	case *BooleanValue:
+		return v.Value, nil
+	case *NullValue:
+		return nil, nil
+	case *EnumValue:
+		return v.Value, nil
+	case *ListValue:
+		items := make([]interface{}, len(v.Values))
+		for i, item := range v.Values {
+			var err error
+			if items[i], err = literalValue(item, variables); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case *ObjectValue:
+		fields := map[string]interface{}{}
+		for _, field := range v.Fields {
+			item, err := literalValue(field.Value, variables)
+			if err != nil {
+				return nil, err
+			}
+			fields[field.Name] = item
+		}
+		return fields, nil
+	}
+	return nil, fmt.Errorf("unsupported value %T", value)
+}
+
+func applyDefault(coerced map[string]interface{}, field *ArgumentDefinition) error {
+	if field.DefaultValue != nil {
+		coerced[field.Name] = field.DefaultValue
+		return nil
+	}
+	if _, ok := field.Type.(*NonNull); ok {
+		return fmt.Errorf("required value of type %s was not provided", field.Type)
+	}
+	return nil
+}
+
+func inputField(t *InputObject, name string) *ArgumentDefinition {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func enumHas(t *Enum, name string) bool {
+	return slices.ContainsFunc(t.Values, func(value *EnumValueDefinition) bool { return value.Name == name })
+}
+
+// coerceArguments mengisi argumen field dari literal query, termasuk nilai
+// default.
+func coerceArguments(definitions []*ArgumentDefinition, arguments []*Argument, variables map[string]interface{}) (map[string]interface{}, error) {
+	given := map[string]*Argument{}
+	for _, argument := range arguments {
+		if _, ok := given[argument.Name]; ok {
+			return nil, fmt.Errorf("there can be only one argument named %q", argument.Name)
+		}
+		if !slices.ContainsFunc(definitions, func(d *ArgumentDefinition) bool { return d.Name == argument.Name }) {
+			return nil, fmt.Errorf("unknown argument %q", argument.Name)
+		}
+		given[argument.Name] = argument
+	}
+
+	coerced := map[string]interface{}{}
+	for _, definition := range definitions {
+		if argument, ok := given[definition.Name]; ok {
+			value, present, err := coerceLiteral(definition.Type, argument.Value, variables)
+			if err != nil {
+				return nil, fmt.Errorf("argument %q: %w", definition.Name, err)
+			}
+			if present {
+				coerced[definition.Name] = value
+				continue
+			}
+		}
+		if err := applyDefault(coerced, definition); err != nil {
+			return nil, fmt.Errorf("argument %q: %w", definition.Name, err)
+		}
+	}
+	return coerced, nil
+}
+
+// printAST menampilkan literal seperti di query, untuk pesan error.
+func printAST(value Value) string {
+	switch v := value.(type) {
+	case *Variable:
+		return "$" + v.Name
+	case *IntValue:
+		return v.Raw
+	case *FloatValue:
+		return v.Raw
+	case *StringValue:
+		return strconv.Quote(v.Value)
+	case *BooleanValue:
+		return strconv.FormatBool(v.Value)
+	case *NullValue:
+		return "null"
+	case *EnumValue:
+		return v.Value
+	case *ListValue:
+		items := make([]string, len(v.Values))
+		for i, item := range v.Values {
+			items[i] = printAST(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *ObjectValue:
+		fields := make([]string, len(v.Fields))
+		for i, field := range v.Fields {
+			fields[i] = field.Name + ": " + printAST(field.Value)
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	}
+	return ""
+}
+
+// printValue menulis nilai Go sebagai literal GraphQL, untuk defaultValue di
+// introspection.
+func printValue(t Type, value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	switch t := t.(type) {
+	case *NonNull:
+		return printValue(t.OfType, value)
+	case *List:
+		items, ok := value.([]interface{})
+		if !ok {
+			return printValue(t.OfType, value)
+		}
+		printed := make([]string, len(items))
+		for i, item := range items {
+			printed[i] = printValue(t.OfType, item)
+		}
+		return "[" + strings.Join(printed, ", ") + "]"
+	case *InputObject:
+		fields, _ := value.(map[string]interface{})
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		printed := make([]string, len(names))
+		for i, name := range names {
+			printed[i] = name + ": " + printValue(inputField(t, name).Type, fields[name])
+		}
+		return "{" + strings.Join(printed, ", ") + "}"
+	case *Enum:
+		return fmt.Sprint(value)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSpace(buf.String())
+}
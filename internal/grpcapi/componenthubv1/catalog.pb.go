@@ -0,0 +1,1953 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: componenthub/v1/catalog.proto
+
+package componenthubv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Category) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Tag) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tag) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Tag) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ComponentVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Framework     string                 `protobuf:"bytes,2,opt,name=framework,proto3" json:"framework,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Styles        string                 `protobuf:"bytes,4,opt,name=styles,proto3" json:"styles,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentVariant) Reset() {
+	*x = ComponentVariant{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentVariant) ProtoMessage() {}
+
+func (x *ComponentVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentVariant.ProtoReflect.Descriptor instead.
+func (*ComponentVariant) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ComponentVariant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ComponentVariant) GetFramework() string {
+	if x != nil {
+		return x.Framework
+	}
+	return ""
+}
+
+func (x *ComponentVariant) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ComponentVariant) GetStyles() string {
+	if x != nil {
+		return x.Styles
+	}
+	return ""
+}
+
+func (x *ComponentVariant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ComponentVariant) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Component struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug        string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Category    *Category              `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	CodeJsx     string                 `protobuf:"bytes,6,opt,name=code_jsx,json=codeJsx,proto3" json:"code_jsx,omitempty"`
+	CodeCss     string                 `protobuf:"bytes,7,opt,name=code_css,json=codeCss,proto3" json:"code_css,omitempty"`
+	// Any JSON value, as stored by the REST API.
+	PropsDefinition *structpb.Value `protobuf:"bytes,8,opt,name=props_definition,json=propsDefinition,proto3" json:"props_definition,omitempty"`
+	UserId          string          `protobuf:"bytes,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WorkspaceId     string          `protobuf:"bytes,10,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// public, internal or private.
+	Visibility         string                 `protobuf:"bytes,11,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Tags               []*Tag                 `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	Variants           []*ComponentVariant    `protobuf:"bytes,13,rep,name=variants,proto3" json:"variants,omitempty"`
+	Status             string                 `protobuf:"bytes,14,opt,name=status,proto3" json:"status,omitempty"`
+	ApprovalStatus     string                 `protobuf:"bytes,15,opt,name=approval_status,json=approvalStatus,proto3" json:"approval_status,omitempty"`
+	ReviewerId         string                 `protobuf:"bytes,16,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	LatestVersion      string                 `protobuf:"bytes,17,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	DeprecatedAt       *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=deprecated_at,json=deprecatedAt,proto3" json:"deprecated_at,omitempty"`
+	DeprecationMessage string                 `protobuf:"bytes,19,opt,name=deprecation_message,json=deprecationMessage,proto3" json:"deprecation_message,omitempty"`
+	SunsetAt           *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+	// Slug of the replacement component.
+	ReplacedBy string `protobuf:"bytes,21,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	// Slug of the component this one was forked from.
+	ForkedFrom        string                 `protobuf:"bytes,22,opt,name=forked_from,json=forkedFrom,proto3" json:"forked_from,omitempty"`
+	ForkedFromVersion string                 `protobuf:"bytes,23,opt,name=forked_from_version,json=forkedFromVersion,proto3" json:"forked_from_version,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,24,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Component) Reset() {
+	*x = Component{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Component) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Component) ProtoMessage() {}
+
+func (x *Component) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Component.ProtoReflect.Descriptor instead.
+func (*Component) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Component) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Component) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Component) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Component) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Component) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *Component) GetCodeJsx() string {
+	if x != nil {
+		return x.CodeJsx
+	}
+	return ""
+}
+
+func (x *Component) GetCodeCss() string {
+	if x != nil {
+		return x.CodeCss
+	}
+	return ""
+}
+
+func (x *Component) GetPropsDefinition() *structpb.Value {
+	if x != nil {
+		return x.PropsDefinition
+	}
+	return nil
+}
+
+func (x *Component) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Component) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *Component) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *Component) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Component) GetVariants() []*ComponentVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Component) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Component) GetApprovalStatus() string {
+	if x != nil {
+		return x.ApprovalStatus
+	}
+	return ""
+}
+
+func (x *Component) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *Component) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+func (x *Component) GetDeprecatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeprecatedAt
+	}
+	return nil
+}
+
+func (x *Component) GetDeprecationMessage() string {
+	if x != nil {
+		return x.DeprecationMessage
+	}
+	return ""
+}
+
+func (x *Component) GetSunsetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SunsetAt
+	}
+	return nil
+}
+
+func (x *Component) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+func (x *Component) GetForkedFrom() string {
+	if x != nil {
+		return x.ForkedFrom
+	}
+	return ""
+}
+
+func (x *Component) GetForkedFromVersion() string {
+	if x != nil {
+		return x.ForkedFromVersion
+	}
+	return ""
+}
+
+func (x *Component) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Component) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Same filters as the query parameters of GET /components.
+type ListComponentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tag names; a component matches if it has any of them.
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Category slug.
+	Category  string `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Status    string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Approval  string `protobuf:"bytes,4,opt,name=approval,proto3" json:"approval,omitempty"`
+	Framework string `protobuf:"bytes,5,opt,name=framework,proto3" json:"framework,omitempty"`
+	// Case-insensitive match on name or description.
+	Q string `protobuf:"bytes,6,opt,name=q,proto3" json:"q,omitempty"`
+	// "false" (default), "true" or "all".
+	Deprecated    string `protobuf:"bytes,7,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	Page          int32  `protobuf:"varint,8,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComponentsRequest) Reset() {
+	*x = ListComponentsRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComponentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComponentsRequest) ProtoMessage() {}
+
+func (x *ListComponentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComponentsRequest.ProtoReflect.Descriptor instead.
+func (*ListComponentsRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListComponentsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListComponentsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetApproval() string {
+	if x != nil {
+		return x.Approval
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetFramework() string {
+	if x != nil {
+		return x.Framework
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetDeprecated() string {
+	if x != nil {
+		return x.Deprecated
+	}
+	return ""
+}
+
+func (x *ListComponentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListComponentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListComponentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Components    []*Component           `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComponentsResponse) Reset() {
+	*x = ListComponentsResponse{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComponentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComponentsResponse) ProtoMessage() {}
+
+func (x *ListComponentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComponentsResponse.ProtoReflect.Descriptor instead.
+func (*ListComponentsResponse) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListComponentsResponse) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type GetComponentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Slug  string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Release version; the latest files are returned when empty.
+	Version       string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetComponentRequest) Reset() {
+	*x = GetComponentRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetComponentRequest) ProtoMessage() {}
+
+func (x *GetComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetComponentRequest.ProtoReflect.Descriptor instead.
+func (*GetComponentRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetComponentRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *GetComponentRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type ComponentVariantInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Framework     string                 `protobuf:"bytes,1,opt,name=framework,proto3" json:"framework,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Styles        string                 `protobuf:"bytes,3,opt,name=styles,proto3" json:"styles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentVariantInput) Reset() {
+	*x = ComponentVariantInput{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentVariantInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentVariantInput) ProtoMessage() {}
+
+func (x *ComponentVariantInput) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentVariantInput.ProtoReflect.Descriptor instead.
+func (*ComponentVariantInput) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ComponentVariantInput) GetFramework() string {
+	if x != nil {
+		return x.Framework
+	}
+	return ""
+}
+
+func (x *ComponentVariantInput) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ComponentVariantInput) GetStyles() string {
+	if x != nil {
+		return x.Styles
+	}
+	return ""
+}
+
+type ComponentFileInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Framework     string                 `protobuf:"bytes,1,opt,name=framework,proto3" json:"framework,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentFileInput) Reset() {
+	*x = ComponentFileInput{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentFileInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentFileInput) ProtoMessage() {}
+
+func (x *ComponentFileInput) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentFileInput.ProtoReflect.Descriptor instead.
+func (*ComponentFileInput) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ComponentFileInput) GetFramework() string {
+	if x != nil {
+		return x.Framework
+	}
+	return ""
+}
+
+func (x *ComponentFileInput) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ComponentFileInput) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ComponentFileInput) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ComponentFileInput) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type CreateComponentRequest struct {
+	state           protoimpl.MessageState   `protogen:"open.v1"`
+	Name            string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	CategoryId      string                   `protobuf:"bytes,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	CodeJsx         string                   `protobuf:"bytes,4,opt,name=code_jsx,json=codeJsx,proto3" json:"code_jsx,omitempty"`
+	CodeCss         string                   `protobuf:"bytes,5,opt,name=code_css,json=codeCss,proto3" json:"code_css,omitempty"`
+	Variants        []*ComponentVariantInput `protobuf:"bytes,6,rep,name=variants,proto3" json:"variants,omitempty"`
+	Files           []*ComponentFileInput    `protobuf:"bytes,7,rep,name=files,proto3" json:"files,omitempty"`
+	PropsDefinition *structpb.Value          `protobuf:"bytes,8,opt,name=props_definition,json=propsDefinition,proto3" json:"props_definition,omitempty"`
+	// Workspace slug.
+	Workspace     string `protobuf:"bytes,9,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	Visibility    string `protobuf:"bytes,10,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateComponentRequest) Reset() {
+	*x = CreateComponentRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateComponentRequest) ProtoMessage() {}
+
+func (x *CreateComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateComponentRequest.ProtoReflect.Descriptor instead.
+func (*CreateComponentRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateComponentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetCodeJsx() string {
+	if x != nil {
+		return x.CodeJsx
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetCodeCss() string {
+	if x != nil {
+		return x.CodeCss
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetVariants() []*ComponentVariantInput {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *CreateComponentRequest) GetFiles() []*ComponentFileInput {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *CreateComponentRequest) GetPropsDefinition() *structpb.Value {
+	if x != nil {
+		return x.PropsDefinition
+	}
+	return nil
+}
+
+func (x *CreateComponentRequest) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+type UpdateComponentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateComponentRequest) Reset() {
+	*x = UpdateComponentRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateComponentRequest) ProtoMessage() {}
+
+func (x *UpdateComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateComponentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateComponentRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateComponentRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateComponentRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateComponentRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+type DeleteComponentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteComponentRequest) Reset() {
+	*x = DeleteComponentRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteComponentRequest) ProtoMessage() {}
+
+func (x *DeleteComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteComponentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteComponentRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteComponentRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type AddComponentTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	TagId         string                 `protobuf:"bytes,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddComponentTagRequest) Reset() {
+	*x = AddComponentTagRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddComponentTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddComponentTagRequest) ProtoMessage() {}
+
+func (x *AddComponentTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddComponentTagRequest.ProtoReflect.Descriptor instead.
+func (*AddComponentTagRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AddComponentTagRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *AddComponentTagRequest) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+type UpdateComponentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateComponentStatusRequest) Reset() {
+	*x = UpdateComponentStatusRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateComponentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateComponentStatusRequest) ProtoMessage() {}
+
+func (x *UpdateComponentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateComponentStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateComponentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateComponentStatusRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateComponentStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type UpdateComponentApprovalRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Slug           string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	ApprovalStatus string                 `protobuf:"bytes,2,opt,name=approval_status,json=approvalStatus,proto3" json:"approval_status,omitempty"`
+	// Ignored; the server records the caller as the reviewer.
+	ReviewerId    string `protobuf:"bytes,3,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateComponentApprovalRequest) Reset() {
+	*x = UpdateComponentApprovalRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateComponentApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateComponentApprovalRequest) ProtoMessage() {}
+
+func (x *UpdateComponentApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateComponentApprovalRequest.ProtoReflect.Descriptor instead.
+func (*UpdateComponentApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateComponentApprovalRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateComponentApprovalRequest) GetApprovalStatus() string {
+	if x != nil {
+		return x.ApprovalStatus
+	}
+	return ""
+}
+
+func (x *UpdateComponentApprovalRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+type UpdateComponentVisibilityRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Slug       string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Visibility string                 `protobuf:"bytes,2,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	// Workspace slug.
+	Workspace     string `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateComponentVisibilityRequest) Reset() {
+	*x = UpdateComponentVisibilityRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateComponentVisibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateComponentVisibilityRequest) ProtoMessage() {}
+
+func (x *UpdateComponentVisibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateComponentVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateComponentVisibilityRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateComponentVisibilityRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateComponentVisibilityRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *UpdateComponentVisibilityRequest) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+type DeprecateComponentRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Slug    string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Date (2006-01-02) or RFC 3339 timestamp.
+	SunsetAt string `protobuf:"bytes,3,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+	// Slug of the replacement component.
+	ReplacedBy    string `protobuf:"bytes,4,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeprecateComponentRequest) Reset() {
+	*x = DeprecateComponentRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeprecateComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeprecateComponentRequest) ProtoMessage() {}
+
+func (x *DeprecateComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeprecateComponentRequest.ProtoReflect.Descriptor instead.
+func (*DeprecateComponentRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeprecateComponentRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *DeprecateComponentRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeprecateComponentRequest) GetSunsetAt() string {
+	if x != nil {
+		return x.SunsetAt
+	}
+	return ""
+}
+
+func (x *DeprecateComponentRequest) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type UndeprecateComponentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeprecateComponentRequest) Reset() {
+	*x = UndeprecateComponentRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeprecateComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeprecateComponentRequest) ProtoMessage() {}
+
+func (x *UndeprecateComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeprecateComponentRequest.ProtoReflect.Descriptor instead.
+func (*UndeprecateComponentRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UndeprecateComponentRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{18}
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{21}
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type CreateTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTagRequest) Reset() {
+	*x = CreateTagRequest{}
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTagRequest) ProtoMessage() {}
+
+func (x *CreateTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_componenthub_v1_catalog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTagRequest.ProtoReflect.Descriptor instead.
+func (*CreateTagRequest) Descriptor() ([]byte, []int) {
+	return file_componenthub_v1_catalog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_componenthub_v1_catalog_proto protoreflect.FileDescriptor
+
+const file_componenthub_v1_catalog_proto_rawDesc = "" +
+	"\n" +
+	"\x1dcomponenthub/v1/catalog.proto\x12\x0fcomponenthub.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb8\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb3\x01\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe2\x01\n" +
+	"\x10ComponentVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tframework\x18\x02 \x01(\tR\tframework\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x16\n" +
+	"\x06styles\x18\x04 \x01(\tR\x06styles\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf6\a\n" +
+	"\tComponent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x125\n" +
+	"\bcategory\x18\x05 \x01(\v2\x19.componenthub.v1.CategoryR\bcategory\x12\x19\n" +
+	"\bcode_jsx\x18\x06 \x01(\tR\acodeJsx\x12\x19\n" +
+	"\bcode_css\x18\a \x01(\tR\acodeCss\x12A\n" +
+	"\x10props_definition\x18\b \x01(\v2\x16.google.protobuf.ValueR\x0fpropsDefinition\x12\x17\n" +
+	"\auser_id\x18\t \x01(\tR\x06userId\x12!\n" +
+	"\fworkspace_id\x18\n" +
+	" \x01(\tR\vworkspaceId\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\v \x01(\tR\n" +
+	"visibility\x12(\n" +
+	"\x04tags\x18\f \x03(\v2\x14.componenthub.v1.TagR\x04tags\x12=\n" +
+	"\bvariants\x18\r \x03(\v2!.componenthub.v1.ComponentVariantR\bvariants\x12\x16\n" +
+	"\x06status\x18\x0e \x01(\tR\x06status\x12'\n" +
+	"\x0fapproval_status\x18\x0f \x01(\tR\x0eapprovalStatus\x12\x1f\n" +
+	"\vreviewer_id\x18\x10 \x01(\tR\n" +
+	"reviewerId\x12%\n" +
+	"\x0elatest_version\x18\x11 \x01(\tR\rlatestVersion\x12?\n" +
+	"\rdeprecated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\fdeprecatedAt\x12/\n" +
+	"\x13deprecation_message\x18\x13 \x01(\tR\x12deprecationMessage\x127\n" +
+	"\tsunset_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\bsunsetAt\x12\x1f\n" +
+	"\vreplaced_by\x18\x15 \x01(\tR\n" +
+	"replacedBy\x12\x1f\n" +
+	"\vforked_from\x18\x16 \x01(\tR\n" +
+	"forkedFrom\x12.\n" +
+	"\x13forked_from_version\x18\x17 \x01(\tR\x11forkedFromVersion\x129\n" +
+	"\n" +
+	"created_at\x18\x18 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x19 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf1\x01\n" +
+	"\x15ListComponentsRequest\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1a\n" +
+	"\bapproval\x18\x04 \x01(\tR\bapproval\x12\x1c\n" +
+	"\tframework\x18\x05 \x01(\tR\tframework\x12\f\n" +
+	"\x01q\x18\x06 \x01(\tR\x01q\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\a \x01(\tR\n" +
+	"deprecated\x12\x12\n" +
+	"\x04page\x18\b \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\"T\n" +
+	"\x16ListComponentsResponse\x12:\n" +
+	"\n" +
+	"components\x18\x01 \x03(\v2\x1a.componenthub.v1.ComponentR\n" +
+	"components\"C\n" +
+	"\x13GetComponentRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"a\n" +
+	"\x15ComponentVariantInput\x12\x1c\n" +
+	"\tframework\x18\x01 \x01(\tR\tframework\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x16\n" +
+	"\x06styles\x18\x03 \x01(\tR\x06styles\"\x90\x01\n" +
+	"\x12ComponentFileInput\x12\x1c\n" +
+	"\tframework\x18\x01 \x01(\tR\tframework\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\"\xa5\x03\n" +
+	"\x16CreateComponentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\tR\n" +
+	"categoryId\x12\x19\n" +
+	"\bcode_jsx\x18\x04 \x01(\tR\acodeJsx\x12\x19\n" +
+	"\bcode_css\x18\x05 \x01(\tR\acodeCss\x12B\n" +
+	"\bvariants\x18\x06 \x03(\v2&.componenthub.v1.ComponentVariantInputR\bvariants\x129\n" +
+	"\x05files\x18\a \x03(\v2#.componenthub.v1.ComponentFileInputR\x05files\x12A\n" +
+	"\x10props_definition\x18\b \x01(\v2\x16.google.protobuf.ValueR\x0fpropsDefinition\x12\x1c\n" +
+	"\tworkspace\x18\t \x01(\tR\tworkspace\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\n" +
+	" \x01(\tR\n" +
+	"visibility\"\x85\x01\n" +
+	"\x16UpdateComponentRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_description\",\n" +
+	"\x16DeleteComponentRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"C\n" +
+	"\x16AddComponentTagRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x15\n" +
+	"\x06tag_id\x18\x02 \x01(\tR\x05tagId\"J\n" +
+	"\x1cUpdateComponentStatusRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"~\n" +
+	"\x1eUpdateComponentApprovalRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12'\n" +
+	"\x0fapproval_status\x18\x02 \x01(\tR\x0eapprovalStatus\x12\x1f\n" +
+	"\vreviewer_id\x18\x03 \x01(\tR\n" +
+	"reviewerId\"t\n" +
+	" UpdateComponentVisibilityRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x02 \x01(\tR\n" +
+	"visibility\x12\x1c\n" +
+	"\tworkspace\x18\x03 \x01(\tR\tworkspace\"\x87\x01\n" +
+	"\x19DeprecateComponentRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tsunset_at\x18\x03 \x01(\tR\bsunsetAt\x12\x1f\n" +
+	"\vreplaced_by\x18\x04 \x01(\tR\n" +
+	"replacedBy\"1\n" +
+	"\x1bUndeprecateComponentRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"\x17\n" +
+	"\x15ListCategoriesRequest\"S\n" +
+	"\x16ListCategoriesResponse\x129\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x19.componenthub.v1.CategoryR\n" +
+	"categories\"+\n" +
+	"\x15CreateCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x11\n" +
+	"\x0fListTagsRequest\"<\n" +
+	"\x10ListTagsResponse\x12(\n" +
+	"\x04tags\x18\x01 \x03(\v2\x14.componenthub.v1.TagR\x04tags\"&\n" +
+	"\x10CreateTagRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name2\xe8\n" +
+	"\n" +
+	"\x0eCatalogService\x12a\n" +
+	"\x0eListComponents\x12&.componenthub.v1.ListComponentsRequest\x1a'.componenthub.v1.ListComponentsResponse\x12P\n" +
+	"\fGetComponent\x12$.componenthub.v1.GetComponentRequest\x1a\x1a.componenthub.v1.Component\x12V\n" +
+	"\x0fCreateComponent\x12'.componenthub.v1.CreateComponentRequest\x1a\x1a.componenthub.v1.Component\x12V\n" +
+	"\x0fUpdateComponent\x12'.componenthub.v1.UpdateComponentRequest\x1a\x1a.componenthub.v1.Component\x12R\n" +
+	"\x0fDeleteComponent\x12'.componenthub.v1.DeleteComponentRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x0fAddComponentTag\x12'.componenthub.v1.AddComponentTagRequest\x1a\x1a.componenthub.v1.Component\x12b\n" +
+	"\x15UpdateComponentStatus\x12-.componenthub.v1.UpdateComponentStatusRequest\x1a\x1a.componenthub.v1.Component\x12f\n" +
+	"\x17UpdateComponentApproval\x12/.componenthub.v1.UpdateComponentApprovalRequest\x1a\x1a.componenthub.v1.Component\x12j\n" +
+	"\x19UpdateComponentVisibility\x121.componenthub.v1.UpdateComponentVisibilityRequest\x1a\x1a.componenthub.v1.Component\x12\\\n" +
+	"\x12DeprecateComponent\x12*.componenthub.v1.DeprecateComponentRequest\x1a\x1a.componenthub.v1.Component\x12`\n" +
+	"\x14UndeprecateComponent\x12,.componenthub.v1.UndeprecateComponentRequest\x1a\x1a.componenthub.v1.Component\x12a\n" +
+	"\x0eListCategories\x12&.componenthub.v1.ListCategoriesRequest\x1a'.componenthub.v1.ListCategoriesResponse\x12S\n" +
+	"\x0eCreateCategory\x12&.componenthub.v1.CreateCategoryRequest\x1a\x19.componenthub.v1.Category\x12O\n" +
+	"\bListTags\x12 .componenthub.v1.ListTagsRequest\x1a!.componenthub.v1.ListTagsResponse\x12D\n" +
+	"\tCreateTag\x12!.componenthub.v1.CreateTagRequest\x1a\x14.componenthub.v1.TagBZ\n" +
+	"\x13com.componenthub.v1P\x01ZAservice_components/internal/grpcapi/componenthubv1;componenthubv1b\x06proto3"
+
+var (
+	file_componenthub_v1_catalog_proto_rawDescOnce sync.Once
+	file_componenthub_v1_catalog_proto_rawDescData []byte
+)
+
+func file_componenthub_v1_catalog_proto_rawDescGZIP() []byte {
+	file_componenthub_v1_catalog_proto_rawDescOnce.Do(func() {
+		file_componenthub_v1_catalog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_componenthub_v1_catalog_proto_rawDesc), len(file_componenthub_v1_catalog_proto_rawDesc)))
+	})
+	return file_componenthub_v1_catalog_proto_rawDescData
+}
+
+var file_componenthub_v1_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_componenthub_v1_catalog_proto_goTypes = []any{
+	(*Category)(nil),                         // 0: componenthub.v1.Category
+	(*Tag)(nil),                              // 1: componenthub.v1.Tag
+	(*ComponentVariant)(nil),                 // 2: componenthub.v1.ComponentVariant
+	(*Component)(nil),                        // 3: componenthub.v1.Component
+	(*ListComponentsRequest)(nil),            // 4: componenthub.v1.ListComponentsRequest
+	(*ListComponentsResponse)(nil),           // 5: componenthub.v1.ListComponentsResponse
+	(*GetComponentRequest)(nil),              // 6: componenthub.v1.GetComponentRequest
+	(*ComponentVariantInput)(nil),            // 7: componenthub.v1.ComponentVariantInput
+	(*ComponentFileInput)(nil),               // 8: componenthub.v1.ComponentFileInput
+	(*CreateComponentRequest)(nil),           // 9: componenthub.v1.CreateComponentRequest
+	(*UpdateComponentRequest)(nil),           // 10: componenthub.v1.UpdateComponentRequest
+	(*DeleteComponentRequest)(nil),           // 11: componenthub.v1.DeleteComponentRequest
+	(*AddComponentTagRequest)(nil),           // 12: componenthub.v1.AddComponentTagRequest
+	(*UpdateComponentStatusRequest)(nil),     // 13: componenthub.v1.UpdateComponentStatusRequest
+	(*UpdateComponentApprovalRequest)(nil),   // 14: componenthub.v1.UpdateComponentApprovalRequest
+	(*UpdateComponentVisibilityRequest)(nil), // 15: componenthub.v1.UpdateComponentVisibilityRequest
+	(*DeprecateComponentRequest)(nil),        // 16: componenthub.v1.DeprecateComponentRequest
+	(*UndeprecateComponentRequest)(nil),      // 17: componenthub.v1.UndeprecateComponentRequest
+	(*ListCategoriesRequest)(nil),            // 18: componenthub.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),           // 19: componenthub.v1.ListCategoriesResponse
+	(*CreateCategoryRequest)(nil),            // 20: componenthub.v1.CreateCategoryRequest
+	(*ListTagsRequest)(nil),                  // 21: componenthub.v1.ListTagsRequest
+	(*ListTagsResponse)(nil),                 // 22: componenthub.v1.ListTagsResponse
+	(*CreateTagRequest)(nil),                 // 23: componenthub.v1.CreateTagRequest
+	(*timestamppb.Timestamp)(nil),            // 24: google.protobuf.Timestamp
+	(*structpb.Value)(nil),                   // 25: google.protobuf.Value
+	(*emptypb.Empty)(nil),                    // 26: google.protobuf.Empty
+}
+var file_componenthub_v1_catalog_proto_depIdxs = []int32{
+	24, // 0: componenthub.v1.Category.created_at:type_name -> google.protobuf.Timestamp
+	24, // 1: componenthub.v1.Category.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 2: componenthub.v1.Tag.created_at:type_name -> google.protobuf.Timestamp
+	24, // 3: componenthub.v1.Tag.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 4: componenthub.v1.ComponentVariant.created_at:type_name -> google.protobuf.Timestamp
+	24, // 5: componenthub.v1.ComponentVariant.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: componenthub.v1.Component.category:type_name -> componenthub.v1.Category
+	25, // 7: componenthub.v1.Component.props_definition:type_name -> google.protobuf.Value
+	1,  // 8: componenthub.v1.Component.tags:type_name -> componenthub.v1.Tag
+	2,  // 9: componenthub.v1.Component.variants:type_name -> componenthub.v1.ComponentVariant
+	24, // 10: componenthub.v1.Component.deprecated_at:type_name -> google.protobuf.Timestamp
+	24, // 11: componenthub.v1.Component.sunset_at:type_name -> google.protobuf.Timestamp
+	24, // 12: componenthub.v1.Component.created_at:type_name -> google.protobuf.Timestamp
+	24, // 13: componenthub.v1.Component.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 14: componenthub.v1.ListComponentsResponse.components:type_name -> componenthub.v1.Component
+	7,  // 15: componenthub.v1.CreateComponentRequest.variants:type_name -> componenthub.v1.ComponentVariantInput
+	8,  // 16: componenthub.v1.CreateComponentRequest.files:type_name -> componenthub.v1.ComponentFileInput
+	25, // 17: componenthub.v1.CreateComponentRequest.props_definition:type_name -> google.protobuf.Value
+	0,  // 18: componenthub.v1.ListCategoriesResponse.categories:type_name -> componenthub.v1.Category
+	1,  // 19: componenthub.v1.ListTagsResponse.tags:type_name -> componenthub.v1.Tag
+	4,  // 20: componenthub.v1.CatalogService.ListComponents:input_type -> componenthub.v1.ListComponentsRequest
+	6,  // 21: componenthub.v1.CatalogService.GetComponent:input_type -> componenthub.v1.GetComponentRequest
+	9,  // 22: componenthub.v1.CatalogService.CreateComponent:input_type -> componenthub.v1.CreateComponentRequest
+	10, // 23: componenthub.v1.CatalogService.UpdateComponent:input_type -> componenthub.v1.UpdateComponentRequest
+	11, // 24: componenthub.v1.CatalogService.DeleteComponent:input_type -> componenthub.v1.DeleteComponentRequest
+	12, // 25: componenthub.v1.CatalogService.AddComponentTag:input_type -> componenthub.v1.AddComponentTagRequest
+	13, // 26: componenthub.v1.CatalogService.UpdateComponentStatus:input_type -> componenthub.v1.UpdateComponentStatusRequest
+	14, // 27: componenthub.v1.CatalogService.UpdateComponentApproval:input_type -> componenthub.v1.UpdateComponentApprovalRequest
+	15, // 28: componenthub.v1.CatalogService.UpdateComponentVisibility:input_type -> componenthub.v1.UpdateComponentVisibilityRequest
+	16, // 29: componenthub.v1.CatalogService.DeprecateComponent:input_type -> componenthub.v1.DeprecateComponentRequest
+	17, // 30: componenthub.v1.CatalogService.UndeprecateComponent:input_type -> componenthub.v1.UndeprecateComponentRequest
+	18, // 31: componenthub.v1.CatalogService.ListCategories:input_type -> componenthub.v1.ListCategoriesRequest
+	20, // 32: componenthub.v1.CatalogService.CreateCategory:input_type -> componenthub.v1.CreateCategoryRequest
+	21, // 33: componenthub.v1.CatalogService.ListTags:input_type -> componenthub.v1.ListTagsRequest
+	23, // 34: componenthub.v1.CatalogService.CreateTag:input_type -> componenthub.v1.CreateTagRequest
+	5,  // 35: componenthub.v1.CatalogService.ListComponents:output_type -> componenthub.v1.ListComponentsResponse
+	3,  // 36: componenthub.v1.CatalogService.GetComponent:output_type -> componenthub.v1.Component
+	3,  // 37: componenthub.v1.CatalogService.CreateComponent:output_type -> componenthub.v1.Component
+	3,  // 38: componenthub.v1.CatalogService.UpdateComponent:output_type -> componenthub.v1.Component
+	26, // 39: componenthub.v1.CatalogService.DeleteComponent:output_type -> google.protobuf.Empty
+	3,  // 40: componenthub.v1.CatalogService.AddComponentTag:output_type -> componenthub.v1.Component
+	3,  // 41: componenthub.v1.CatalogService.UpdateComponentStatus:output_type -> componenthub.v1.Component
+	3,  // 42: componenthub.v1.CatalogService.UpdateComponentApproval:output_type -> componenthub.v1.Component
+	3,  // 43: componenthub.v1.CatalogService.UpdateComponentVisibility:output_type -> componenthub.v1.Component
+	3,  // 44: componenthub.v1.CatalogService.DeprecateComponent:output_type -> componenthub.v1.Component
+	3,  // 45: componenthub.v1.CatalogService.UndeprecateComponent:output_type -> componenthub.v1.Component
+	19, // 46: componenthub.v1.CatalogService.ListCategories:output_type -> componenthub.v1.ListCategoriesResponse
+	0,  // 47: componenthub.v1.CatalogService.CreateCategory:output_type -> componenthub.v1.Category
+	22, // 48: componenthub.v1.CatalogService.ListTags:output_type -> componenthub.v1.ListTagsResponse
+	1,  // 49: componenthub.v1.CatalogService.CreateTag:output_type -> componenthub.v1.Tag
+	35, // [35:50] is the sub-list for method output_type
+	20, // [20:35] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_componenthub_v1_catalog_proto_init() }
+func file_componenthub_v1_catalog_proto_init() {
+	if File_componenthub_v1_catalog_proto != nil {
+		return
+	}
+	file_componenthub_v1_catalog_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_componenthub_v1_catalog_proto_rawDesc), len(file_componenthub_v1_catalog_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_componenthub_v1_catalog_proto_goTypes,
+		DependencyIndexes: file_componenthub_v1_catalog_proto_depIdxs,
+		MessageInfos:      file_componenthub_v1_catalog_proto_msgTypes,
+	}.Build()
+	File_componenthub_v1_catalog_proto = out.File
+	file_componenthub_v1_catalog_proto_goTypes = nil
+	file_componenthub_v1_catalog_proto_depIdxs = nil
+}
@@ -0,0 +1,696 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: componenthub/v1/catalog.proto
+
+package componenthubv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CatalogService_ListComponents_FullMethodName            = "/componenthub.v1.CatalogService/ListComponents"
+	CatalogService_GetComponent_FullMethodName              = "/componenthub.v1.CatalogService/GetComponent"
+	CatalogService_CreateComponent_FullMethodName           = "/componenthub.v1.CatalogService/CreateComponent"
+	CatalogService_UpdateComponent_FullMethodName           = "/componenthub.v1.CatalogService/UpdateComponent"
+	CatalogService_DeleteComponent_FullMethodName           = "/componenthub.v1.CatalogService/DeleteComponent"
+	CatalogService_AddComponentTag_FullMethodName           = "/componenthub.v1.CatalogService/AddComponentTag"
+	CatalogService_UpdateComponentStatus_FullMethodName     = "/componenthub.v1.CatalogService/UpdateComponentStatus"
+	CatalogService_UpdateComponentApproval_FullMethodName   = "/componenthub.v1.CatalogService/UpdateComponentApproval"
+	CatalogService_UpdateComponentVisibility_FullMethodName = "/componenthub.v1.CatalogService/UpdateComponentVisibility"
+	CatalogService_DeprecateComponent_FullMethodName        = "/componenthub.v1.CatalogService/DeprecateComponent"
+	CatalogService_UndeprecateComponent_FullMethodName      = "/componenthub.v1.CatalogService/UndeprecateComponent"
+	CatalogService_ListCategories_FullMethodName            = "/componenthub.v1.CatalogService/ListCategories"
+	CatalogService_CreateCategory_FullMethodName            = "/componenthub.v1.CatalogService/CreateCategory"
+	CatalogService_ListTags_FullMethodName                  = "/componenthub.v1.CatalogService/ListTags"
+	CatalogService_CreateTag_FullMethodName                 = "/componenthub.v1.CatalogService/CreateTag"
+)
+
+// CatalogServiceClient is the client API for CatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CatalogService exposes the component, category and tag operations of the
+// REST API. Every call runs the matching REST endpoint, so validation,
+// permissions, API key scopes, rate limits, the audit log and events are the
+// same. Authenticate with an "authorization: Bearer <token>" metadata entry
+// holding an access token or an API key.
+type CatalogServiceClient interface {
+	// GET /components
+	ListComponents(ctx context.Context, in *ListComponentsRequest, opts ...grpc.CallOption) (*ListComponentsResponse, error)
+	// GET /components/{slug}
+	GetComponent(ctx context.Context, in *GetComponentRequest, opts ...grpc.CallOption) (*Component, error)
+	// POST /components
+	CreateComponent(ctx context.Context, in *CreateComponentRequest, opts ...grpc.CallOption) (*Component, error)
+	// PATCH /components/{slug}
+	UpdateComponent(ctx context.Context, in *UpdateComponentRequest, opts ...grpc.CallOption) (*Component, error)
+	// DELETE /components/{slug}
+	DeleteComponent(ctx context.Context, in *DeleteComponentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// POST /components/{slug}/tags
+	AddComponentTag(ctx context.Context, in *AddComponentTagRequest, opts ...grpc.CallOption) (*Component, error)
+	// PATCH /components/{slug}/status
+	UpdateComponentStatus(ctx context.Context, in *UpdateComponentStatusRequest, opts ...grpc.CallOption) (*Component, error)
+	// PATCH /components/{slug}/approval
+	UpdateComponentApproval(ctx context.Context, in *UpdateComponentApprovalRequest, opts ...grpc.CallOption) (*Component, error)
+	// PATCH /components/{slug}/visibility
+	UpdateComponentVisibility(ctx context.Context, in *UpdateComponentVisibilityRequest, opts ...grpc.CallOption) (*Component, error)
+	// PUT /components/{slug}/deprecation
+	DeprecateComponent(ctx context.Context, in *DeprecateComponentRequest, opts ...grpc.CallOption) (*Component, error)
+	// DELETE /components/{slug}/deprecation
+	UndeprecateComponent(ctx context.Context, in *UndeprecateComponentRequest, opts ...grpc.CallOption) (*Component, error)
+	// GET /categories
+	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	// POST /categories
+	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	// GET /tags
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// POST /tags
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*Tag, error)
+}
+
+type catalogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogServiceClient(cc grpc.ClientConnInterface) CatalogServiceClient {
+	return &catalogServiceClient{cc}
+}
+
+func (c *catalogServiceClient) ListComponents(ctx context.Context, in *ListComponentsRequest, opts ...grpc.CallOption) (*ListComponentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListComponentsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListComponents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetComponent(ctx context.Context, in *GetComponentRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_GetComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CreateComponent(ctx context.Context, in *CreateComponentRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_CreateComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateComponent(ctx context.Context, in *UpdateComponentRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DeleteComponent(ctx context.Context, in *DeleteComponentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CatalogService_DeleteComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) AddComponentTag(ctx context.Context, in *AddComponentTagRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_AddComponentTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateComponentStatus(ctx context.Context, in *UpdateComponentStatusRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateComponentStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateComponentApproval(ctx context.Context, in *UpdateComponentApprovalRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateComponentApproval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateComponentVisibility(ctx context.Context, in *UpdateComponentVisibilityRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateComponentVisibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DeprecateComponent(ctx context.Context, in *DeprecateComponentRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_DeprecateComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UndeprecateComponent(ctx context.Context, in *UndeprecateComponentRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, CatalogService_UndeprecateComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, CatalogService_CreateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, CatalogService_CreateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogServiceServer is the server API for CatalogService service.
+// All implementations must embed UnimplementedCatalogServiceServer
+// for forward compatibility.
+//
+// CatalogService exposes the component, category and tag operations of the
+// REST API. Every call runs the matching REST endpoint, so validation,
+// permissions, API key scopes, rate limits, the audit log and events are the
+// same. Authenticate with an "authorization: Bearer <token>" metadata entry
+// holding an access token or an API key.
+type CatalogServiceServer interface {
+	// GET /components
+	ListComponents(context.Context, *ListComponentsRequest) (*ListComponentsResponse, error)
+	// GET /components/{slug}
+	GetComponent(context.Context, *GetComponentRequest) (*Component, error)
+	// POST /components
+	CreateComponent(context.Context, *CreateComponentRequest) (*Component, error)
+	// PATCH /components/{slug}
+	UpdateComponent(context.Context, *UpdateComponentRequest) (*Component, error)
+	// DELETE /components/{slug}
+	DeleteComponent(context.Context, *DeleteComponentRequest) (*emptypb.Empty, error)
+	// POST /components/{slug}/tags
+	AddComponentTag(context.Context, *AddComponentTagRequest) (*Component, error)
+	// PATCH /components/{slug}/status
+	UpdateComponentStatus(context.Context, *UpdateComponentStatusRequest) (*Component, error)
+	// PATCH /components/{slug}/approval
+	UpdateComponentApproval(context.Context, *UpdateComponentApprovalRequest) (*Component, error)
+	// PATCH /components/{slug}/visibility
+	UpdateComponentVisibility(context.Context, *UpdateComponentVisibilityRequest) (*Component, error)
+	// PUT /components/{slug}/deprecation
+	DeprecateComponent(context.Context, *DeprecateComponentRequest) (*Component, error)
+	// DELETE /components/{slug}/deprecation
+	UndeprecateComponent(context.Context, *UndeprecateComponentRequest) (*Component, error)
+	// GET /categories
+	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	// POST /categories
+	CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error)
+	// GET /tags
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// POST /tags
+	CreateTag(context.Context, *CreateTagRequest) (*Tag, error)
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+// UnimplementedCatalogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatalogServiceServer struct{}
+
+func (UnimplementedCatalogServiceServer) ListComponents(context.Context, *ListComponentsRequest) (*ListComponentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComponents not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetComponent(context.Context, *GetComponentRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComponent not implemented")
+}
+func (UnimplementedCatalogServiceServer) CreateComponent(context.Context, *CreateComponentRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComponent not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateComponent(context.Context, *UpdateComponentRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComponent not implemented")
+}
+func (UnimplementedCatalogServiceServer) DeleteComponent(context.Context, *DeleteComponentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComponent not implemented")
+}
+func (UnimplementedCatalogServiceServer) AddComponentTag(context.Context, *AddComponentTagRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComponentTag not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateComponentStatus(context.Context, *UpdateComponentStatusRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComponentStatus not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateComponentApproval(context.Context, *UpdateComponentApprovalRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComponentApproval not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateComponentVisibility(context.Context, *UpdateComponentVisibilityRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComponentVisibility not implemented")
+}
+func (UnimplementedCatalogServiceServer) DeprecateComponent(context.Context, *DeprecateComponentRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeprecateComponent not implemented")
+}
+func (UnimplementedCatalogServiceServer) UndeprecateComponent(context.Context, *UndeprecateComponentRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeprecateComponent not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedCatalogServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedCatalogServiceServer) CreateTag(context.Context, *CreateTagRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
+func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogServiceServer will
+// result in compilation errors.
+type UnsafeCatalogServiceServer interface {
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+func RegisterCatalogServiceServer(s grpc.ServiceRegistrar, srv CatalogServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCatalogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CatalogService_ServiceDesc, srv)
+}
+
+func _CatalogService_ListComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListComponentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListComponents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListComponents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListComponents(ctx, req.(*ListComponentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetComponent(ctx, req.(*GetComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CreateComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CreateComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateComponent(ctx, req.(*CreateComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateComponent(ctx, req.(*UpdateComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeleteComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeleteComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteComponent(ctx, req.(*DeleteComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_AddComponentTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddComponentTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).AddComponentTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_AddComponentTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).AddComponentTag(ctx, req.(*AddComponentTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateComponentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateComponentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateComponentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateComponentStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateComponentStatus(ctx, req.(*UpdateComponentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateComponentApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateComponentApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateComponentApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateComponentApproval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateComponentApproval(ctx, req.(*UpdateComponentApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateComponentVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateComponentVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateComponentVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateComponentVisibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateComponentVisibility(ctx, req.(*UpdateComponentVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeprecateComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeprecateComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeprecateComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeprecateComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeprecateComponent(ctx, req.(*DeprecateComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UndeprecateComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeprecateComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UndeprecateComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UndeprecateComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UndeprecateComponent(ctx, req.(*UndeprecateComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CreateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CreateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "componenthub.v1.CatalogService",
+	HandlerType: (*CatalogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListComponents",
+			Handler:    _CatalogService_ListComponents_Handler,
+		},
+		{
+			MethodName: "GetComponent",
+			Handler:    _CatalogService_GetComponent_Handler,
+		},
+		{
+			MethodName: "CreateComponent",
+			Handler:    _CatalogService_CreateComponent_Handler,
+		},
+		{
+			MethodName: "UpdateComponent",
+			Handler:    _CatalogService_UpdateComponent_Handler,
+		},
+		{
+			MethodName: "DeleteComponent",
+			Handler:    _CatalogService_DeleteComponent_Handler,
+		},
+		{
+			MethodName: "AddComponentTag",
+			Handler:    _CatalogService_AddComponentTag_Handler,
+		},
+		{
+			MethodName: "UpdateComponentStatus",
+			Handler:    _CatalogService_UpdateComponentStatus_Handler,
+		},
+		{
+			MethodName: "UpdateComponentApproval",
+			Handler:    _CatalogService_UpdateComponentApproval_Handler,
+		},
+		{
+			MethodName: "UpdateComponentVisibility",
+			Handler:    _CatalogService_UpdateComponentVisibility_Handler,
+		},
+		{
+			MethodName: "DeprecateComponent",
+			Handler:    _CatalogService_DeprecateComponent_Handler,
+		},
+		{
+			MethodName: "UndeprecateComponent",
+			Handler:    _CatalogService_UndeprecateComponent_Handler,
+		},
+		{
+			MethodName: "ListCategories",
+			Handler:    _CatalogService_ListCategories_Handler,
+		},
+		{
+			MethodName: "CreateCategory",
+			Handler:    _CatalogService_CreateCategory_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _CatalogService_ListTags_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _CatalogService_CreateTag_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "componenthub/v1/catalog.proto",
+}
@@ -0,0 +1,277 @@
+// Package grpcapi menjalankan API gRPC CatalogService
+// (proto/componenthub/v1/catalog.proto) di port terpisah. Setiap RPC
+// menjalankan endpoint REST yang sama lewat handler.Dispatch, jadi logika
+// bisnis, auth dan scope API key tidak diduplikasi. Token dikirim lewat
+// metadata "authorization: Bearer <token>".
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	pb "service_components/internal/grpcapi/componenthubv1"
+	"service_components/internal/handler"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// forwardedMetadata adalah metadata yang diteruskan sebagai header HTTP.
+var forwardedMetadata = []string{"authorization", "x-request-id", "user-agent"}
+
+// NewServer membuat server gRPC CatalogService di atas router, dengan server
+// reflection supaya grpcurl dan tooling lain bisa membaca service-nya.
+func NewServer(router http.Handler) *grpc.Server {
+	server := grpc.NewServer()
+	pb.RegisterCatalogServiceServer(server, &Server{Router: router})
+	reflection.Register(server)
+	return server
+}
+
+// Serve menjalankan NewServer di port.
+func Serve(port string, router http.Handler) {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("FATAL: failed to listen for gRPC on :%s: %v", port, err)
+	}
+	server := NewServer(router)
+
+	slog.Info("gRPC server listening", "port", port)
+	if err := server.Serve(listener); err != nil {
+		log.Fatalf("FATAL: gRPC server stopped: %v", err)
+	}
+}
+
+type Server struct {
+	pb.UnimplementedCatalogServiceServer
+	Router http.Handler
+}
+
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+}
+
+// call menjalankan endpoint REST dengan in sebagai body JSON (nama field
+// proto, yang sama dengan JSON REST) dan mengembalikan data envelope-nya.
+func (s *Server) call(ctx context.Context, method, path string, in proto.Message) (json.RawMessage, error) {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = (protojson.MarshalOptions{UseProtoNames: true}).Marshal(in); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	header := http.Header{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range forwardedMetadata {
+		for _, value := range md.Get(key) {
+			header.Add(key, value)
+		}
+	}
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+
+	// RPC tidak melewati router HTTP sebelumnya, jadi dispatch ini tidak
+	// ditandai internal: rate limit, metrics dan access log dihitung sekali
+	// di sini.
+	result, err := handler.Dispatch(ctx, s.Router, method, path, header, remoteAddr, body)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if result.Status >= http.StatusBadRequest {
+		code, ok := grpcCodes[result.Status]
+		if !ok {
+			code = codes.Internal
+		}
+		return nil, status.Error(code, result.Error)
+	}
+	return result.Data, nil
+}
+
+// decode membaca JSON REST ke message proto. Field yang tidak ada di proto
+// (misalnya files dan screenshots) diabaikan.
+func decode(data []byte, out proto.Message) error {
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, out); err != nil {
+		slog.Error("grpc: failed to decode response", "type", fmt.Sprintf("%T", out), "error", err)
+		return status.Error(codes.Internal, "Failed to decode response")
+	}
+	return nil
+}
+
+// decodeList membaca array JSON REST ke field list message.
+func decodeList(field string, data json.RawMessage, out proto.Message) error {
+	if len(data) == 0 || string(data) == "null" {
+		data = json.RawMessage("[]")
+	}
+	name, _ := json.Marshal(field)
+	return decode([]byte("{"+string(name)+":"+string(data)+"}"), out)
+}
+
+func componentPath(slug, suffix string) string {
+	return "/components/" + url.PathEscape(slug) + suffix
+}
+
+// component menjalankan mutation komponen lalu membaca komponen hasilnya
+// dengan GET /components/{slug}, supaya response selalu lengkap (kategori,
+// tag, varian).
+func (s *Server) component(ctx context.Context, method, path string, in proto.Message) (*pb.Component, error) {
+	data, err := s.call(ctx, method, path, in)
+	if err != nil {
+		return nil, err
+	}
+	var changed struct {
+		Slug string `json:"slug"`
+	}
+	if err := json.Unmarshal(data, &changed); err != nil || changed.Slug == "" {
+		return nil, status.Error(codes.Internal, "Failed to decode response")
+	}
+	return s.GetComponent(ctx, &pb.GetComponentRequest{Slug: changed.Slug})
+}
+
+func (s *Server) ListComponents(ctx context.Context, req *pb.ListComponentsRequest) (*pb.ListComponentsResponse, error) {
+	query := url.Values{}
+	if len(req.Tags) > 0 {
+		query.Set("tag", strings.Join(req.Tags, ","))
+	}
+	for key, value := range map[string]string{
+		"category":   req.Category,
+		"status":     req.Status,
+		"approval":   req.Approval,
+		"framework":  req.Framework,
+		"q":          req.Q,
+		"deprecated": req.Deprecated,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(int(req.Page)))
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(int(req.Limit)))
+	}
+
+	path := "/components"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	data, err := s.call(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListComponentsResponse{}
+	return resp, decodeList("components", data, resp)
+}
+
+func (s *Server) GetComponent(ctx context.Context, req *pb.GetComponentRequest) (*pb.Component, error) {
+	path := componentPath(req.Slug, "")
+	if req.Version != "" {
+		path += "?version=" + url.QueryEscape(req.Version)
+	}
+	data, err := s.call(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	component := &pb.Component{}
+	return component, decode(data, component)
+}
+
+func (s *Server) CreateComponent(ctx context.Context, req *pb.CreateComponentRequest) (*pb.Component, error) {
+	return s.component(ctx, http.MethodPost, "/components", req)
+}
+
+func (s *Server) UpdateComponent(ctx context.Context, req *pb.UpdateComponentRequest) (*pb.Component, error) {
+	return s.component(ctx, http.MethodPatch, componentPath(req.Slug, ""), req)
+}
+
+func (s *Server) DeleteComponent(ctx context.Context, req *pb.DeleteComponentRequest) (*emptypb.Empty, error) {
+	if _, err := s.call(ctx, http.MethodDelete, componentPath(req.Slug, ""), nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) AddComponentTag(ctx context.Context, req *pb.AddComponentTagRequest) (*pb.Component, error) {
+	return s.component(ctx, http.MethodPost, componentPath(req.Slug, "/tags"), req)
+}
+
+func (s *Server) UpdateComponentStatus(ctx context.Context, req *pb.UpdateComponentStatusRequest) (*pb.Component, error) {
+	return s.component(ctx, http.MethodPatch, componentPath(req.Slug, "/status"), req)
+}
+
+func (s *Server) UpdateComponentApproval(ctx context.Context, req *pb.UpdateComponentApprovalRequest) (*pb.Component, error) {
+	return s.component(ctx, http.MethodPatch, componentPath(req.Slug, "/approval"), req)
+}
+
+func (s *Server) UpdateComponentVisibility(ctx context.Context, req *pb.UpdateComponentVisibilityRequest) (*pb.Component, error) {
+	return s.component(ctx, http.MethodPatch, componentPath(req.Slug, "/visibility"), req)
+}
+
+func (s *Server) DeprecateComponent(ctx context.Context, req *pb.DeprecateComponentRequest) (*pb.Component, error) {
+	return s.component(ctx, http.MethodPut, componentPath(req.Slug, "/deprecation"), req)
+}
+
+func (s *Server) UndeprecateComponent(ctx context.Context, req *pb.UndeprecateComponentRequest) (*pb.Component, error) {
+	return s.component(ctx, http.MethodDelete, componentPath(req.Slug, "/deprecation"), nil)
+}
+
+func (s *Server) ListCategories(ctx context.Context, _ *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	data, err := s.call(ctx, http.MethodGet, "/categories", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListCategoriesResponse{}
+	return resp, decodeList("categories", data, resp)
+}
+
+func (s *Server) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.Category, error) {
+	data, err := s.call(ctx, http.MethodPost, "/categories", req)
+	if err != nil {
+		return nil, err
+	}
+	category := &pb.Category{}
+	return category, decode(data, category)
+}
+
+func (s *Server) ListTags(ctx context.Context, _ *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	data, err := s.call(ctx, http.MethodGet, "/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListTagsResponse{}
+	return resp, decodeList("tags", data, resp)
+}
+
+func (s *Server) CreateTag(ctx context.Context, req *pb.CreateTagRequest) (*pb.Tag, error) {
+	data, err := s.call(ctx, http.MethodPost, "/tags", req)
+	if err != nil {
+		return nil, err
+	}
+	tag := &pb.Tag{}
+	return tag, decode(data, tag)
+}
@@ -0,0 +1,171 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	pb "service_components/internal/grpcapi/componenthubv1"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeRouter meniru router REST: tanpa token 401, token "reader" tidak
+// boleh menulis (403), dan slug "status-<kode>" menjawab dengan kode itu.
+type fakeRouter struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+}
+
+func (f *fakeRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	f.requests = append(f.requests, r)
+	f.bodies = append(f.bodies, string(body))
+	f.mu.Unlock()
+
+	reply := func(status int, data any, message string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		var errorValue any
+		if message != "" {
+			errorValue = message
+		}
+		json.NewEncoder(w).Encode(map[string]any{"success": status < 400, "data": data, "error": errorValue})
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	switch {
+	case token == "":
+		reply(http.StatusUnauthorized, nil, "Authentication required")
+	case token == "reader" && r.Method != http.MethodGet:
+		reply(http.StatusForbidden, nil, "Insufficient scope")
+	case strings.HasPrefix(r.URL.Path, "/api/v1/components/status-"):
+		code, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/v1/components/status-"))
+		reply(code, nil, "")
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/components/button":
+		reply(http.StatusOK, map[string]any{"slug": "button", "name": "Button", "category": map[string]any{"slug": "forms"}, "files": []any{}}, "")
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/categories":
+		reply(http.StatusCreated, map[string]any{"slug": "forms", "name": "Forms"}, "")
+	default:
+		reply(http.StatusNotFound, nil, "Not Found")
+	}
+}
+
+func (f *fakeRouter) last() (*http.Request, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[len(f.requests)-1], f.bodies[len(f.bodies)-1]
+}
+
+// dial menjalankan NewServer di atas bufconn dan mengembalikan client-nya.
+func dial(t *testing.T, router http.Handler) pb.CatalogServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(router)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewCatalogServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token, "x-request-id", "req-123")
+}
+
+func TestAuthMetadataRoundTrip(t *testing.T) {
+	router := &fakeRouter{}
+	client := dial(t, router)
+
+	component, err := client.GetComponent(withToken("writer"), &pb.GetComponentRequest{Slug: "button"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if component.Slug != "button" || component.Name != "Button" || component.Category.GetSlug() != "forms" {
+		t.Errorf("component %v", component)
+	}
+	req, _ := router.last()
+	if req.Header.Get("Authorization") != "Bearer writer" || req.Header.Get("X-Request-Id") != "req-123" {
+		t.Errorf("forwarded headers %v", req.Header)
+	}
+	if req.Method != http.MethodGet || req.URL.Path != "/api/v1/components/button" {
+		t.Errorf("request %s %s", req.Method, req.URL)
+	}
+
+	category, err := client.CreateCategory(withToken("writer"), &pb.CreateCategoryRequest{Name: "Forms"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, body := router.last()
+	if category.Slug != "forms" || body != `{"name":"Forms"}` || req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("category %v, body %s, content type %q", category, body, req.Header.Get("Content-Type"))
+	}
+}
+
+func TestHTTPErrorsMapToStatusCodes(t *testing.T) {
+	client := dial(t, &fakeRouter{})
+
+	tests := []struct {
+		name    string
+		call    func() error
+		code    codes.Code
+		message string
+	}{
+		{"missing token", func() error {
+			_, err := client.GetComponent(context.Background(), &pb.GetComponentRequest{Slug: "button"})
+			return err
+		}, codes.Unauthenticated, "Authentication required"},
+		{"read-only token", func() error {
+			_, err := client.CreateCategory(withToken("reader"), &pb.CreateCategoryRequest{Name: "Forms"})
+			return err
+		}, codes.PermissionDenied, "Insufficient scope"},
+		{"unknown component", func() error {
+			_, err := client.GetComponent(withToken("reader"), &pb.GetComponentRequest{Slug: "missing"})
+			return err
+		}, codes.NotFound, "Not Found"},
+	}
+	for status, code := range map[int]codes.Code{
+		http.StatusBadRequest:          codes.InvalidArgument,
+		http.StatusConflict:            codes.AlreadyExists,
+		http.StatusTooManyRequests:     codes.ResourceExhausted,
+		http.StatusServiceUnavailable:  codes.Unavailable,
+		http.StatusInternalServerError: codes.Internal,
+		http.StatusTeapot:              codes.Internal,
+	} {
+		slug := "status-" + strconv.Itoa(status)
+		tests = append(tests, struct {
+			name    string
+			call    func() error
+			code    codes.Code
+			message string
+		}{slug, func() error {
+			_, err := client.GetComponent(withToken("reader"), &pb.GetComponentRequest{Slug: slug})
+			return err
+		}, code, http.StatusText(status)})
+	}
+
+	for _, tt := range tests {
+		st, _ := status.FromError(tt.call())
+		if st.Code() != tt.code || st.Message() != tt.message {
+			t.Errorf("%s: %v %q, want %v %q", tt.name, st.Code(), st.Message(), tt.code, tt.message)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"service_components/internal/auth"
+	"service_components/internal/database"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays int      `json:"expires_in_days"`
+	Workspace     string   `json:"workspace"`
+}
+
+// keyPrefix adalah bagian awal key yang aman ditampilkan untuk mengenali key.
+func keyPrefix(key string) string {
+	return key[:len(auth.APIKeyPrefix)+8]
+}
+
+// requireUserSession menolak request yang memakai API key, supaya API key
+// yang bocor tidak bisa dipakai membuat key baru.
+func requireUserSession(c *gin.Context) (uuid.UUID, bool) {
+	callerID, ok := requireCaller(c)
+	if !ok {
+		return uuid.Nil, false
+	}
+	if _, usesKey := middleware.APIKey(c); usesKey {
+		utils.Error(c, http.StatusForbidden, "API keys cannot manage API keys")
+		return uuid.Nil, false
+	}
+	return callerID, true
+}
+
+// adminWorkspace mencari workspace yang pemanggilnya owner atau admin.
+func adminWorkspace(c *gin.Context, slug string) (*model.Workspace, bool) {
+	var workspace model.Workspace
+	err := database.DB.WithContext(c.Request.Context()).Where("slug = ?", slug).First(&workspace).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && scopeFor(c).role(workspace.ID) == "") {
+		utils.Error(c, http.StatusNotFound, "Workspace Not Found")
+		return nil, false
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find workspace")
+		return nil, false
+	}
+	if role := scopeFor(c).role(workspace.ID); role != model.WorkspaceRoleOwner && role != model.WorkspaceRoleAdmin {
+		utils.Error(c, http.StatusForbidden, "Only owners and admins can manage workspace API keys")
+		return nil, false
+	}
+	return &workspace, true
+}
+
+// findAPIKey mengambil key milik pemanggil, atau key workspace yang
+// pemanggilnya owner/admin.
+func findAPIKey(c *gin.Context, callerID uuid.UUID) (*model.APIKey, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusNotFound, "API Key Not Found")
+		return nil, false
+	}
+
+	var key model.APIKey
+	err = database.DB.WithContext(c.Request.Context()).Where("id = ?", id).First(&key).Error
+	if err == nil && key.UserID != callerID {
+		role := ""
+		if key.WorkspaceID != nil {
+			role = scopeFor(c).role(*key.WorkspaceID)
+		}
+		if role != model.WorkspaceRoleOwner && role != model.WorkspaceRoleAdmin {
+			err = gorm.ErrRecordNotFound
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "API Key Not Found")
+		return nil, false
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find API key")
+		return nil, false
+	}
+	return &key, true
+}
+
+// CreateAPIKey membuat API key untuk CI atau service dengan scope
+// components:read, components:write dan/atau review. Jika workspace diisi, key
+// menjadi milik workspace dan hanya owner/admin yang boleh membuatnya. Key
+// hanya dikembalikan sekali; yang disimpan hanya hash-nya.
+func CreateAPIKey(c *gin.Context) {
+	callerID, ok := requireUserSession(c)
+	if !ok {
+		return
+	}
+
+	var input CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		utils.Error(c, http.StatusBadRequest, "name is required")
+		return
+	}
+	if len(input.Scopes) == 0 {
+		utils.Error(c, http.StatusBadRequest, "scopes is required")
+		return
+	}
+	scopes := make([]string, 0, len(input.Scopes))
+	for _, scope := range input.Scopes {
+		if !slices.Contains(model.APIKeyScopes, scope) {
+			utils.Error(c, http.StatusBadRequest, "scopes must be components:read, components:write or review")
+			return
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if input.ExpiresInDays < 0 {
+		utils.Error(c, http.StatusBadRequest, "expires_in_days must not be negative")
+		return
+	}
+
+	key := model.APIKey{Name: name, UserID: callerID, Scopes: scopes}
+	if input.Workspace != "" {
+		workspace, ok := adminWorkspace(c, input.Workspace)
+		if !ok {
+			return
+		}
+		key.WorkspaceID = &workspace.ID
+	}
+	if input.ExpiresInDays > 0 {
+		expires := time.Now().AddDate(0, 0, input.ExpiresInDays).UTC()
+		key.ExpiresAt = &expires
+	}
+
+	secret, err := auth.NewAPIKey()
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to generate API key")
+		return
+	}
+	key.KeyHash = auth.HashToken(secret)
+	key.Prefix = keyPrefix(secret)
+	if err := database.DB.WithContext(c.Request.Context()).Create(&key).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create API key")
+		return
+	}
+
+	key.Key = secret
+	utils.Created(c, key)
+}
+
+// GetAPIKeys mengembalikan API key milik pemanggil, atau API key workspace
+// jika query workspace diisi (owner/admin saja). Secret key tidak pernah ikut
+// dikembalikan.
+func GetAPIKeys(c *gin.Context) {
+	callerID, ok := requireUserSession(c)
+	if !ok {
+		return
+	}
+
+	query := database.DB.WithContext(c.Request.Context()).Where("user_id = ? AND workspace_id IS NULL", callerID)
+	if slug := c.Query("workspace"); slug != "" {
+		workspace, ok := adminWorkspace(c, slug)
+		if !ok {
+			return
+		}
+		query = database.DB.WithContext(c.Request.Context()).Where("workspace_id = ?", workspace.ID)
+	}
+
+	keys := []model.APIKey{}
+	if err := query.Order("created_at desc").Find(&keys).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch API keys")
+		return
+	}
+
+	utils.Success(c, keys)
+}
+
+// RotateAPIKey mengganti secret API key sehingga key lama langsung tidak
+// berlaku. Nama, scope dan masa berlaku tetap; key baru hanya dikembalikan
+// sekali.
+func RotateAPIKey(c *gin.Context) {
+	callerID, ok := requireUserSession(c)
+	if !ok {
+		return
+	}
+	key, ok := findAPIKey(c, callerID)
+	if !ok {
+		return
+	}
+	if key.RevokedAt != nil {
+		utils.Error(c, http.StatusConflict, "API key has been revoked")
+		return
+	}
+
+	secret, err := auth.NewAPIKey()
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to generate API key")
+		return
+	}
+	now := time.Now().UTC()
+	result := database.DB.WithContext(c.Request.Context()).Model(&model.APIKey{}).Where("id = ? AND key_hash = ?", key.ID, key.KeyHash).Updates(map[string]interface{}{
+		"key_hash":     auth.HashToken(secret),
+		"prefix":       keyPrefix(secret),
+		"rotated_at":   now,
+		"last_used_at": nil,
+	})
+	if result.Error != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to rotate API key")
+		return
+	}
+	if result.RowsAffected == 0 {
+		utils.Error(c, http.StatusConflict, "API key was rotated concurrently")
+		return
+	}
+
+	key.Prefix = keyPrefix(secret)
+	key.RotatedAt = &now
+	key.LastUsedAt = nil
+	key.Key = secret
+	utils.Success(c, key)
+}
+
+// RevokeAPIKey mencabut API key sehingga request dengan key itu ditolak.
+func RevokeAPIKey(c *gin.Context) {
+	callerID, ok := requireUserSession(c)
+	if !ok {
+		return
+	}
+	key, ok := findAPIKey(c, callerID)
+	if !ok {
+		return
+	}
+
+	if key.RevokedAt == nil {
+		if err := database.DB.WithContext(c.Request.Context()).Model(key).Update("revoked_at", time.Now().UTC()).Error; err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to revoke API key")
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,379 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"service_components/internal/database"
+	"service_components/internal/logging"
+	"service_components/internal/model"
+	"service_components/internal/storage"
+	"service_components/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const maxAssetsPerUpload = 10
+
+// allowedAssetTypes adalah content type hasil sniffing yang boleh diupload.
+var allowedAssetTypes = map[string]bool{
+	"image/png":     true,
+	"image/jpeg":    true,
+	"image/gif":     true,
+	"image/webp":    true,
+	"image/svg+xml": true,
+	"image/x-icon":  true,
+	"font/woff":     true,
+	"font/woff2":    true,
+	"font/ttf":      true,
+	"font/otf":      true,
+}
+
+// sniffContentType menentukan content type dari isi file, bukan dari header
+// yang dikirim client. SVG terdeteksi sebagai XML/teks oleh
+// http.DetectContentType sehingga dicek terpisah.
+func sniffContentType(name string, data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+
+	if strings.EqualFold(path.Ext(name), ".svg") &&
+		(contentType == "text/xml" || contentType == "text/plain") &&
+		bytes.Contains(data, []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return contentType
+}
+
+func signedAssetURL(asset *model.Asset) string {
+	expires := time.Now().Add(storage.SignedURLTTL)
+	id := asset.ID.String()
+	return "/api/v1/assets/" + id + "/download?expires=" + strconv.FormatInt(expires.Unix(), 10) +
+		"&signature=" + storage.Sign(id, expires)
+}
+
+// UploadComponentAssets menyimpan gambar, icon atau font (multipart, field
+// "file", boleh lebih dari satu) sebagai asset komponen. Semua file disimpan
+// dalam satu transaksi: jika satu gagal, tidak ada yang tersimpan.
+func UploadComponentAssets(c *gin.Context) {
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, storage.MaxUploadSize*maxAssetsPerUpload+(1<<20))
+	form, err := c.MultipartForm()
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			utils.Error(c, http.StatusRequestEntityTooLarge, "Upload is too large")
+			return
+		}
+		utils.Error(c, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	headers := form.File["file"]
+	if len(headers) == 0 {
+		utils.Error(c, http.StatusBadRequest, "At least one file is required")
+		return
+	}
+	if len(headers) > maxAssetsPerUpload {
+		utils.Error(c, http.StatusBadRequest, fmt.Sprintf("At most %d files per upload", maxAssetsPerUpload))
+		return
+	}
+
+	uploads := make([]*assetUpload, 0, len(headers))
+	for _, header := range headers {
+		upload, status, err := readAsset(header)
+		if err != nil {
+			utils.Error(c, status, err.Error())
+			return
+		}
+		uploads = append(uploads, upload)
+	}
+
+	// Semua file disimpan dalam satu transaksi. Jika satu file gagal, asset
+	// sebelumnya ikut dibatalkan dan blob yang sempat diupload dibersihkan.
+	var stored []string
+	assets := make([]model.Asset, 0, len(uploads))
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		for _, upload := range uploads {
+			asset, err := storeAsset(c, tx, component, upload, &stored)
+			if err != nil {
+				return err
+			}
+			asset.URL = signedAssetURL(asset)
+			assets = append(assets, *asset)
+		}
+		return nil
+	})
+	if err != nil {
+		discardBlobs(c.Request.Context(), stored)
+		utils.Error(c, http.StatusInternalServerError, "Failed to store assets")
+		return
+	}
+
+	utils.Created(c, assets)
+}
+
+// assetUpload adalah file upload yang sudah lolos validasi.
+type assetUpload struct {
+	name        string
+	contentType string
+	data        []byte
+}
+
+// readAsset membaca satu file upload dan memvalidasi ukuran serta content
+// type-nya.
+func readAsset(header *multipart.FileHeader) (*assetUpload, int, error) {
+	if header.Size > storage.MaxUploadSize {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("%s exceeds the %d MB limit", header.Filename, storage.MaxUploadSize>>20)
+	}
+
+	f, err := header.Open()
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, storage.MaxUploadSize+1))
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if int64(len(data)) > storage.MaxUploadSize {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("%s exceeds the %d MB limit", header.Filename, storage.MaxUploadSize>>20)
+	}
+
+	contentType := sniffContentType(header.Filename, data)
+	if !allowedAssetTypes[contentType] {
+		return nil, http.StatusUnsupportedMediaType, fmt.Errorf("%s: unsupported content type %s", header.Filename, contentType)
+	}
+	return &assetUpload{name: header.Filename, contentType: contentType, data: data}, http.StatusOK, nil
+}
+
+// storeAsset menyimpan satu file di dalam tx. Blob yang hash-nya sudah ada
+// tidak diupload ulang, dan asset yang sama pada komponen yang sama
+// dikembalikan apa adanya. Key blob yang baru diupload ditambahkan ke stored.
+func storeAsset(c *gin.Context, tx *gorm.DB, component *model.Component, upload *assetUpload, stored *[]string) (*model.Asset, error) {
+	hash, key := storage.HashKey(upload.data)
+
+	var existing model.Asset
+	result := tx.Where("component_id = ? AND hash = ?", component.ID, hash).Limit(1).Find(&existing)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return &existing, nil
+	}
+
+	put, err := putBlob(tx, key, upload.data, upload.contentType)
+	if err != nil {
+		return nil, err
+	}
+	if put {
+		*stored = append(*stored, key)
+	}
+
+	asset := model.Asset{
+		ComponentID: component.ID,
+		FileName:    strings.ReplaceAll(path.Base(strings.ReplaceAll(upload.name, "\\", "/")), `"`, ""),
+		ContentType: upload.contentType,
+		Size:        int64(len(upload.data)),
+		Hash:        hash,
+		StorageKey:  key,
+	}
+	if err := tx.Create(&asset).Error; err != nil {
+		return nil, err
+	}
+	if err := recordAudit(c, tx, "asset.create", model.AuditEntityComponent, component.ID, component.Slug, nil, &asset); err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// GetComponentAssets mengembalikan asset komponen beserta signed URL download
+// masing-masing.
+func GetComponentAssets(c *gin.Context) {
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var assets []model.Asset
+	if err := database.DB.WithContext(c.Request.Context()).Where("component_id = ?", component.ID).Order("created_at asc").Find(&assets).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch assets")
+		return
+	}
+	for i := range assets {
+		assets[i].URL = signedAssetURL(&assets[i])
+	}
+
+	utils.Success(c, assets)
+}
+
+// DeleteComponentAsset menghapus asset dari komponen. Blob di storage ikut
+// dihapus jika tidak lagi dipakai asset lain.
+func DeleteComponentAsset(c *gin.Context) {
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var asset model.Asset
+	err := database.DB.WithContext(c.Request.Context()).Where("id = ? AND component_id = ?", c.Param("id"), component.ID).First(&asset).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Asset Not Found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find asset")
+		return
+	}
+
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&asset).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(c, tx, "asset.delete", model.AuditEntityComponent, component.ID, component.Slug, &asset, nil); err != nil {
+			return err
+		}
+		return releaseBlobs(tx, asset.StorageKey)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to delete asset")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// lockBlobs mengambil advisory lock transaksi untuk setiap key blob. Key
+// dibentuk dari hash isi file, jadi asset dan screenshot dengan isi yang sama
+// berbagi blob. Dengan lock ini putBlob dan releaseBlobs untuk key yang sama
+// berjalan bergantian di dalam transaksi yang juga menulis baris
+// referensinya, sehingga blob tidak bisa terhapus di antara pengecekan Exists
+// dan commit baris yang memakainya. Key dikunci berurutan supaya dua
+// transaksi tidak saling menunggu. SQLite (dipakai test) sudah menyerialkan
+// transaksi tulis, jadi lock hanya diambil di Postgres.
+func lockBlobs(tx *gorm.DB, keys ...string) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+	for _, key := range slices.Compact(keys) {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putBlob mengupload data ke key jika belum ada dan melaporkan apakah blob
+// baru diupload.
+func putBlob(tx *gorm.DB, key string, data []byte, contentType string) (bool, error) {
+	if err := lockBlobs(tx, key); err != nil {
+		return false, err
+	}
+	ctx := tx.Statement.Context
+	exists, err := storage.Store.Exists(ctx, key)
+	if err != nil || exists {
+		return false, err
+	}
+	if err := storage.Store.Put(ctx, key, data, contentType); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseBlobs menghapus blob yang tidak lagi dipakai asset maupun
+// screenshot. Panggil di dalam transaksi yang menghapus barisnya, setelah
+// baris dihapus. Gagal menghapus blob hanya di-log: akibatnya hanya blob
+// yatim.
+func releaseBlobs(tx *gorm.DB, keys ...string) error {
+	if err := lockBlobs(tx, keys...); err != nil {
+		return err
+	}
+	ctx := tx.Statement.Context
+	for _, key := range keys {
+		var assets, screenshots int64
+		if err := tx.Model(&model.Asset{}).Where("storage_key = ?", key).Count(&assets).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Screenshot{}).Where("storage_key = ? OR thumbnail_key = ?", key, key).Count(&screenshots).Error; err != nil {
+			return err
+		}
+		if assets+screenshots > 0 {
+			continue
+		}
+		if err := storage.Store.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			logging.FromContext(ctx).Error("failed to delete blob", "key", key, "error", err)
+		}
+	}
+	return nil
+}
+
+// discardBlobs membersihkan blob yang diupload oleh transaksi yang gagal.
+func discardBlobs(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	err := database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return releaseBlobs(tx, keys...)
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to discard blobs", "keys", keys, "error", err)
+	}
+}
+
+// DownloadAsset mengirim isi asset jika signed URL dari endpoint list/upload
+// valid dan belum kedaluwarsa.
+func DownloadAsset(c *gin.Context) {
+	id := c.Param("id")
+	if !storage.Verify(id, c.Query("expires"), c.Query("signature")) {
+		utils.Error(c, http.StatusForbidden, "Invalid or expired signature")
+		return
+	}
+
+	var asset model.Asset
+	err := database.DB.WithContext(c.Request.Context()).Where("id = ?", id).First(&asset).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Asset Not Found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find asset")
+		return
+	}
+
+	blob, err := storage.Store.Get(c.Request.Context(), asset.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		utils.Error(c, http.StatusNotFound, "Asset content missing")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to read asset")
+		return
+	}
+	defer blob.Close()
+
+	c.Header("Content-Disposition", `inline; filename="`+asset.FileName+`"`)
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("ETag", `"`+asset.Hash+`"`)
+	if asset.ContentType == "image/svg+xml" {
+		c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	}
+	c.DataFromReader(http.StatusOK, asset.Size, asset.ContentType, blob, nil)
+}
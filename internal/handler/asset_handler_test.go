@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"service_components/internal/database"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/storage"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// failingStore adalah LocalStore yang menolak Put untuk key tertentu.
+type failingStore struct {
+	*storage.LocalStore
+	failPut string
+}
+
+func (s *failingStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if key == s.failPut {
+		return errors.New("storage unavailable")
+	}
+	return s.LocalStore.Put(ctx, key, data, contentType)
+}
+
+// useStore memasang LocalStore di direktori sementara sebagai storage.Store.
+func useStore(t *testing.T) *failingStore {
+	t.Helper()
+	store := &failingStore{LocalStore: &storage.LocalStore{Root: t.TempDir()}}
+	previous, previousSize := storage.Store, storage.MaxUploadSize
+	storage.Store, storage.MaxUploadSize = store, 1<<20
+	t.Cleanup(func() { storage.Store, storage.MaxUploadSize = previous, previousSize })
+	return store
+}
+
+// pngFile membuat PNG 1x1 dengan warna tersebut, jadi isi dan key blob-nya
+// berbeda per warna.
+func pngFile(t *testing.T, shade uint8) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, 1, 1))
+	img.Pix[0] = shade
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// uploadAssets mengirim files sebagai multipart field "file".
+func uploadAssets(t *testing.T, token, slug string, files ...[]byte) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, data := range files {
+		part, err := mw.CreateFormFile("file", "icon.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(data)
+	}
+	mw.Close()
+
+	engine := gin.New()
+	engine.Use(middleware.Authenticate())
+	engine.POST("/components/:slug/assets", UploadComponentAssets)
+	req := httptest.NewRequest(http.MethodPost, "/components/"+slug+"/assets", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func blobExists(t *testing.T, data []byte) bool {
+	t.Helper()
+	_, key := storage.HashKey(data)
+	exists, err := storage.Store.Exists(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return exists
+}
+
+func countAssets(t *testing.T) int64 {
+	t.Helper()
+	var n int64
+	if err := database.DB.Model(&model.Asset{}).Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestUploadAssetsIsAllOrNothing(t *testing.T) {
+	openTestDB(t)
+	store := useStore(t)
+	author, token := newUser(t, "author@example.com", model.UserRoleUser)
+	newComponent(t, "button", author.ID, nil, model.VisibilityPublic)
+	first, second := pngFile(t, 10), pngFile(t, 20)
+
+	// File kedua ditolak saat validasi: file pertama tidak ikut tersimpan.
+	if w := uploadAssets(t, token, "button", first, []byte("plain text")); w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("invalid file: status %d, want 415", w.Code)
+	}
+	if n := countAssets(t); n != 0 || blobExists(t, first) {
+		t.Errorf("invalid file: %d assets, blob kept %v", n, blobExists(t, first))
+	}
+
+	// Storage gagal di file kedua: asset dan blob file pertama dibatalkan.
+	_, store.failPut = storage.HashKey(second)
+	if w := uploadAssets(t, token, "button", first, second); w.Code != http.StatusInternalServerError {
+		t.Fatalf("storage failure: status %d, want 500", w.Code)
+	}
+	if n := countAssets(t); n != 0 || blobExists(t, first) {
+		t.Errorf("storage failure: %d assets, blob kept %v", n, blobExists(t, first))
+	}
+
+	store.failPut = ""
+	if w := uploadAssets(t, token, "button", first, second, first); w.Code != http.StatusCreated {
+		t.Fatalf("upload: status %d: %s", w.Code, w.Body)
+	}
+	if n := countAssets(t); n != 2 || !blobExists(t, first) || !blobExists(t, second) {
+		t.Errorf("upload: %d assets, want the duplicate stored once", n)
+	}
+}
+
+// Blob baru dihapus setelah referensi terakhir, dari asset maupun
+// screenshot, ikut dihapus.
+func TestDeleteAssetReleasesSharedBlob(t *testing.T) {
+	openTestDB(t)
+	useStore(t)
+	author, token := newUser(t, "author@example.com", model.UserRoleUser)
+	button := newComponent(t, "button", author.ID, nil, model.VisibilityPublic)
+	newComponent(t, "card", author.ID, nil, model.VisibilityPublic)
+	data := pngFile(t, 10)
+
+	for _, slug := range []string{"button", "card"} {
+		if w := uploadAssets(t, token, slug, data); w.Code != http.StatusCreated {
+			t.Fatalf("upload to %s: status %d: %s", slug, w.Code, w.Body)
+		}
+	}
+	hash, key := storage.HashKey(data)
+	screenshot := model.Screenshot{ComponentID: button.ID, ContentType: "image/png", Hash: hash, StorageKey: key, ThumbnailKey: key}
+	if err := database.DB.Create(&screenshot).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	deleteAsset := func(slug string) {
+		t.Helper()
+		var asset model.Asset
+		database.DB.Where("component_id = (SELECT id FROM components WHERE slug = ?)", slug).First(&asset)
+		w := serve(t, token, http.MethodDelete, "/components/:slug/assets/:id", "/components/"+slug+"/assets/"+asset.ID.String(), nil, DeleteComponentAsset)
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("delete: status %d: %s", w.Code, w.Body)
+		}
+	}
+
+	deleteAsset("button")
+	if !blobExists(t, data) {
+		t.Fatal("blob deleted while another asset uses it")
+	}
+	deleteAsset("card")
+	if !blobExists(t, data) {
+		t.Fatal("blob deleted while a screenshot uses it")
+	}
+
+	w := serve(t, token, http.MethodDelete, "/components/:slug/screenshots/:id", "/components/button/screenshots/"+screenshot.ID.String(), nil, DeleteComponentScreenshot)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("delete screenshot: status %d: %s", w.Code, w.Body)
+	}
+	if blobExists(t, data) {
+		t.Error("blob kept after the last reference was deleted")
+	}
+}
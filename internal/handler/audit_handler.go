@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"service_components/internal/database"
+	"service_components/internal/logging"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// auditIgnoredFields tidak ikut dibandingkan karena selalu berubah tiap update.
+var auditIgnoredFields = map[string]bool{"updated_at": true}
+
+// auditComponentRelations adalah relasi komponen yang tidak ikut snapshot;
+// perubahan file, variant, screenshot dst. dicatat sebagai event tersendiri.
+// Tag dicatat sebagai daftar slug di auditSnapshot jika relasinya dimuat.
+var auditComponentRelations = []string{"category", "tags", "variants", "files", "screenshots", "primary_image", "version", "replaced_by", "forked_from"}
+
+// auditSnapshot mengubah entity menjadi map field JSON-nya.
+func auditSnapshot(entity interface{}) (map[string]interface{}, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	if value := reflect.ValueOf(entity); (value.Kind() == reflect.Ptr || value.Kind() == reflect.Map) && value.IsNil() {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, err
+	}
+
+	if component, ok := entity.(*model.Component); ok {
+		for _, field := range auditComponentRelations {
+			delete(snapshot, field)
+		}
+		snapshot["category_id"] = component.CategoryID.String()
+		snapshot["replaced_by_id"] = component.ReplacedByID
+		snapshot["forked_from_id"] = component.ForkedFromID
+		if component.Tags != nil {
+			tags := make([]string, len(component.Tags))
+			for i, tag := range component.Tags {
+				tags[i] = tag.Slug
+			}
+			sort.Strings(tags)
+			snapshot["tags"] = tags
+		}
+	}
+	return snapshot, nil
+}
+
+// auditDiff menyisakan field yang berbeda antara before dan after.
+func auditDiff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for field, value := range after {
+		if auditIgnoredFields[field] {
+			continue
+		}
+		if old, ok := before[field]; !ok || !reflect.DeepEqual(old, value) {
+			changedBefore[field] = before[field]
+			changedAfter[field] = value
+		}
+	}
+	for field, old := range before {
+		if _, ok := after[field]; !ok && !auditIgnoredFields[field] {
+			changedBefore[field] = old
+			changedAfter[field] = nil
+		}
+	}
+	// id tetap disertakan supaya sub-resource (file, screenshot, ...) yang
+	// berubah bisa dikenali.
+	if id, ok := after["id"]; ok && len(changedAfter) > 0 {
+		changedBefore["id"] = id
+		changedAfter["id"] = id
+	}
+	return changedBefore, changedAfter
+}
+
+func auditJSON(snapshot map[string]interface{}) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	return json.Marshal(snapshot)
+}
+
+// recordAudit menyimpan satu audit event lewat db (bisa transaksi yang sedang
+// berjalan). before nil berarti create, after nil berarti delete; untuk
+// update hanya field yang berubah yang disimpan, dan update tanpa perubahan
+// tidak dicatat.
+func recordAudit(c *gin.Context, db *gorm.DB, action, entityType string, entityID uuid.UUID, slug string, before, after interface{}) error {
+	beforeSnapshot, err := auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterSnapshot, err := auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+	if beforeSnapshot != nil && afterSnapshot != nil {
+		beforeSnapshot, afterSnapshot = auditDiff(beforeSnapshot, afterSnapshot)
+		if len(afterSnapshot) == 0 {
+			return nil
+		}
+	}
+
+	event := model.AuditEvent{
+		ActorType:  model.AuditActorAnonymous,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		EntitySlug: slug,
+		RequestID:  logging.RequestID(c.Request.Context()),
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+	if id, ok := middleware.CallerID(c); ok {
+		event.ActorID = &id
+		event.ActorType = model.AuditActorUser
+	}
+	if key, ok := middleware.APIKey(c); ok {
+		event.APIKeyID = &key.ID
+		event.ActorType = model.AuditActorAPIKey
+	}
+	if event.Before, err = auditJSON(beforeSnapshot); err != nil {
+		return err
+	}
+	if event.After, err = auditJSON(afterSnapshot); err != nil {
+		return err
+	}
+
+	return db.Create(&event).Error
+}
+
+// logAudit mencatat audit event di luar transaksi. Kegagalan hanya di-log
+// karena perubahannya sendiri sudah tersimpan.
+func logAudit(c *gin.Context, action, entityType string, entityID uuid.UUID, slug string, before, after interface{}) {
+	if err := recordAudit(c, database.DB.WithContext(c.Request.Context()), action, entityType, entityID, slug, before, after); err != nil {
+		logging.FromContext(c.Request.Context()).Error("failed to record audit event", "action", action, "entity_type", entityType, "entity_id", entityID, "error", err)
+	}
+}
+
+// requireAdmin hanya meloloskan sesi user dengan role admin.
+func requireAdmin(c *gin.Context) bool {
+	if _, ok := requireUserSession(c); !ok {
+		return false
+	}
+	role, ok := userRole(c)
+	if !ok {
+		return false
+	}
+	if role != model.UserRoleAdmin {
+		utils.Error(c, http.StatusForbidden, "Admin role required")
+		return false
+	}
+	return true
+}
+
+// GetAuditEvents mengembalikan riwayat perubahan komponen, kategori dan tag,
+// terbaru dulu, dengan filter entity, action, actor dan rentang waktu. Hanya
+// untuk admin.
+func GetAuditEvents(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	query := database.DB.WithContext(c.Request.Context()).Model(&model.AuditEvent{})
+
+	if entityType := c.Query("entity_type"); entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+	if entity := c.Query("entity"); entity != "" {
+		query = query.Where("entity_slug = ?", entity)
+	}
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	for param, column := range map[string]string{"entity_id": "entity_id", "actor_id": "actor_id"} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, param+" must be a UUID")
+			return
+		}
+		query = query.Where(column+" = ?", id)
+	}
+	for param, op := range map[string]string{"from": ">=", "to": "<="} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, param+" must be an RFC3339 timestamp")
+			return
+		}
+		query = query.Where("created_at "+op+" ?", at)
+	}
+
+	var events []model.AuditEvent
+	err := query.Order("created_at desc").Offset((page - 1) * limit).Limit(limit).Find(&events).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch audit events")
+		return
+	}
+
+	utils.Success(c, events)
+}
@@ -0,0 +1,409 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"service_components/internal/auth"
+	"service_components/internal/database"
+	"service_components/internal/logging"
+	"service_components/internal/mail"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegisterRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8,max=128"`
+	Name     string `json:"name" binding:"required"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	All bool `json:"all"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=128"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+type AuthResponse struct {
+	User model.User `json:"user"`
+	TokenResponse
+}
+
+type MeResponse struct {
+	User       model.User        `json:"user"`
+	Components []model.Component `json:"components"`
+}
+
+// dummyHash dipakai saat email tidak terdaftar atau akun tidak punya password
+// (dibuat lewat SSO) supaya waktu respon login sama dengan akun biasa.
+var dummyHash, _ = auth.HashPassword("componenthub-dummy-password")
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// newTokens membuat pasangan access token dan refresh token baru untuk sesi.
+func newTokens(session *model.Session) (*TokenResponse, error) {
+	access, err := auth.NewToken()
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := auth.NewToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	session.AccessTokenHash = auth.HashToken(access)
+	session.RefreshTokenHash = auth.HashToken(refresh)
+	session.AccessExpiresAt = now.Add(auth.AccessTokenTTL)
+	session.RefreshExpiresAt = now.Add(auth.RefreshTokenTTL)
+	return &TokenResponse{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(auth.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func startSession(c *gin.Context, userID uuid.UUID) (*TokenResponse, error) {
+	session := model.Session{UserID: userID, UserAgent: c.Request.UserAgent()}
+	tokens, err := newTokens(&session)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.DB.WithContext(c.Request.Context()).Create(&session).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Register membuat akun baru dengan email dan password (minimal 8 karakter)
+// lalu langsung membuka sesi untuknya.
+func Register(c *gin.Context) {
+	var input RegisterRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		utils.Error(c, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	email := normalizeEmail(input.Email)
+	var taken int64
+	if err := database.DB.WithContext(c.Request.Context()).Unscoped().Model(&model.User{}).Where("email = ?", email).Count(&taken).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to register user")
+		return
+	}
+	if taken > 0 {
+		utils.Error(c, http.StatusConflict, "Email is already registered")
+		return
+	}
+
+	hash, err := auth.HashPassword(input.Password)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to hash password")
+		return
+	}
+	user := model.User{Email: email, Name: name, PasswordHash: hash, Role: model.UserRoleUser}
+	if err := database.DB.WithContext(c.Request.Context()).Create(&user).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to register user")
+		return
+	}
+
+	tokens, err := startSession(c, user.ID)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create session")
+		return
+	}
+
+	utils.Created(c, AuthResponse{User: user, TokenResponse: *tokens})
+}
+
+// Login memeriksa email dan password lalu mengembalikan access token (untuk
+// header Authorization: Bearer) dan refresh token.
+func Login(c *gin.Context) {
+	var input LoginRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var user model.User
+	err := database.DB.WithContext(c.Request.Context()).Where("email = ?", normalizeEmail(input.Email)).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find user")
+		return
+	}
+
+	hash := user.PasswordHash
+	if hash == "" {
+		hash = dummyHash
+	}
+	match, checkErr := auth.CheckPassword(hash, input.Password)
+	if checkErr != nil {
+		logging.FromContext(c.Request.Context()).Error("invalid password hash", "user_id", user.ID, "error", checkErr)
+	}
+	// Akun SSO tidak punya password; dummyHash tetap dicek supaya waktunya
+	// sama, tetapi hasilnya selalu ditolak.
+	if err != nil || user.PasswordHash == "" || !match {
+		utils.Error(c, http.StatusUnauthorized, "Invalid email or password")
+		return
+	}
+
+	tokens, err := startSession(c, user.ID)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create session")
+		return
+	}
+
+	utils.Success(c, AuthResponse{User: user, TokenResponse: *tokens})
+}
+
+// RefreshToken menukar refresh token dengan pasangan access token dan refresh
+// token baru. Refresh token lama tidak bisa dipakai lagi.
+func RefreshToken(c *gin.Context) {
+	var input RefreshRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var session model.Session
+	var tokens *TokenResponse
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("refresh_token_hash = ? AND revoked_at IS NULL AND refresh_expires_at > ?", auth.HashToken(input.RefreshToken), time.Now().UTC()).
+			First(&session).Error
+		if err != nil {
+			return err
+		}
+		previous := session.RefreshTokenHash
+		if tokens, err = newTokens(&session); err != nil {
+			return err
+		}
+
+		// Syarat refresh_token_hash lama mencegah dua refresh bersamaan
+		// memakai token yang sama.
+		result := tx.Model(&model.Session{}).Where("id = ? AND refresh_token_hash = ?", session.ID, previous).Updates(map[string]interface{}{
+			"access_token_hash":  session.AccessTokenHash,
+			"refresh_token_hash": session.RefreshTokenHash,
+			"access_expires_at":  session.AccessExpiresAt,
+			"refresh_expires_at": session.RefreshExpiresAt,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusUnauthorized, "Invalid or expired refresh token")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to refresh token")
+		return
+	}
+
+	utils.Success(c, tokens)
+}
+
+// Logout mencabut sesi dari access token yang dipakai, atau semua sesi user
+// jika all bernilai true.
+func Logout(c *gin.Context) {
+	var input LogoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	userID, ok := requireCaller(c)
+	if !ok {
+		return
+	}
+
+	query := database.DB.WithContext(c.Request.Context()).Model(&model.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if !input.All {
+		sessionID, _ := middleware.SessionID(c)
+		query = query.Where("id = ?", sessionID)
+	}
+	if err := query.Update("revoked_at", time.Now().UTC()).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ForgotPassword membuat token reset password dan mengirim link-nya lewat
+// email (MAIL_DRIVER). Respon selalu 202 supaya tidak membocorkan email mana
+// yang terdaftar.
+func ForgotPassword(c *gin.Context) {
+	var input ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	accepted := gin.H{"message": "If the email is registered, a reset link has been sent"}
+
+	var user model.User
+	err := database.DB.WithContext(c.Request.Context()).Where("email = ?", normalizeEmail(input.Email)).First(&user).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.FromContext(c.Request.Context()).Error("failed to find user for password reset", "error", err)
+		}
+		utils.Accepted(c, accepted)
+		return
+	}
+
+	token, err := auth.NewToken()
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+	reset := model.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: time.Now().Add(auth.PasswordResetTTL).UTC(),
+	}
+	if err := database.DB.WithContext(c.Request.Context()).Create(&reset).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create reset token")
+		return
+	}
+
+	// Email dikirim di background supaya waktu response tidak membocorkan
+	// apakah email terdaftar.
+	ctx := context.WithoutCancel(c.Request.Context())
+	msg := mail.PasswordReset(user.Email, token, auth.PasswordResetTTL)
+	go func() {
+		if err := mail.Send(ctx, msg); err != nil {
+			logging.FromContext(ctx).Error("failed to send password reset email", "user_id", user.ID, "error", err)
+		}
+	}()
+	utils.Accepted(c, accepted)
+}
+
+// ResetPassword mengganti password memakai token reset. Token hanya berlaku
+// sekali, dan semua sesi user dicabut setelah password diganti.
+func ResetPassword(c *gin.Context) {
+	var input ResetPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	hash, err := auth.HashPassword(input.Password)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to hash password")
+		return
+	}
+
+	now := time.Now().UTC()
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		// Kunci baris token supaya tidak bisa dipakai dua kali bersamaan.
+		var reset model.PasswordReset
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", auth.HashToken(input.Token), now).
+			First(&reset).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&reset).Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.User{}).Where("id = ?", reset.UserID).Update("password_hash", hash).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Session{}).Where("user_id = ? AND revoked_at IS NULL", reset.UserID).
+			Update("revoked_at", now).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusBadRequest, "Reset token is invalid or expired")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMe mengembalikan profil user yang login beserta komponen buatannya,
+// terbaru dulu.
+func GetMe(c *gin.Context) {
+	userID, ok := requireCaller(c)
+	if !ok {
+		return
+	}
+
+	var user model.User
+	if err := database.DB.WithContext(c.Request.Context()).First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Error(c, http.StatusUnauthorized, "User no longer exists")
+			return
+		}
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch user")
+		return
+	}
+
+	components := []model.Component{}
+	err := database.DB.WithContext(c.Request.Context()).Preload("Category").Preload("Tags").Where("user_id = ?", userID).
+		Order("created_at desc").Find(&components).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch components")
+		return
+	}
+	if err := attachPrimaryImages(database.DB.WithContext(c.Request.Context()), components); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch screenshots")
+		return
+	}
+	refs := make([]*model.Component, len(components))
+	for i := range components {
+		refs[i] = &components[i]
+	}
+	if err := fillRelatedSlugs(database.DB.WithContext(c.Request.Context()), refs...); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch components")
+		return
+	}
+
+	utils.Success(c, MeResponse{User: user, Components: components})
+}
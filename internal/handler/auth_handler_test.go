@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"service_components/internal/auth"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/oidc"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestLoginRejectsSSOAccounts(t *testing.T) {
+	openTestDB(t)
+	user, status, err := provisionUser(database.DB, oidc.Claims{
+		"iss":            "https://idp.example.com",
+		"sub":            "jane",
+		"email":          "jane@example.com",
+		"email_verified": true,
+	})
+	if err != nil {
+		t.Fatalf("provisionUser: %d %v", status, err)
+	}
+	if user.PasswordHash != "" {
+		t.Fatal("user SSO tidak boleh punya password")
+	}
+
+	// Password dummyHash tidak boleh membuka akun yang tidak punya password.
+	for _, password := range []string{"componenthub-dummy-password", testPassword} {
+		w := serve(t, "", http.MethodPost, "/auth/login", "/auth/login",
+			map[string]string{"email": "jane@example.com", "password": password}, Login)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("password %q: status %d, want 401: %s", password, w.Code, w.Body)
+		}
+	}
+}
+
+func TestLogin(t *testing.T) {
+	openTestDB(t)
+	newUser(t, "jane@example.com", model.UserRoleUser)
+
+	tests := []struct {
+		email    string
+		password string
+		want     int
+	}{
+		{"jane@example.com", testPassword, http.StatusOK},
+		{" Jane@Example.com ", testPassword, http.StatusOK},
+		{"jane@example.com", "wrong-horse", http.StatusUnauthorized},
+		{"john@example.com", testPassword, http.StatusUnauthorized},
+		{"john@example.com", "componenthub-dummy-password", http.StatusUnauthorized},
+		{"jane@example.com", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		w := serve(t, "", http.MethodPost, "/auth/login", "/auth/login",
+			map[string]string{"email": tt.email, "password": tt.password}, Login)
+		if w.Code != tt.want {
+			t.Errorf("%s / %q: status %d, want %d: %s", tt.email, tt.password, w.Code, tt.want, w.Body)
+		}
+	}
+}
+
+// login masuk lewat handler Login dan mengembalikan pasangan token sesi baru.
+func login(t *testing.T, email, password string) TokenResponse {
+	t.Helper()
+	w := serve(t, "", http.MethodPost, "/auth/login", "/auth/login",
+		map[string]string{"email": email, "password": password}, Login)
+	if w.Code != http.StatusOK {
+		t.Fatalf("login: status %d: %s", w.Code, w.Body)
+	}
+	var response struct {
+		Data AuthResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	return response.Data.TokenResponse
+}
+
+func refresh(t *testing.T, token string) (int, TokenResponse) {
+	t.Helper()
+	w := serve(t, "", http.MethodPost, "/auth/refresh", "/auth/refresh", map[string]string{"refresh_token": token}, RefreshToken)
+	var response struct {
+		Data TokenResponse `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w.Code, response.Data
+}
+
+func me(t *testing.T, token string) int {
+	t.Helper()
+	return serve(t, token, http.MethodGet, "/me", "/me", nil, GetMe).Code
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	openTestDB(t)
+	newUser(t, "jane@example.com", model.UserRoleUser)
+	first := login(t, "jane@example.com", testPassword)
+
+	status, second := refresh(t, first.RefreshToken)
+	if status != http.StatusOK {
+		t.Fatalf("refresh: status %d", status)
+	}
+	if second.AccessToken == first.AccessToken || second.RefreshToken == first.RefreshToken {
+		t.Fatal("refresh must issue new tokens")
+	}
+	if got := me(t, second.AccessToken); got != http.StatusOK {
+		t.Errorf("new access token: status %d, want 200", got)
+	}
+	if got := me(t, first.AccessToken); got != http.StatusUnauthorized {
+		t.Errorf("old access token: status %d, want 401", got)
+	}
+
+	// Refresh token lama hanya bisa dipakai sekali.
+	if status, _ := refresh(t, first.RefreshToken); status != http.StatusUnauthorized {
+		t.Errorf("reused refresh token: status %d, want 401", status)
+	}
+	if status, _ := refresh(t, second.RefreshToken); status != http.StatusOK {
+		t.Errorf("rotated refresh token: status %d, want 200", status)
+	}
+	if status, _ := refresh(t, "unknown"); status != http.StatusUnauthorized {
+		t.Errorf("unknown refresh token: status %d, want 401", status)
+	}
+}
+
+func TestRefreshTokenRejectsExpiredAndRevokedSessions(t *testing.T) {
+	openTestDB(t)
+	newUser(t, "jane@example.com", model.UserRoleUser)
+	expired := login(t, "jane@example.com", testPassword)
+	database.DB.Model(&model.Session{}).Where("refresh_token_hash = ?", auth.HashToken(expired.RefreshToken)).
+		Update("refresh_expires_at", time.Now().Add(-time.Minute).UTC())
+	if status, _ := refresh(t, expired.RefreshToken); status != http.StatusUnauthorized {
+		t.Errorf("expired: status %d, want 401", status)
+	}
+
+	revoked := login(t, "jane@example.com", testPassword)
+	if w := serve(t, revoked.AccessToken, http.MethodPost, "/auth/logout", "/auth/logout", map[string]bool{"all": true}, Logout); w.Code != http.StatusNoContent {
+		t.Fatalf("logout: status %d", w.Code)
+	}
+	if status, _ := refresh(t, revoked.RefreshToken); status != http.StatusUnauthorized {
+		t.Errorf("revoked: status %d, want 401", status)
+	}
+}
+
+// newResetToken menyimpan token reset password untuk user dan mengembalikan
+// token mentahnya.
+func newResetToken(t *testing.T, userID uuid.UUID, expiresAt time.Time) string {
+	t.Helper()
+	token, err := auth.NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reset := model.PasswordReset{UserID: userID, TokenHash: auth.HashToken(token), ExpiresAt: expiresAt.UTC()}
+	if err := database.DB.Create(&reset).Error; err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestResetPasswordIsSingleUse(t *testing.T) {
+	openTestDB(t)
+	user, session := newUser(t, "jane@example.com", model.UserRoleUser)
+	token := newResetToken(t, user.ID, time.Now().Add(time.Hour))
+
+	reset := func(token, password string) int {
+		return serve(t, "", http.MethodPost, "/auth/password/reset", "/auth/password/reset",
+			map[string]string{"token": token, "password": password}, ResetPassword).Code
+	}
+	if got := reset(token, "battery-staple"); got != http.StatusNoContent {
+		t.Fatalf("reset: status %d, want 204", got)
+	}
+	if got := reset(token, "another-password"); got != http.StatusBadRequest {
+		t.Errorf("reused token: status %d, want 400", got)
+	}
+
+	// Password dari reset pertama yang berlaku dan sesi lama dicabut.
+	login(t, "jane@example.com", "battery-staple")
+	if w := serve(t, "", http.MethodPost, "/auth/login", "/auth/login",
+		map[string]string{"email": "jane@example.com", "password": "another-password"}, Login); w.Code != http.StatusUnauthorized {
+		t.Errorf("second password: status %d, want 401", w.Code)
+	}
+	if got := me(t, session); got != http.StatusUnauthorized {
+		t.Errorf("session before reset: status %d, want 401", got)
+	}
+
+	expired := newResetToken(t, user.ID, time.Now().Add(-time.Minute))
+	if got := reset(expired, "battery-staple"); got != http.StatusBadRequest {
+		t.Errorf("expired token: status %d, want 400", got)
+	}
+	if got := reset("unknown", "battery-staple"); got != http.StatusBadRequest {
+		t.Errorf("unknown token: status %d, want 400", got)
+	}
+}
@@ -8,23 +8,15 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type CreateCategoryRequest struct {
 	Name string `json:"name" binding:"required"`
 }
 
-// CreateCategory godoc
-// @Summary Membuat kategori baru
-// @Description Endpoint untuk menambah kategori baru
-// @Tags Category
-// @Accept json
-// @Produce json
-// @Param data body CreateCategoryRequest true "Data kategori"
-// @Success 201 {object} model.Category
-// @Failure 400 {object} utils.ErrorResponse
-// @Router /categories [post]
-
+// CreateCategory membuat kategori baru dengan slug dari nama (huruf kecil,
+// spasi menjadi "-") dan mencatatnya di audit log.
 func CreateCategory(c *gin.Context) {
 	var input CreateCategoryRequest
 
@@ -39,7 +31,13 @@ func CreateCategory(c *gin.Context) {
 		Name: input.Name,
 		Slug: slug,
 	}
-	if err := database.DB.Create(&category).Error; err != nil {
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&category).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, "create", model.AuditEntityCategory, category.ID, category.Slug, nil, &category)
+	})
+	if err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Gagal menyimpan kategori ke database")
 		return
 	}
@@ -47,10 +45,11 @@ func CreateCategory(c *gin.Context) {
 	utils.Created(c, category)
 }
 
+// GetAllCategories mengembalikan semua kategori, urut berdasarkan nama.
 func GetAllCategories(c *gin.Context) {
 	var categories []model.Category
 
-	if err := database.DB.Order("name asc").Find(&categories).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Order("name asc").Find(&categories).Error; err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Gagal mengambil data kategori")
 		return
 	}
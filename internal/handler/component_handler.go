@@ -20,8 +20,7 @@ type UpdateComponentStatusRequest struct {
 }
 
 type UpdateComponentApprovalRequest struct {
-	ApprovalStatus string    `json:"approval_status"`
-	ReviewerID     uuid.UUID `json:"reviewer_id"`
+	ApprovalStatus string `json:"approval_status"`
 }
 
 type AddComponentTagRequest struct {
@@ -34,27 +33,27 @@ type UpdateComponentRequest struct {
 }
 
 type CreateComponentRequest struct {
-	Name            string      `json:"name" binding:"required"`
-	Description     string      `json:"description"`
-	CategoryID      uuid.UUID   `json:"category_id" binding:"required"`
-	CodeJSX         string      `json:"code_jsx" binding:"required"`
-	CodeCSS         string      `json:"code_css"`
-	PropsDefinition interface{} `json:"props_definition"`
+	Name            string                    `json:"name" binding:"required"`
+	Description     string                    `json:"description"`
+	CategoryID      uuid.UUID                 `json:"category_id" binding:"required"`
+	CodeJSX         string                    `json:"code_jsx"`
+	CodeCSS         string                    `json:"code_css"`
+	Variants        []ComponentVariantRequest `json:"variants"`
+	Files           []ComponentFileRequest    `json:"files"`
+	PropsDefinition interface{}               `json:"props_definition"`
+	Workspace       string                    `json:"workspace"`
+	Visibility      string                    `json:"visibility"`
 }
 
-// CreateComponent godoc
-// @Summary Membuat komponen baru
-// @Description Endpoint untuk menambah komponen UI baru
-// @Tags Component
-// @Accept json
-// @Produce json
-// @Param data body CreateComponentRequest true "Data komponen"
-// @Success 201 {object} model.Component
-// @Failure 400 {object} utils.ErrorResponse
-// @Failure 500 {object} utils.ErrorResponse
-// @Router /components [post]
-
+// CreateComponent membuat komponen baru atas nama pemanggil beserta varian
+// framework dan file sumbernya. Slug diambil dari nama; workspace dan
+// visibility opsional.
 func CreateComponent(c *gin.Context) {
+	userID, ok := requireCaller(c)
+	if !ok {
+		return
+	}
+
 	var input CreateComponentRequest
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -62,8 +61,18 @@ func CreateComponent(c *gin.Context) {
 		return
 	}
 
+	variants, err := buildVariants(input.CodeJSX, input.CodeCSS, input.Variants)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workspaceID, visibility, ok := resolveWorkspace(c, input.Workspace, input.Visibility)
+	if !ok {
+		return
+	}
+
 	var propsJSON []byte
-	var err error
 	if input.PropsDefinition != nil {
 		propsJSON, err = json.Marshal(input.PropsDefinition)
 		if err != nil {
@@ -74,6 +83,12 @@ func CreateComponent(c *gin.Context) {
 
 	slug := strings.ToLower(strings.ReplaceAll(input.Name, " ", "-"))
 
+	files, err := buildFiles(slug, variants, input.Files)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	component := model.Component{
 		Slug:            slug,
 		Name:            input.Name,
@@ -82,15 +97,27 @@ func CreateComponent(c *gin.Context) {
 		CodeJSX:         input.CodeJSX,
 		CodeCSS:         input.CodeCSS,
 		PropsDefinition: propsJSON,
-		UserID:          uuid.New(),
+		UserID:          userID,
+		WorkspaceID:     workspaceID,
+		Visibility:      visibility,
+		Files:           files,
 	}
 
-	if err := database.DB.Create(&component).Error; err != nil {
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&component).Error; err != nil {
+			return err
+		}
+		if err := syncVariants(tx, component.ID); err != nil {
+			return err
+		}
+		return recordComponentChange(c, tx, "create", nil, &component)
+	})
+	if err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Failed to create component: "+err.Error())
 		return
 	}
 	var createdComponent model.Component
-	if err := database.DB.Preload("Category").Preload("Tags").First(&createdComponent, "id = ?", component.ID).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Preload("Category").Preload("Tags").Preload("Variants").Preload("Files").First(&createdComponent, "id = ?", component.ID).Error; err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Gagal mengambil data yang baru dibuat")
 		return
 	}
@@ -98,88 +125,140 @@ func CreateComponent(c *gin.Context) {
 	utils.Created(c, createdComponent)
 }
 
-// GetAllComponents godoc
-// @Summary Get filtered list of components
-// @Description Get components with advanced filtering, search, and pagination
-// @Tags Component
-// @Accept json
-// @Produce json
-// @Param tag query string false "Tag filter (comma separated)"
-// @Param category query string false "Category slug"
-// @Param status query string false "Component status"
-// @Param approval query string false "Approval status"
-// @Param q query string false "Search keyword (name/description)"
-// @Param page query int false "Page number"
-// @Param limit query int false "Items per page"
-// @Success 200 {object} []model.Component
-// @Failure 500 {object} utils.ErrorResponse
-// @Router /components [get]
+// maxPageSize membatasi limit supaya satu request tidak mengambil seluruh
+// katalog sekaligus.
+const maxPageSize = 100
+
+// componentFilter adalah filter daftar komponen, dipakai GET /components dan
+// GraphQL supaya hasilnya sama.
+type componentFilter struct {
+	Tags       []string
+	Category   string
+	Status     string
+	Approval   string
+	Framework  string
+	Search     string
+	Deprecated string
+}
 
-func GetAllComponents(c *gin.Context) {
-	var components []model.Component
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset := (page - 1) * limit
+func (f componentFilter) scope() (func(*gorm.DB) *gorm.DB, error) {
+	switch f.Deprecated {
+	case "", "false", "true", "all":
+	default:
+		return nil, errors.New("deprecated must be false, true or all")
+	}
 
-	tag := c.Query("tag")
-	category := c.Query("category")
-	status := c.Query("status")
-	approval := c.Query("approval")
-	q := c.Query("q")
+	return func(query *gorm.DB) *gorm.DB {
+		switch f.Deprecated {
+		case "", "false":
+			query = query.Where("components.deprecated_at IS NULL")
+		case "true":
+			query = query.Where("components.deprecated_at IS NOT NULL")
+		}
 
-	query := database.DB.Preload("Category").Preload("Tags")
+		if f.Category != "" {
+			query = query.Where("components.category_id IN (SELECT id FROM categories WHERE slug = ? AND deleted_at IS NULL)", f.Category)
+		}
 
-	if category != "" {
-		var cat model.Category
-		if err := database.DB.Where("slug = ?", category).First(&cat).Error; err == nil {
-			query = query.Where("category_id = ?", cat.ID)
+		// Subquery, bukan JOIN, supaya komponen dengan beberapa tag yang cocok
+		// tidak muncul berulang.
+		if len(f.Tags) > 0 {
+			query = query.Where("components.id IN (SELECT component_tags.component_id FROM component_tags JOIN tags ON tags.id = component_tags.tag_id WHERE tags.name IN ?)", f.Tags)
 		}
-	}
 
-	if tag != "" {
-		tagNames := strings.Split(tag, ",")
-		query = query.Joins("JOIN component_tags ON component_tags.component_id = components.id").
-			Joins("JOIN tags ON tags.id = component_tags.tag_id").
-			Where("tags.name IN ?", tagNames)
-	}
+		if f.Status != "" {
+			query = query.Where("components.status = ?", f.Status)
+		}
 
-	if status != "" {
-		query = query.Where("status = ?", status)
-	}
+		if f.Approval != "" {
+			query = query.Where("components.approval_status = ?", f.Approval)
+		}
 
-	if approval != "" {
-		query = query.Where("approval_status = ?", approval)
+		if f.Framework != "" {
+			variantExists := "EXISTS (SELECT 1 FROM component_variants WHERE component_variants.component_id = components.id AND component_variants.framework = ?)"
+			if f.Framework == model.FrameworkReact {
+				query = query.Where("(components.code_jsx <> '' OR "+variantExists+")", f.Framework)
+			} else {
+				query = query.Where(variantExists, f.Framework)
+			}
+		}
+
+		if f.Search != "" {
+			kw := "%" + f.Search + "%"
+			query = query.Where("(components.name ILIKE ? OR components.description ILIKE ?)", kw, kw)
+		}
+		return query
+	}, nil
+}
+
+// GetAllComponents mengembalikan daftar komponen yang boleh dilihat pemanggil,
+// terbaru dulu, dengan filter tag, category, status, approval, framework, q
+// dan deprecated serta paginasi page/limit (maksimal maxPageSize).
+func GetAllComponents(c *gin.Context) {
+	var components []model.Component
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
 	}
+	offset := (page - 1) * limit
 
-	if q != "" {
-		kw := "%" + q + "%"
-		query = query.Where("name ILIKE ? OR description ILIKE ?", kw, kw)
+	filter := componentFilter{
+		Category:   c.Query("category"),
+		Status:     c.Query("status"),
+		Approval:   c.Query("approval"),
+		Framework:  c.Query("framework"),
+		Search:     c.Query("q"),
+		Deprecated: c.DefaultQuery("deprecated", "false"),
+	}
+	if tag := c.Query("tag"); tag != "" {
+		filter.Tags = strings.Split(tag, ",")
+	}
+	filterScope, err := filter.scope()
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	err := query.Offset(offset).Limit(limit).Order("created_at desc").Find(&components).Error
+	query := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c), filterScope).Preload("Category").Preload("Tags").Preload("Variants")
+
+	err = query.Offset(offset).Limit(limit).Order("created_at desc").Find(&components).Error
 	if err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Failed to fetch components")
 		return
 	}
+	if err := attachPrimaryImages(database.DB.WithContext(c.Request.Context()), components); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch screenshots")
+		return
+	}
+	refs := make([]*model.Component, len(components))
+	for i := range components {
+		refs[i] = &components[i]
+	}
+	if err := fillRelatedSlugs(database.DB.WithContext(c.Request.Context()), refs...); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch components")
+		return
+	}
 	utils.Success(c, components)
 }
 
-// GetComponentBySlug godoc
-// @Summary Get component by slug
-// @Description Get detail komponen berdasarkan slug
-// @Tags Component
-// @Accept json
-// @Produce json
-// @Param slug path string true "Slug komponen"
-// @Success 200 {object} model.Component
-// @Failure 404 {object} utils.ErrorResponse
-// @Failure 500 {object} utils.ErrorResponse
-// @Router /components/{slug} [get]
+// GetComponentBySlug mengembalikan detail komponen beserta kategori, tag,
+// varian, file dan screenshot. Query version mengganti kode dan props dengan
+// isi rilis tersebut; komponen deprecated diberi header Deprecation.
 func GetComponentBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	var component model.Component
 
-	err := database.DB.Preload("Category").Preload("Tags").Where("slug = ?", slug).First(&component).Error
+	err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Preload("Category").Preload("Tags").Preload("Variants").
+		Preload("Files", func(db *gorm.DB) *gorm.DB { return db.Order("framework asc, path asc") }).
+		Preload("Screenshots", preloadScreenshots).
+		Where("slug = ?", slug).First(&component).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		utils.Error(c, http.StatusNotFound, "Component Not Found")
@@ -190,27 +269,31 @@ func GetComponentBySlug(c *gin.Context) {
 		return
 	}
 
+	if version := c.Query("version"); version != "" {
+		release, ok := findRelease(c, &component, version)
+		if !ok {
+			return
+		}
+		applyRelease(&component, release)
+	}
+
+	if err := fillRelatedSlugs(database.DB.WithContext(c.Request.Context()), &component); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch component")
+		return
+	}
+
+	attachScreenshots(&component)
+	setDeprecationHeaders(c, &component)
 	utils.Success(c, component)
 }
 
-// UpdateComponentBySlug godoc
-// @Summary Update komponen by slug
-// @Description Update name/description komponen berdasarkan slug
-// @Tags Component
-// @Accept json
-// @Produce json
-// @Param slug path string true "Slug komponen"
-// @Param data body UpdateComponentRequest true "Data update komponen"
-// @Success 200 {object} model.Component
-// @Failure 400 {object} utils.ErrorResponse
-// @Failure 404 {object} utils.ErrorResponse
-// @Failure 500 {object} utils.ErrorResponse
-// @Router /components/{slug} [patch]
+// UpdateComponentBySlug mengubah nama dan/atau deskripsi komponen. Mengganti
+// nama juga mengganti slug. Hanya untuk editor komponen.
 func UpdateComponentBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	var component model.Component
 
-	err := database.DB.Where("slug = ?", slug).First(&component).Error
+	err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Where("slug = ?", slug).First(&component).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		utils.Error(c, http.StatusNotFound, "Component Not Found")
 		return
@@ -219,6 +302,9 @@ func UpdateComponentBySlug(c *gin.Context) {
 		utils.Error(c, http.StatusInternalServerError, "Failed to query component")
 		return
 	}
+	if !requireEditor(c, &component) {
+		return
+	}
 
 	var input UpdateComponentRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -226,6 +312,7 @@ func UpdateComponentBySlug(c *gin.Context) {
 		return
 	}
 
+	before := component
 	if input.Name != nil {
 		component.Name = *input.Name
 		component.Slug = strings.ToLower(strings.ReplaceAll(*input.Name, " ", "-"))
@@ -234,7 +321,13 @@ func UpdateComponentBySlug(c *gin.Context) {
 		component.Description = *input.Description
 	}
 
-	if err := database.DB.Save(&component).Error; err != nil {
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&component).Error; err != nil {
+			return err
+		}
+		return recordComponentChange(c, tx, "update", &before, &component)
+	})
+	if err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Failed to update component")
 		return
 	}
@@ -242,48 +335,42 @@ func UpdateComponentBySlug(c *gin.Context) {
 	utils.Success(c, component)
 }
 
-// DeleteComponentBySlug godoc
-// @Summary Delete komponen by slug
-// @Description Hapus komponen berdasarkan slug
-// @Tags Component
-// @Accept json
-// @Produce json
-// @Param slug path string true "Slug komponen"
-// @Success 204 {string} string "No Content"
-// @Failure 404 {object} utils.ErrorResponse
-// @Failure 500 {object} utils.ErrorResponse
-// @Router /components/{slug} [delete]
+// DeleteComponentBySlug menghapus komponen (soft delete) dan mencatat
+// perubahannya. Hanya untuk editor komponen.
 func DeleteComponentBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 
-	result := database.DB.Where("slug = ?", slug).Delete(&model.Component{})
-
-	if result.Error != nil {
+	var component model.Component
+	err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Where("slug = ?", slug).First(&component).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Component Not Found")
+		return
+	}
+	if err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Failed to delete component")
 		return
 	}
+	if !requireEditor(c, &component) {
+		return
+	}
 
-	if result.RowsAffected == 0 {
-		utils.Error(c, http.StatusNotFound, "Component Not Found")
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&component).Error; err != nil {
+			return err
+		}
+		return recordComponentChange(c, tx, "delete", &component, nil)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to delete component")
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// AddComponentTag godoc
-// @Summary Tambahkan tag ke komponen
-// @Description Endpoint untuk menambah tag pada komponen
-// @Tags Component
-// @Accept json
-// @Produce json
-// @Param slug path string true "Slug komponen"
-// @Param data body AddComponentTagRequest true "Data tag"
-// @Success 200 {object} model.Component
-// @Failure 400 {object} utils.ErrorResponse
-// @Failure 404 {object} utils.ErrorResponse
-// @Failure 500 {object} utils.ErrorResponse
-// @Router /components/{slug}/tags [post]
+// AddComponentTag menempelkan tag yang sudah ada ke komponen lalu
+// mengembalikan komponen dengan daftar tag terbaru. Hanya untuk editor
+// komponen.
 func AddComponentTag(c *gin.Context) {
 	componentSlug := c.Param("slug")
 
@@ -294,7 +381,7 @@ func AddComponentTag(c *gin.Context) {
 	}
 
 	var component model.Component
-	err := database.DB.Where("slug = ?", componentSlug).First(&component).Error
+	err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Where("slug = ?", componentSlug).First(&component).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		utils.Error(c, http.StatusNotFound, "Component Not Found")
 		return
@@ -303,9 +390,12 @@ func AddComponentTag(c *gin.Context) {
 		utils.Error(c, http.StatusInternalServerError, "Failed to find component")
 		return
 	}
+	if !requireEditor(c, &component) {
+		return
+	}
 
 	var tag model.Tag
-	err = database.DB.First(&tag, input.TagID).Error
+	err = database.DB.WithContext(c.Request.Context()).First(&tag, input.TagID).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		utils.Error(c, http.StatusNotFound, "Tag Not Found")
 		return
@@ -315,30 +405,38 @@ func AddComponentTag(c *gin.Context) {
 		return
 	}
 
-	if err := database.DB.Model(&component).Association("Tags").Append(&tag); err != nil {
+	before := component
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&component).Association("Tags").Find(&before.Tags); err != nil {
+			return err
+		}
+		if err := tx.Model(&component).Association("Tags").Append(&tag); err != nil {
+			return err
+		}
+		if err := tx.Model(&component).Association("Tags").Find(&component.Tags); err != nil {
+			return err
+		}
+		return recordComponentChange(c, tx, "update", &before, &component)
+	})
+	if err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Failed to add tag to component")
 		return
 	}
 
-	database.DB.Preload("Category").Preload("Tags").First(&component)
+	if err := database.DB.WithContext(c.Request.Context()).Preload("Category").Preload("Tags").First(&component).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to reload component")
+		return
+	}
 
 	utils.Success(c, component)
 }
 
-// UpdateComponentStatus godoc
-// @Summary Update status komponen
-// @Description Update status komponen (draft/published)
-// @Tags Component
-// @Accept json
-// @Produce json
-// @Param slug path string true "Slug komponen"
-// @Param data body handler.UpdateComponentStatusRequest true "Data status"
-// @Success 200 {object} model.Component
-// @Failure 400 {object} utils.ErrorResponse
-// @Failure 404 {object} utils.ErrorResponse
-// @Failure 500 {object} utils.ErrorResponse
-// @Router /components/{slug}/status [patch]
+// UpdateComponentStatus mengubah status komponen (draft/published). Hanya
+// untuk reviewer dan admin.
 func UpdateComponentStatus(c *gin.Context) {
+	if !requireReviewer(c) {
+		return
+	}
 	slug := c.Param("slug")
 	var req struct {
 		Status string `json:"status"`
@@ -348,45 +446,60 @@ func UpdateComponentStatus(c *gin.Context) {
 		return
 	}
 	var component model.Component
-	if err := database.DB.Where("slug = ?", slug).First(&component).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Where("slug = ?", slug).First(&component).Error; err != nil {
 		utils.Error(c, http.StatusNotFound, "Komponen tidak ditemukan")
 		return
 	}
+	before := component
 	component.Status = req.Status
-	database.DB.Save(&component)
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&component).Error; err != nil {
+			return err
+		}
+		return recordComponentChange(c, tx, "update", &before, &component)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to update component status")
+		return
+	}
+
 	utils.Success(c, component)
 }
 
-// UpdateComponentApproval godoc
-// @Summary Update approval komponen
-// @Description Update approval status komponen
-// @Tags Component
-// @Accept json
-// @Produce json
-// @Param slug path string true "Slug komponen"
-// @Param data body handler.UpdateComponentApprovalRequest true "Data approval"
-// @Success 200 {object} model.Component
-// @Failure 400 {object} utils.ErrorResponse
-// @Failure 404 {object} utils.ErrorResponse
-// @Failure 500 {object} utils.ErrorResponse
-// @Router /components/{slug}/approval [patch]
+// UpdateComponentApproval mengubah approval status komponen dan mencatat
+// pemanggil sebagai reviewer. Hanya untuk reviewer dan admin.
 func UpdateComponentApproval(c *gin.Context) {
+	if !requireReviewer(c) {
+		return
+	}
 	slug := c.Param("slug")
-	var req struct {
-		ApprovalStatus string    `json:"approval_status"`
-		ReviewerID     uuid.UUID `json:"reviewer_id"`
+	reviewerID, ok := requireCaller(c)
+	if !ok {
+		return
 	}
+	var req UpdateComponentApprovalRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 	var component model.Component
-	if err := database.DB.Where("slug = ?", slug).First(&component).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Where("slug = ?", slug).First(&component).Error; err != nil {
 		utils.Error(c, http.StatusNotFound, "Komponen tidak ditemukan")
 		return
 	}
+	before := component
 	component.ApprovalStatus = req.ApprovalStatus
-	component.ReviewerID = req.ReviewerID
-	database.DB.Save(&component)
+	component.ReviewerID = reviewerID
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&component).Error; err != nil {
+			return err
+		}
+		return recordComponentChange(c, tx, "update", &before, &component)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to update component approval")
+		return
+	}
+
 	utils.Success(c, component)
 }
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUpdateComponentApprovalRecordsCaller(t *testing.T) {
+	openTestDB(t)
+	author, token := newUser(t, "author@example.com", model.UserRoleUser)
+	reviewer, reviewerToken := newUser(t, "reviewer@example.com", model.UserRoleReviewer)
+	newComponent(t, "button", author.ID, nil, model.VisibilityPublic)
+
+	// reviewer_id di body diabaikan; yang dicatat selalu pemanggil.
+	body := map[string]string{"approval_status": model.ApprovalApproved, "reviewer_id": uuid.NewString()}
+	route := "/components/:slug/approval"
+	if w := serve(t, token, http.MethodPatch, route, "/components/button/approval", body, UpdateComponentApproval); w.Code != http.StatusForbidden {
+		t.Errorf("author: status %d, want 403", w.Code)
+	}
+	if w := serve(t, reviewerToken, http.MethodPatch, route, "/components/button/approval", body, UpdateComponentApproval); w.Code != http.StatusOK {
+		t.Fatalf("reviewer: status %d: %s", w.Code, w.Body)
+	}
+
+	var component model.Component
+	if err := database.DB.First(&component, "slug = ?", "button").Error; err != nil {
+		t.Fatal(err)
+	}
+	if component.ReviewerID != reviewer.ID || component.ApprovalStatus != model.ApprovalApproved {
+		t.Errorf("reviewer %s status %q, want %s approved", component.ReviewerID, component.ApprovalStatus, reviewer.ID)
+	}
+}
+
+func TestAddComponentTagRecordsChange(t *testing.T) {
+	openTestDB(t)
+	author, token := newUser(t, "author@example.com", model.UserRoleUser)
+	component := newComponent(t, "button", author.ID, nil, model.VisibilityPublic)
+	form := model.Tag{Slug: "form", Name: "Form"}
+	input := model.Tag{Slug: "input", Name: "Input"}
+	for _, tag := range []*model.Tag{&form, &input} {
+		if err := database.DB.Create(tag).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	add := func(tag model.Tag) int {
+		return serve(t, token, http.MethodPost, "/components/:slug/tags", "/components/button/tags",
+			map[string]string{"tag_id": tag.ID.String()}, AddComponentTag).Code
+	}
+	if got := add(input); got != http.StatusOK {
+		t.Fatalf("add input: status %d", got)
+	}
+	if got := add(form); got != http.StatusOK {
+		t.Fatalf("add form: status %d", got)
+	}
+
+	var audit model.AuditEvent
+	if err := database.DB.Where("entity_id = ?", component.ID).Order("created_at desc, id desc").Last(&audit).Error; err != nil {
+		t.Fatal(err)
+	}
+	var before, after map[string]interface{}
+	json.Unmarshal(audit.Before, &before)
+	json.Unmarshal(audit.After, &after)
+	if !reflect.DeepEqual(before["tags"], []interface{}{"input"}) || !reflect.DeepEqual(after["tags"], []interface{}{"form", "input"}) {
+		t.Errorf("audit tags %v -> %v, want [input] -> [form input]", before["tags"], after["tags"])
+	}
+
+	var outbox []model.OutboxEvent
+	database.DB.Where("aggregate_id = ?", component.ID).Find(&outbox)
+	if len(outbox) != 2 || outbox[1].Type != model.EventComponentUpdated {
+		t.Errorf("%d outbox events, want two %s", len(outbox), model.EventComponentUpdated)
+	}
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DeprecateComponentRequest struct {
+	Message    string `json:"message" binding:"required"`
+	SunsetAt   string `json:"sunset_at"`
+	ReplacedBy string `json:"replaced_by"`
+}
+
+// parseSunset menerima tanggal (2006-01-02) atau timestamp RFC3339.
+func parseSunset(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("sunset_at must be a date (2006-01-02) or RFC3339 timestamp")
+}
+
+// fillRelatedSlugs mengisi slug komponen pengganti (replaced_by) dan komponen
+// asal fork (forked_from).
+func fillRelatedSlugs(db *gorm.DB, components ...*model.Component) error {
+	ids := make([]uuid.UUID, 0, len(components))
+	for _, component := range components {
+		if component.ReplacedByID != nil {
+			ids = append(ids, *component.ReplacedByID)
+		}
+		if component.ForkedFromID != nil {
+			ids = append(ids, *component.ForkedFromID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var related []model.Component
+	if err := db.Unscoped().Select("id", "slug").Where("id IN ?", ids).Find(&related).Error; err != nil {
+		return err
+	}
+	slugs := make(map[uuid.UUID]string, len(related))
+	for _, component := range related {
+		slugs[component.ID] = component.Slug
+	}
+	for _, component := range components {
+		if component.ReplacedByID != nil {
+			component.ReplacedBy = slugs[*component.ReplacedByID]
+		}
+		if component.ForkedFromID != nil {
+			component.ForkedFrom = slugs[*component.ForkedFromID]
+		}
+	}
+	return nil
+}
+
+// setDeprecationHeaders menambahkan header Deprecation, Sunset dan Link
+// successor-version untuk komponen yang deprecated.
+func setDeprecationHeaders(c *gin.Context, component *model.Component) {
+	if component.DeprecatedAt == nil {
+		return
+	}
+
+	c.Header("Deprecation", "@"+strconv.FormatInt(component.DeprecatedAt.Unix(), 10))
+	if component.SunsetAt != nil {
+		c.Header("Sunset", component.SunsetAt.UTC().Format(http.TimeFormat))
+	}
+	if component.ReplacedBy != "" {
+		c.Header("Link", `</api/v1/components/`+component.ReplacedBy+`>; rel="successor-version"`)
+	}
+}
+
+// deprecationWarning menyusun pesan peringatan untuk consumer registry.
+func deprecationWarning(component *model.Component) string {
+	if component.DeprecatedAt == nil {
+		return ""
+	}
+
+	parts := []string{"Deprecated: " + component.DeprecationMessage}
+	if component.ReplacedBy != "" {
+		parts = append(parts, "Use "+component.ReplacedBy+" instead.")
+	}
+	if component.SunsetAt != nil {
+		parts = append(parts, "It will be removed after "+component.SunsetAt.Format(time.DateOnly)+".")
+	}
+	return strings.Join(parts, " ")
+}
+
+// DeprecateComponent menandai komponen sebagai deprecated dengan pesan,
+// tanggal sunset dan komponen pengganti (opsional). Komponen deprecated tidak
+// muncul di listing default.
+func DeprecateComponent(c *gin.Context) {
+	var input DeprecateComponentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	sunset, err := parseSunset(input.SunsetAt)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var replacedByID *uuid.UUID
+	if input.ReplacedBy != "" {
+		if input.ReplacedBy == component.Slug {
+			utils.Error(c, http.StatusBadRequest, "A component cannot replace itself")
+			return
+		}
+		var replacement model.Component
+		err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Select("id").Where("slug = ?", input.ReplacedBy).First(&replacement).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Error(c, http.StatusBadRequest, "replaced_by component not found")
+			return
+		}
+		if err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to find replacement component")
+			return
+		}
+		replacedByID = &replacement.ID
+	}
+
+	before := *component
+	now := time.Now().UTC()
+	if component.DeprecatedAt != nil {
+		now = *component.DeprecatedAt
+	}
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(component).Updates(map[string]interface{}{
+			"deprecated_at":       now,
+			"deprecation_message": strings.TrimSpace(input.Message),
+			"sunset_at":           sunset,
+			"replaced_by_id":      replacedByID,
+		}).Error
+		if err != nil {
+			return err
+		}
+		component.DeprecatedAt = &now
+		component.DeprecationMessage = strings.TrimSpace(input.Message)
+		component.SunsetAt = sunset
+		component.ReplacedByID = replacedByID
+		component.ReplacedBy = input.ReplacedBy
+		return recordComponentChange(c, tx, "update", &before, component)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to deprecate component")
+		return
+	}
+
+	setDeprecationHeaders(c, component)
+	utils.Success(c, component)
+}
+
+// UndeprecateComponent menghapus status deprecated, tanggal sunset dan
+// komponen pengganti dari komponen.
+func UndeprecateComponent(c *gin.Context) {
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	before := *component
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(component).Updates(map[string]interface{}{
+			"deprecated_at":       nil,
+			"deprecation_message": "",
+			"sunset_at":           nil,
+			"replaced_by_id":      nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		component.DeprecatedAt = nil
+		component.DeprecationMessage = ""
+		component.SunsetAt = nil
+		component.ReplacedByID = nil
+		return recordComponentChange(c, tx, "update", &before, component)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to undeprecate component")
+		return
+	}
+
+	utils.Success(c, component)
+}
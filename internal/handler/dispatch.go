@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"service_components/internal/logging"
+	"service_components/internal/middleware"
+)
+
+// DispatchResult adalah response request REST yang dijalankan oleh Dispatch.
+// Data dan Error diambil dari envelope utils.Success/utils.Error.
+type DispatchResult struct {
+	Status int
+	Data   json.RawMessage
+	Error  string
+}
+
+// Dispatch menjalankan request REST /api/v1 di router secara in-process.
+// GraphQL dan gRPC memakai ini supaya auth, scope API key, rate limit,
+// validasi, audit log dan outbox sama persis dengan REST API. header dan
+// remoteAddr diteruskan dari request asal untuk auth dan rate limit; request
+// ID asal dipakai ulang supaya log-nya bisa ditelusuri bersama. Pemanggil
+// yang request asalnya sudah melewati router menandai ctx dengan
+// middleware.WithInternal.
+func Dispatch(ctx context.Context, router http.Handler, method, path string, header http.Header, remoteAddr string, body []byte) (*DispatchResult, error) {
+	var payload io.Reader = http.NoBody
+	if body != nil {
+		payload = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, "/api/v1"+path, payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header.Clone()
+	req.Header.Del("Content-Length")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if id := logging.RequestID(ctx); id != "" {
+		req.Header.Set(middleware.RequestIDHeader, id)
+	}
+	req.RemoteAddr = remoteAddr
+
+	w := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+	router.ServeHTTP(w, req)
+
+	result := &DispatchResult{Status: w.status}
+	var envelope struct {
+		Data  json.RawMessage `json:"data"`
+		Error string          `json:"error"`
+	}
+	if w.body.Len() > 0 && json.Unmarshal(w.body.Bytes(), &envelope) == nil {
+		result.Data, result.Error = envelope.Data, envelope.Error
+	}
+	if result.Status >= http.StatusBadRequest && result.Error == "" {
+		result.Error = http.StatusText(result.Status)
+	}
+	return result, nil
+}
+
+// bufferedResponse menampung response request yang dijalankan Dispatch.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponse) Header() http.Header         { return w.header }
+func (w *bufferedResponse) Write(p []byte) (int, error) { return w.body.Write(p) }
+func (w *bufferedResponse) WriteHeader(status int)      { w.status = status }
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/events"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// streamBuffer adalah jumlah event yang boleh tertahan per koneksi SSE
+// sebelum koneksi itu diputus.
+const streamBuffer = 64
+
+// componentEvents menentukan domain event dari perubahan komponen. Update
+// yang tidak mengubah status, approval atau deprecation menjadi
+// component.updated.
+func componentEvents(before, after *model.Component) []string {
+	switch {
+	case before == nil:
+		return []string{model.EventComponentCreated}
+	case after == nil:
+		return []string{model.EventComponentDeleted}
+	}
+
+	var events []string
+	if after.Status != before.Status && after.Status == model.StatusPublished {
+		events = append(events, model.EventComponentPublished)
+	}
+	if after.ApprovalStatus != before.ApprovalStatus {
+		switch after.ApprovalStatus {
+		case model.ApprovalApproved:
+			events = append(events, model.EventComponentApproved)
+		case model.ApprovalRejected:
+			events = append(events, model.EventComponentRejected)
+		}
+	}
+	if after.DeprecatedAt != nil && before.DeprecatedAt == nil {
+		events = append(events, model.EventComponentDeprecated)
+	}
+	if len(events) == 0 {
+		events = append(events, model.EventComponentUpdated)
+	}
+	return events
+}
+
+// recordComponentChange mencatat perubahan komponen ke audit log dan menulis
+// domain event-nya ke outbox. Panggil dengan transaksi perubahan supaya event
+// tidak hilang jika proses mati setelah commit. before nil berarti create,
+// after nil berarti delete.
+func recordComponentChange(c *gin.Context, db *gorm.DB, action string, before, after *model.Component) error {
+	component := after
+	if component == nil {
+		component = before
+	}
+	if err := recordAudit(c, db, action, model.AuditEntityComponent, component.ID, component.Slug, before, after); err != nil {
+		return err
+	}
+
+	data, err := auditSnapshot(component)
+	if err != nil {
+		return err
+	}
+	for _, event := range componentEvents(before, after) {
+		if err := events.Record(db, event, model.AuditEntityComponent, component.ID, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventComponent adalah field snapshot komponen di data event yang dipakai
+// untuk filter dan visibility.
+type eventComponent struct {
+	CategoryID  uuid.UUID  `json:"category_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	WorkspaceID *uuid.UUID `json:"workspace_id"`
+	Visibility  string     `json:"visibility"`
+}
+
+// canSee menerapkan aturan visibleTo ke snapshot komponen.
+func (s *callerScope) canSee(component *eventComponent) bool {
+	if component.WorkspaceID == nil || component.Visibility == model.VisibilityPublic {
+		return true
+	}
+	if !s.Authenticated {
+		return false
+	}
+	workspaceID := *component.WorkspaceID
+	switch component.Visibility {
+	case model.VisibilityInternal:
+		return slices.Contains(s.Member, workspaceID)
+	case model.VisibilityPrivate:
+		own := component.UserID == s.UserID
+		if s.workspaceKey {
+			own = own && slices.Contains(s.Member, workspaceID)
+		}
+		return own || slices.Contains(s.Admin, workspaceID)
+	}
+	return false
+}
+
+// StreamEvents mengirim perubahan komponen (create, update, status, approval,
+// deprecation dan delete) sebagai Server-Sent Events. Nama event SSE adalah
+// tipe event (mis. component.published), id-nya sequence outbox, dan data-nya
+// event JSON dengan snapshot komponen. Hanya komponen yang boleh dilihat
+// pemanggil yang dikirim; membership workspace dibaca saat tersambung lalu
+// diperbarui setiap heartbeat. Kirim header Last-Event-ID (atau query
+// last_event_id) untuk melanjutkan dari buffer event terakhir; jika event itu
+// sudah tidak ada di buffer, server mengirim event "reset" dan klien sebaiknya
+// memuat ulang datanya. Event "ping" dikirim berkala sebagai heartbeat.
+func StreamEvents(c *gin.Context) {
+	if events.Memory == nil {
+		utils.Error(c, http.StatusServiceUnavailable, "Event stream is disabled; add memory to EVENT_PUBLISHERS")
+		return
+	}
+
+	var types []string
+	if value := c.Query("type"); value != "" {
+		for _, eventType := range strings.Split(value, ",") {
+			eventType = strings.TrimSpace(eventType)
+			if !slices.Contains(model.WebhookEvents, eventType) {
+				utils.Error(c, http.StatusBadRequest, "Unknown event type: "+eventType)
+				return
+			}
+			types = append(types, eventType)
+		}
+	}
+
+	var categories []uuid.UUID
+	if value := c.Query("category"); value != "" {
+		var slugs []string
+		for _, slug := range strings.Split(value, ",") {
+			if slug = strings.TrimSpace(slug); slug != "" {
+				slugs = append(slugs, slug)
+			}
+		}
+		slices.Sort(slugs)
+		slugs = slices.Compact(slugs)
+		var found []model.Category
+		if err := database.DB.WithContext(c.Request.Context()).Where("slug IN ?", slugs).Find(&found).Error; err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to find category")
+			return
+		}
+		if len(found) != len(slugs) {
+			utils.Error(c, http.StatusBadRequest, "Unknown category")
+			return
+		}
+		for _, category := range found {
+			categories = append(categories, category.ID)
+		}
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var lastSequence int64
+	if lastEventID != "" {
+		var err error
+		if lastSequence, err = strconv.ParseInt(lastEventID, 10, 64); err != nil || lastSequence < 0 {
+			utils.Error(c, http.StatusBadRequest, "Last-Event-ID must be an event sequence")
+			return
+		}
+	}
+
+	scope := scopeFor(c)
+	visible := func(event events.Event) bool {
+		if len(types) > 0 && !slices.Contains(types, event.Type) {
+			return false
+		}
+		var component eventComponent
+		if err := json.Unmarshal(event.Data, &component); err != nil {
+			return false
+		}
+		if len(categories) > 0 && !slices.Contains(categories, component.CategoryID) {
+			return false
+		}
+		return scope.canSee(&component)
+	}
+
+	replay, resumed, stream, cancel := events.Memory.Resume(lastSequence, streamBuffer)
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Nonaktifkan buffering reverse proxy (nginx).
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	send := func(event sse.Event) bool {
+		if err := sse.Encode(c.Writer, event); err != nil {
+			return false
+		}
+		c.Writer.Flush()
+		return true
+	}
+
+	if !resumed && !send(sse.Event{Event: "reset", Data: gin.H{"last_event_id": lastSequence}}) {
+		return
+	}
+	for _, event := range replay {
+		if visible(event) && !send(sse.Event{Id: strconv.FormatInt(event.Sequence, 10), Event: event.Type, Data: event}) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(events.StreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-stream:
+			// Channel ditutup karena klien terlalu lambat; klien akan
+			// tersambung ulang dan melanjutkan lewat Last-Event-ID.
+			if !ok {
+				return
+			}
+			if visible(event) && !send(sse.Event{Id: strconv.FormatInt(event.Sequence, 10), Event: event.Type, Data: event}) {
+				return
+			}
+		case now := <-heartbeat.C:
+			// Membership dibaca ulang supaya member yang dikeluarkan dari
+			// workspace berhenti menerima event komponen internal/private.
+			scope = loadScope(c)
+			if !send(sse.Event{Event: "ping", Data: gin.H{"time": now.UTC()}}) {
+				return
+			}
+		}
+	}
+}
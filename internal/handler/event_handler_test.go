@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"service_components/internal/database"
+	"service_components/internal/events"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// useMemoryEvents memasang MemoryPublisher baru sebagai events.Memory dengan
+// heartbeat pendek. Ping dipakai test sebagai tanda replay sudah selesai.
+func useMemoryEvents(t *testing.T) *events.MemoryPublisher {
+	t.Helper()
+	previous, heartbeat := events.Memory, events.StreamHeartbeat
+	events.Memory, events.StreamHeartbeat = events.NewMemoryPublisher(100), 20*time.Millisecond
+	t.Cleanup(func() { events.Memory, events.StreamHeartbeat = previous, heartbeat })
+	return events.Memory
+}
+
+// publishEvent menerbitkan event dengan snapshot component ke memory, dengan
+// data yang sama seperti recordComponentChange.
+func publishEvent(t *testing.T, memory *events.MemoryPublisher, sequence int64, eventType string, component model.Component) {
+	t.Helper()
+	snapshot, err := auditSnapshot(&component)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := events.Event{ID: uuid.New(), Sequence: sequence, Type: eventType, AggregateType: model.AuditEntityComponent, AggregateID: component.ID, Data: data}
+	if err := memory.Publish(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sseStream adalah koneksi ke StreamEvents yang sedang dibaca test.
+type sseStream struct {
+	t       *testing.T
+	scanner *bufio.Scanner
+}
+
+// openStream membuka GET /events/stream?query. Status selain 200
+// dikembalikan tanpa stream.
+func openStream(t *testing.T, token, query string) (*sseStream, int) {
+	t.Helper()
+	engine := gin.New()
+	engine.Use(middleware.Authenticate())
+	engine.GET("/events/stream", StreamEvents)
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events/stream?"+query, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+	return &sseStream{t: t, scanner: bufio.NewScanner(resp.Body)}, resp.StatusCode
+}
+
+// next membaca satu pesan SSE dan mengembalikan nama event dan id-nya.
+func (s *sseStream) next() (event, id string) {
+	s.t.Helper()
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		switch {
+		case line == "" && event != "":
+			return event, id
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimPrefix(line, "event:")
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimPrefix(line, "id:")
+		}
+	}
+	s.t.Fatalf("stream ended: %v", s.scanner.Err())
+	return "", ""
+}
+
+// untilPing mengumpulkan id event sampai ping berikutnya. Event "reset"
+// dicatat sebagai "reset".
+func (s *sseStream) untilPing() []string {
+	s.t.Helper()
+	var ids []string
+	for {
+		event, id := s.next()
+		switch event {
+		case "ping":
+			return ids
+		case "reset":
+			ids = append(ids, "reset")
+		default:
+			ids = append(ids, id)
+		}
+	}
+}
+
+func TestStreamEventsFilters(t *testing.T) {
+	f := newAccessFixture(t)
+	memory := useMemoryEvents(t)
+	forms := model.Category{Slug: "forms", Name: "Forms"}
+	buttons := model.Category{Slug: "buttons", Name: "Buttons"}
+	database.DB.Create(&forms)
+	database.DB.Create(&buttons)
+
+	author := f.users["author"].ID
+	snapshot := func(category model.Category, workspace *model.Workspace, visibility string) model.Component {
+		component := model.Component{ID: uuid.New(), CategoryID: category.ID, UserID: author, Visibility: visibility}
+		if workspace != nil {
+			component.WorkspaceID = &workspace.ID
+		}
+		return component
+	}
+	// Event 1 hanya titik awal replay (last_event_id=1).
+	publishEvent(t, memory, 1, model.EventComponentCreated, snapshot(forms, nil, model.VisibilityPublic))
+	publishEvent(t, memory, 2, model.EventComponentCreated, snapshot(forms, nil, model.VisibilityPublic))
+	publishEvent(t, memory, 3, model.EventComponentPublished, snapshot(buttons, &f.acme, model.VisibilityPublic))
+	publishEvent(t, memory, 4, model.EventComponentUpdated, snapshot(forms, &f.acme, model.VisibilityInternal))
+	publishEvent(t, memory, 5, model.EventComponentCreated, snapshot(forms, &f.acme, model.VisibilityPrivate))
+	publishEvent(t, memory, 6, model.EventComponentDeleted, snapshot(buttons, &f.beta, model.VisibilityInternal))
+
+	tests := []struct {
+		name   string
+		caller string
+		query  string
+		want   []string
+	}{
+		{"anonymous sees public components", "anonymous", "", []string{"2", "3"}},
+		{"members see internal components", "member", "", []string{"2", "3", "4"}},
+		{"admins see private components", "admin", "", []string{"2", "3", "4", "5"}},
+		{"authors see their own components", "author", "", []string{"2", "3", "4", "5", "6"}},
+		{"workspace keys see their workspace", "workspace key", "", []string{"2", "3", "6"}},
+		{"type filter", "author", "type=component.created", []string{"2", "5"}},
+		{"several types", "author", "type=component.created,component.deleted", []string{"2", "5", "6"}},
+		{"category filter", "author", "category=forms", []string{"2", "4", "5"}},
+		{"repeated category", "author", "category=forms,%20forms", []string{"2", "4", "5"}},
+		{"type and category", "author", "category=forms,buttons&type=component.created,component.deleted", []string{"2", "5", "6"}},
+		{"unknown id resets", "author", "", []string{"reset"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := "last_event_id=1&" + tt.query
+			if tt.want[0] == "reset" {
+				query = "last_event_id=999"
+			}
+			stream, status := openStream(t, f.tokens[tt.caller], query)
+			if status != http.StatusOK {
+				t.Fatalf("status %d", status)
+			}
+			if got := stream.untilPing(); !slices.Equal(got, tt.want) {
+				t.Errorf("events %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for query, want := range map[string]int{
+		"type=component.renamed":  http.StatusBadRequest,
+		"category=forms,unknown":  http.StatusBadRequest,
+		"last_event_id=abc":       http.StatusBadRequest,
+		"last_event_id=-1":        http.StatusBadRequest,
+		"category=buttons,forms,": http.StatusOK,
+	} {
+		if _, status := openStream(t, "", query); status != want {
+			t.Errorf("%s: status %d, want %d", query, status, want)
+		}
+	}
+}
+
+// Member yang dikeluarkan dari workspace berhenti menerima event internal
+// tanpa perlu tersambung ulang.
+func TestStreamEventsRechecksMembership(t *testing.T) {
+	f := newAccessFixture(t)
+	memory := useMemoryEvents(t)
+	internal := model.Component{ID: uuid.New(), UserID: f.users["author"].ID, WorkspaceID: &f.acme.ID, Visibility: model.VisibilityInternal}
+	public := model.Component{ID: uuid.New(), UserID: f.users["author"].ID, Visibility: model.VisibilityPublic}
+
+	stream, status := openStream(t, f.tokens["member"], "")
+	if status != http.StatusOK {
+		t.Fatalf("status %d", status)
+	}
+	stream.untilPing()
+	publishEvent(t, memory, 1, model.EventComponentUpdated, internal)
+	if got := stream.untilPing(); !slices.Equal(got, []string{"1"}) {
+		t.Fatalf("member: events %v, want the internal event", got)
+	}
+
+	database.DB.Where("workspace_id = ? AND user_id = ?", f.acme.ID, f.users["member"].ID).Delete(&model.WorkspaceMember{})
+	// Ping pertama bisa sudah dijadwalkan sebelum member dihapus; ping kedua
+	// pasti membaca membership yang baru.
+	stream.untilPing()
+	stream.untilPing()
+	publishEvent(t, memory, 2, model.EventComponentUpdated, internal)
+	publishEvent(t, memory, 3, model.EventComponentUpdated, public)
+	if got := stream.untilPing(); !slices.Equal(got, []string{"3"}) {
+		t.Errorf("removed member: events %v, want only the public event", got)
+	}
+}
@@ -0,0 +1,573 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ComponentFileRequest struct {
+	Framework string `json:"framework"`
+	Path      string `json:"path" binding:"required"`
+	Language  string `json:"language"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+}
+
+type UpdateComponentFileRequest struct {
+	Path    *string `json:"path"`
+	Content *string `json:"content"`
+}
+
+var frameworkExtensions = map[string]string{
+	model.FrameworkReact:         ".jsx",
+	model.FrameworkVue:           ".vue",
+	model.FrameworkSvelte:        ".svelte",
+	model.FrameworkHTML:          ".html",
+	model.FrameworkWebComponents: ".js",
+}
+
+var fileLanguages = map[string]string{
+	".jsx":    "jsx",
+	".tsx":    "tsx",
+	".js":     "javascript",
+	".mjs":    "javascript",
+	".ts":     "typescript",
+	".vue":    "vue",
+	".svelte": "svelte",
+	".html":   "html",
+	".css":    "css",
+	".scss":   "scss",
+	".json":   "json",
+	".md":     "markdown",
+	".mdx":    "mdx",
+	".svg":    "svg",
+}
+
+var errFileConflict = errors.New("file conflict")
+
+// cleanFilePath menormalkan path relatif dan menolak path absolut atau yang
+// keluar dari direktori komponen.
+func cleanFilePath(p string) (string, error) {
+	p = path.Clean(strings.ReplaceAll(strings.TrimSpace(p), "\\", "/"))
+	if p == "." || p == "" || strings.HasPrefix(p, "/") || p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("invalid file path %q", p)
+	}
+	return p, nil
+}
+
+func detectLanguage(p string) string {
+	if lang, ok := fileLanguages[strings.ToLower(path.Ext(p))]; ok {
+		return lang
+	}
+	return "text"
+}
+
+func detectRole(p string) string {
+	base := strings.ToLower(path.Base(p))
+	switch {
+	case strings.Contains(base, ".stories."):
+		return model.FileRoleStory
+	case strings.Contains(base, ".test.") || strings.Contains(base, ".spec."):
+		return model.FileRoleTest
+	case strings.HasSuffix(base, ".css") || strings.HasSuffix(base, ".scss"):
+		return model.FileRoleStyle
+	case strings.HasPrefix(base, "use"):
+		return model.FileRoleHook
+	}
+	return model.FileRoleUtil
+}
+
+// newComponentFile memvalidasi request dan mengisi default framework, bahasa
+// dan role berdasarkan path.
+func newComponentFile(req ComponentFileRequest) (model.ComponentFile, error) {
+	cleaned, err := cleanFilePath(req.Path)
+	if err != nil {
+		return model.ComponentFile{}, err
+	}
+
+	file := model.ComponentFile{
+		Framework: req.Framework,
+		Path:      cleaned,
+		Language:  req.Language,
+		Role:      req.Role,
+		Content:   req.Content,
+	}
+	if file.Framework == "" {
+		file.Framework = model.FrameworkReact
+	}
+	if !slices.Contains(model.Frameworks, file.Framework) {
+		return file, fmt.Errorf("framework must be one of %s", strings.Join(model.Frameworks, ", "))
+	}
+	if file.Language == "" {
+		file.Language = detectLanguage(cleaned)
+	}
+	if file.Role == "" {
+		file.Role = detectRole(cleaned)
+	}
+	if !slices.Contains(model.FileRoles, file.Role) {
+		return file, fmt.Errorf("role must be one of %s", strings.Join(model.FileRoles, ", "))
+	}
+	if file.Role == model.FileRoleComponent {
+		if err := validateVariant(file.Framework, file.Content); err != nil {
+			return file, err
+		}
+	}
+	return file, nil
+}
+
+// filesFromVariants membuat file entry (role component) dan style untuk
+// setiap varian, dipakai untuk komponen baru dan komponen lama yang belum
+// punya file.
+func filesFromVariants(slug string, variants []model.ComponentVariant) []model.ComponentFile {
+	files := make([]model.ComponentFile, 0, len(variants)*2)
+	for _, variant := range variants {
+		entry := slug + frameworkExtensions[variant.Framework]
+		files = append(files, model.ComponentFile{
+			Framework: variant.Framework,
+			Path:      entry,
+			Language:  detectLanguage(entry),
+			Role:      model.FileRoleComponent,
+			Content:   variant.Code,
+		})
+		if variant.Styles != "" {
+			files = append(files, model.ComponentFile{
+				Framework: variant.Framework,
+				Path:      slug + ".css",
+				Language:  "css",
+				Role:      model.FileRoleStyle,
+				Content:   variant.Styles,
+			})
+		}
+	}
+	return files
+}
+
+// buildFiles membentuk file awal komponen baru dari variannya ditambah file
+// tambahan dari request.
+func buildFiles(slug string, variants []model.ComponentVariant, extra []ComponentFileRequest) ([]model.ComponentFile, error) {
+	files := filesFromVariants(slug, variants)
+	for _, req := range extra {
+		file, err := newComponentFile(req)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, checkFileSet(files)
+}
+
+// checkFileSet memastikan path unik dan hanya ada satu file entry per framework.
+func checkFileSet(files []model.ComponentFile) error {
+	paths := map[string]bool{}
+	entries := map[string]bool{}
+	for _, file := range files {
+		key := file.Framework + "/" + file.Path
+		if paths[key] {
+			return fmt.Errorf("duplicate file %s", key)
+		}
+		paths[key] = true
+
+		if file.Role == model.FileRoleComponent {
+			if entries[file.Framework] {
+				return fmt.Errorf("%s already has a component entry file", file.Framework)
+			}
+			entries[file.Framework] = true
+		}
+	}
+	if len(entries) == 0 {
+		return errors.New("at least one component entry file is required")
+	}
+	return nil
+}
+
+// ensureFiles mengubah komponen lama yang hanya punya varian atau
+// CodeJSX/CodeCSS menjadi file, sebelum file-nya diubah.
+func ensureFiles(tx *gorm.DB, component *model.Component) error {
+	var count int64
+	if err := tx.Model(&model.ComponentFile{}).Where("component_id = ?", component.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	files, err := legacyFiles(tx, component)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	for i := range files {
+		files[i].ComponentID = component.ID
+	}
+	return tx.Create(&files).Error
+}
+
+// loadComponentFiles mengambil file komponen. Komponen lama yang belum punya
+// file ditampilkan dari varian atau CodeJSX/CodeCSS-nya tanpa disimpan.
+func loadComponentFiles(db *gorm.DB, component *model.Component, framework string) ([]model.ComponentFile, error) {
+	var files []model.ComponentFile
+	err := db.Where("component_id = ?", component.ID).Order("framework asc, path asc").Find(&files).Error
+	if err != nil || len(files) > 0 {
+		return filterFramework(files, framework), err
+	}
+
+	files, err = legacyFiles(db, component)
+	return filterFramework(files, framework), err
+}
+
+// legacyFiles membentuk file dari varian komponen, atau dari CodeJSX/CodeCSS
+// untuk komponen yang dibuat sebelum ada varian.
+func legacyFiles(db *gorm.DB, component *model.Component) ([]model.ComponentFile, error) {
+	var variants []model.ComponentVariant
+	if err := db.Where("component_id = ?", component.ID).Order("framework asc").Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	return variantFiles(component, variants), nil
+}
+
+// variantFiles menurunkan file dari varian komponen lama yang sudah dimuat,
+// atau dari CodeJSX/CodeCSS jika komponen belum punya varian.
+func variantFiles(component *model.Component, variants []model.ComponentVariant) []model.ComponentFile {
+	if len(variants) == 0 && component.CodeJSX != "" {
+		variants = []model.ComponentVariant{{Framework: model.FrameworkReact, Code: component.CodeJSX, Styles: component.CodeCSS}}
+	}
+	return filesFromVariants(component.Slug, variants)
+}
+
+func filterFramework(files []model.ComponentFile, framework string) []model.ComponentFile {
+	if framework == "" {
+		return files
+	}
+	filtered := make([]model.ComponentFile, 0, len(files))
+	for _, file := range files {
+		if file.Framework == framework {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
+
+// syncVariants menurunkan ulang varian dan kolom CodeJSX/CodeCSS dari file
+// komponen: file role component menjadi Code dan file role style pertama
+// menjadi Styles.
+func syncVariants(tx *gorm.DB, componentID uuid.UUID) error {
+	var files []model.ComponentFile
+	if err := tx.Where("component_id = ?", componentID).Order("framework asc, path asc").Find(&files).Error; err != nil {
+		return err
+	}
+
+	derived := deriveVariants(componentID, files)
+
+	var existing []model.ComponentVariant
+	if err := tx.Where("component_id = ?", componentID).Find(&existing).Error; err != nil {
+		return err
+	}
+	for _, variant := range existing {
+		want, ok := derived[variant.Framework]
+		if !ok {
+			if err := tx.Delete(&variant).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		variant.Code = want.Code
+		variant.Styles = want.Styles
+		if err := tx.Save(&variant).Error; err != nil {
+			return err
+		}
+		delete(derived, variant.Framework)
+	}
+	for _, variant := range derived {
+		if err := tx.Create(variant).Error; err != nil {
+			return err
+		}
+	}
+
+	codeJSX, codeCSS := legacyCode(files)
+	return tx.Model(&model.Component{}).Where("id = ?", componentID).
+		Updates(map[string]interface{}{"code_jsx": codeJSX, "code_css": codeCSS}).Error
+}
+
+// deriveVariants membentuk varian per framework dari daftar file yang sudah
+// diurutkan berdasarkan framework dan path.
+func deriveVariants(componentID uuid.UUID, files []model.ComponentFile) map[string]*model.ComponentVariant {
+	derived := map[string]*model.ComponentVariant{}
+	for _, file := range files {
+		if file.Role == model.FileRoleComponent && derived[file.Framework] == nil {
+			derived[file.Framework] = &model.ComponentVariant{ComponentID: componentID, Framework: file.Framework, Code: file.Content}
+		}
+	}
+	for _, file := range files {
+		if variant := derived[file.Framework]; variant != nil && file.Role == model.FileRoleStyle && variant.Styles == "" {
+			variant.Styles = file.Content
+		}
+	}
+	return derived
+}
+
+// legacyCode mengembalikan isi CodeJSX/CodeCSS yang diturunkan dari file react.
+func legacyCode(files []model.ComponentFile) (codeJSX, codeCSS string) {
+	for _, file := range files {
+		if file.Framework != model.FrameworkReact {
+			continue
+		}
+		if file.Role == model.FileRoleComponent && codeJSX == "" {
+			codeJSX = file.Content
+		}
+		if file.Role == model.FileRoleStyle && codeCSS == "" {
+			codeCSS = file.Content
+		}
+	}
+	return codeJSX, codeCSS
+}
+
+// GetComponentFiles mengembalikan file sumber komponen, bisa dibatasi ke satu
+// framework. Query version mengambil file dari rilis tersebut.
+func GetComponentFiles(c *gin.Context) {
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	files, ok := versionFiles(c, component, c.Query("framework"))
+	if !ok {
+		return
+	}
+
+	utils.Success(c, files)
+}
+
+// AddComponentFile menambah file sumber (hook, util, story, test, asset, ...)
+// ke komponen.
+func AddComponentFile(c *gin.Context) {
+	var input ComponentFileRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := newComponentFile(input)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := ensureFiles(tx, component); err != nil {
+			return err
+		}
+
+		var files []model.ComponentFile
+		if err := tx.Where("component_id = ?", component.ID).Find(&files).Error; err != nil {
+			return err
+		}
+		if err := checkFileSet(append(files, file)); err != nil {
+			return fmt.Errorf("%w: %s", errFileConflict, err.Error())
+		}
+
+		file.ComponentID = component.ID
+		if err := tx.Create(&file).Error; err != nil {
+			return err
+		}
+		if err := syncVariants(tx, component.ID); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, "file.create", model.AuditEntityComponent, component.ID, component.Slug, nil, &file)
+	})
+	if errors.Is(err, errFileConflict) {
+		utils.Error(c, http.StatusConflict, strings.TrimPrefix(err.Error(), errFileConflict.Error()+": "))
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to add file")
+		return
+	}
+
+	utils.Created(c, file)
+}
+
+// UpdateComponentFile mengganti path (rename) dan/atau isi file komponen.
+func UpdateComponentFile(c *gin.Context) {
+	var input UpdateComponentFileRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var file model.ComponentFile
+	err := database.DB.WithContext(c.Request.Context()).Where("id = ? AND component_id = ?", c.Param("id"), component.ID).First(&file).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "File Not Found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find file")
+		return
+	}
+
+	before := file
+	if input.Path != nil {
+		cleaned, err := cleanFilePath(*input.Path)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		file.Path = cleaned
+		file.Language = detectLanguage(cleaned)
+	}
+	if input.Content != nil {
+		file.Content = *input.Content
+	}
+	if file.Role == model.FileRoleComponent {
+		if err := validateVariant(file.Framework, file.Content); err != nil {
+			utils.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		var taken int64
+		tx.Model(&model.ComponentFile{}).
+			Where("component_id = ? AND framework = ? AND path = ? AND id <> ?", component.ID, file.Framework, file.Path, file.ID).
+			Count(&taken)
+		if taken > 0 {
+			return errFileConflict
+		}
+
+		if err := tx.Save(&file).Error; err != nil {
+			return err
+		}
+		if err := syncVariants(tx, component.ID); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, "file.update", model.AuditEntityComponent, component.ID, component.Slug, &before, &file)
+	})
+	if errors.Is(err, errFileConflict) {
+		utils.Error(c, http.StatusConflict, "A file with that path already exists")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to update file")
+		return
+	}
+
+	utils.Success(c, file)
+}
+
+// DeleteComponentFile menghapus satu file dari komponen. File entry terakhir
+// tidak dapat dihapus.
+func DeleteComponentFile(c *gin.Context) {
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var file model.ComponentFile
+	err := database.DB.WithContext(c.Request.Context()).Where("id = ? AND component_id = ?", c.Param("id"), component.ID).First(&file).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "File Not Found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find file")
+		return
+	}
+
+	if file.Role == model.FileRoleComponent {
+		var entries int64
+		database.DB.WithContext(c.Request.Context()).Model(&model.ComponentFile{}).Where("component_id = ? AND role = ?", component.ID, model.FileRoleComponent).Count(&entries)
+		if entries <= 1 {
+			utils.Error(c, http.StatusBadRequest, "A component must keep at least one component entry file")
+			return
+		}
+	}
+
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&file).Error; err != nil {
+			return err
+		}
+		if err := syncVariants(tx, component.ID); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, "file.delete", model.AuditEntityComponent, component.ID, component.Slug, &file, nil)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to delete file")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DownloadComponent mengirim semua file komponen (atau satu framework, atau
+// satu rilis lewat query version) dalam satu arsip zip, dikelompokkan per
+// framework.
+func DownloadComponent(c *gin.Context) {
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	framework := c.Query("framework")
+	files, ok := versionFiles(c, component, framework)
+	if !ok {
+		return
+	}
+	if len(files) == 0 {
+		utils.Error(c, http.StatusNotFound, "No files for this framework")
+		return
+	}
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, file := range files {
+		name := component.Slug + "/" + file.Framework + "/" + file.Path
+		if framework != "" {
+			name = component.Slug + "/" + file.Path
+		}
+		w, err := zw.Create(name)
+		if err == nil {
+			_, err = w.Write([]byte(file.Content))
+		}
+		if err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to build archive")
+			return
+		}
+	}
+	if err := zw.Close(); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to build archive")
+		return
+	}
+
+	fileName := component.Slug
+	if version := c.Query("version"); version != "" {
+		fileName += "@" + version
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+fileName+`.zip"`)
+	c.Data(http.StatusOK, "application/zip", archive.Bytes())
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ForkComponentRequest struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Workspace  string `json:"workspace"`
+	Visibility string `json:"visibility"`
+}
+
+// ForkComponent menyalin kode, file, props, tag dan kategori komponen menjadi
+// komponen draft baru milik pemanggil. Isi version untuk fork dari rilis
+// tertentu; tanpa version kode terkini yang disalin.
+func ForkComponent(c *gin.Context) {
+	userID, ok := requireCaller(c)
+	if !ok {
+		return
+	}
+
+	var input ForkComponentRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	source, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+	var tags []*model.Tag
+	if err := database.DB.WithContext(c.Request.Context()).Model(source).Association("Tags").Find(&tags); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch tags")
+		return
+	}
+
+	// Versi sumber dicatat sebagai versi rilis, atau updated_at untuk kode
+	// yang belum dirilis.
+	props := source.PropsDefinition
+	sourceVersion := source.UpdatedAt.UTC().Format(time.RFC3339)
+	var files []model.ComponentFile
+	if input.Version != "" {
+		release, ok := findRelease(c, source, input.Version)
+		if !ok {
+			return
+		}
+		files = releaseFiles(source, release)
+		props = release.PropsDefinition
+		sourceVersion = release.Version
+	} else {
+		var err error
+		files, err = loadComponentFiles(database.DB.WithContext(c.Request.Context()), source, "")
+		if err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to fetch files")
+			return
+		}
+	}
+
+	name := strings.TrimSpace(input.Name)
+	named := name != ""
+	if !named {
+		name = source.Name + " Fork"
+	}
+	slug := strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+
+	// Tanpa workspace dan visibility di body, fork mengikuti workspace dan
+	// visibility sumber selama pemanggil member workspace itu.
+	workspaceID, visibility := source.WorkspaceID, input.Visibility
+	if visibility == "" {
+		visibility = source.Visibility
+	}
+	if input.Workspace != "" || workspaceID == nil || scopeFor(c).role(*workspaceID) == "" {
+		workspaceID, visibility, ok = resolveWorkspace(c, input.Workspace, visibility)
+		if !ok {
+			return
+		}
+	} else if !validVisibility(visibility) {
+		utils.Error(c, http.StatusBadRequest, "visibility must be public, internal or private")
+		return
+	}
+	if !forkVisibleWithin(source, workspaceID, visibility) {
+		utils.Error(c, http.StatusForbidden, "A fork cannot be more visible than its source")
+		return
+	}
+
+	fork := model.Component{
+		Name:              name,
+		Description:       source.Description,
+		CategoryID:        source.CategoryID,
+		PropsDefinition:   props,
+		UserID:            userID,
+		WorkspaceID:       workspaceID,
+		Visibility:        visibility,
+		Status:            model.StatusDraft,
+		ApprovalStatus:    model.ApprovalPending,
+		ForkedFromID:      &source.ID,
+		ForkedFromVersion: sourceVersion,
+	}
+
+	status := http.StatusInternalServerError
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		var taken int64
+		if err := tx.Unscoped().Model(&model.Component{}).Where("slug = ?", slug).Count(&taken).Error; err != nil {
+			return err
+		}
+		switch {
+		case taken == 0:
+			fork.Slug = slug
+		case named:
+			status = http.StatusConflict
+			return gorm.ErrDuplicatedKey
+		default:
+			free, err := freeSlug(tx, &model.Component{}, slug)
+			if err != nil {
+				return err
+			}
+			fork.Slug = free
+		}
+
+		if err := tx.Omit("Tags", "Files").Create(&fork).Error; err != nil {
+			return err
+		}
+		if len(tags) > 0 {
+			if err := tx.Model(&fork).Association("Tags").Append(tags); err != nil {
+				return err
+			}
+		}
+		if len(files) > 0 {
+			copies := make([]model.ComponentFile, 0, len(files))
+			for _, file := range files {
+				copies = append(copies, model.ComponentFile{
+					ComponentID: fork.ID,
+					Framework:   file.Framework,
+					Path:        file.Path,
+					Language:    file.Language,
+					Role:        file.Role,
+					Content:     file.Content,
+				})
+			}
+			if err := tx.Create(&copies).Error; err != nil {
+				return err
+			}
+		}
+		if err := syncVariants(tx, fork.ID); err != nil {
+			return err
+		}
+		return recordComponentChange(c, tx, "create", nil, &fork)
+	})
+	if err != nil {
+		if status == http.StatusConflict {
+			utils.Error(c, status, "A component named "+name+" already exists")
+			return
+		}
+		utils.Error(c, status, "Failed to fork component")
+		return
+	}
+
+	var created model.Component
+	err = database.DB.WithContext(c.Request.Context()).Preload("Category").Preload("Tags").Preload("Variants").
+		Preload("Files", func(db *gorm.DB) *gorm.DB { return db.Order("framework asc, path asc") }).
+		First(&created, "id = ?", fork.ID).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch forked component")
+		return
+	}
+	created.ForkedFrom = source.Slug
+
+	utils.Created(c, created)
+}
+
+// visibilityRank mengurutkan visibility dari yang paling tertutup.
+var visibilityRank = map[string]int{
+	model.VisibilityPrivate:  0,
+	model.VisibilityInternal: 1,
+	model.VisibilityPublic:   2,
+}
+
+// forkVisibleWithin memastikan fork tidak terlihat oleh lebih banyak orang
+// daripada sumbernya: fork dari komponen internal atau private harus tetap di
+// workspace sumber dengan visibility yang sama atau lebih tertutup.
+func forkVisibleWithin(source *model.Component, workspaceID *uuid.UUID, visibility string) bool {
+	if source.WorkspaceID == nil || source.Visibility == model.VisibilityPublic {
+		return true
+	}
+	if workspaceID == nil || *workspaceID != *source.WorkspaceID {
+		return false
+	}
+	return visibilityRank[visibility] <= visibilityRank[source.Visibility]
+}
+
+// GetComponentForks mengembalikan komponen yang di-fork langsung dari komponen
+// ini dan boleh dilihat pemanggil, terbaru dulu.
+func GetComponentForks(c *gin.Context) {
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var forks []model.Component
+	err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Preload("Category").Where("forked_from_id = ?", component.ID).
+		Order("created_at desc").Find(&forks).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch forks")
+		return
+	}
+	for i := range forks {
+		forks[i].ForkedFrom = component.Slug
+	}
+
+	utils.Success(c, forks)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"service_components/internal/model"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestForkWorkspaceAndVisibility(t *testing.T) {
+	f := newAccessFixture(t)
+
+	tests := []struct {
+		name       string
+		caller     string
+		source     string
+		body       map[string]string
+		want       int
+		workspace  *uuid.UUID
+		visibility string
+	}{
+		{"defaults to the source workspace", "member", "int", nil, 201, &f.acme.ID, model.VisibilityInternal},
+		{"narrower visibility", "member", "int", map[string]string{"visibility": "private"}, 201, &f.acme.ID, model.VisibilityPrivate},
+		{"wider visibility", "member", "int", map[string]string{"visibility": "public"}, 403, nil, ""},
+		{"other workspace", "author", "int", map[string]string{"workspace": "beta", "visibility": "internal"}, 403, nil, ""},
+		{"private to internal", "author", "priv", map[string]string{"visibility": "internal"}, 403, nil, ""},
+		{"invalid visibility", "member", "int", map[string]string{"visibility": "secret"}, 400, nil, ""},
+		{"non-member forks a public component", "outsider", "pub", nil, 201, nil, model.VisibilityPublic},
+		{"non-member cannot target the workspace", "outsider", "pub", map[string]string{"workspace": "acme"}, 403, nil, ""},
+		{"public source into another workspace", "author", "pub", map[string]string{"workspace": "beta", "visibility": "private"}, 201, &f.beta.ID, model.VisibilityPrivate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body interface{}
+			if tt.body != nil {
+				body = tt.body
+			}
+			w := serve(t, f.tokens[tt.caller], http.MethodPost, "/components/:slug/fork", "/components/"+tt.source+"/fork", body, ForkComponent)
+			if w.Code != tt.want {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if tt.want != http.StatusCreated {
+				return
+			}
+
+			var response struct {
+				Data model.Component `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			fork := response.Data
+			if fork.Visibility != tt.visibility {
+				t.Errorf("visibility = %q, want %q", fork.Visibility, tt.visibility)
+			}
+			if (fork.WorkspaceID == nil) != (tt.workspace == nil) || (fork.WorkspaceID != nil && *fork.WorkspaceID != *tt.workspace) {
+				t.Errorf("workspace = %v, want %v", fork.WorkspaceID, tt.workspace)
+			}
+		})
+	}
+}
@@ -0,0 +1,946 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"service_components/internal/database"
+	"service_components/internal/graphql"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// GraphQLResponse hanya untuk dokumentasi swagger; response sebenarnya
+// adalah graphql.Result.
+type GraphQLResponse struct {
+	Data   interface{}      `json:"data"`
+	Errors []*graphql.Error `json:"errors,omitempty"`
+}
+
+// graphqlRequest adalah state per request GraphQL: context gin untuk
+// visibility, router untuk mutation, dan Loader relasi.
+type graphqlRequest struct {
+	c      *gin.Context
+	router *gin.Engine
+	// mutations menghitung perubahan yang sudah di-dispatch request ini.
+	mutations int
+
+	categories *graphql.Loader[uuid.UUID, *model.Category]
+	components *graphql.Loader[uuid.UUID, *model.Component]
+	tags       *graphql.Loader[uuid.UUID, []*model.Tag]
+	variants   *graphql.Loader[uuid.UUID, []model.ComponentVariant]
+}
+
+func newGraphQLRequest(c *gin.Context, router *gin.Engine) *graphqlRequest {
+	req := &graphqlRequest{c: c, router: router}
+	req.resetLoaders()
+	return req
+}
+
+// resetLoaders mengosongkan cache Loader. Dipanggil setelah setiap mutation
+// supaya field berikutnya membaca data terbaru.
+func (r *graphqlRequest) resetLoaders() {
+	r.categories = graphql.NewLoader(func(ids []uuid.UUID) (map[uuid.UUID]*model.Category, error) {
+		var categories []*model.Category
+		if err := database.DB.WithContext(r.c.Request.Context()).Unscoped().Where("id IN ?", ids).Find(&categories).Error; err != nil {
+			return nil, errors.New("Failed to fetch categories")
+		}
+		found := map[uuid.UUID]*model.Category{}
+		for _, category := range categories {
+			found[category.ID] = category
+		}
+		return found, nil
+	})
+
+	r.components = graphql.NewLoader(func(ids []uuid.UUID) (map[uuid.UUID]*model.Component, error) {
+		var components []*model.Component
+		if err := database.DB.WithContext(r.c.Request.Context()).Scopes(visibleTo(r.c)).Where("components.id IN ?", ids).Find(&components).Error; err != nil {
+			return nil, errors.New("Failed to fetch components")
+		}
+		found := map[uuid.UUID]*model.Component{}
+		for _, component := range components {
+			found[component.ID] = component
+		}
+		return found, nil
+	})
+
+	r.tags = graphql.NewLoader(func(ids []uuid.UUID) (map[uuid.UUID][]*model.Tag, error) {
+		var rows []struct {
+			ComponentID uuid.UUID
+			model.Tag
+		}
+		err := database.DB.WithContext(r.c.Request.Context()).Model(&model.Tag{}).Select("component_tags.component_id, tags.*").
+			Joins("JOIN component_tags ON component_tags.tag_id = tags.id").
+			Where("component_tags.component_id IN ?", ids).Order("tags.name asc").Scan(&rows).Error
+		if err != nil {
+			return nil, errors.New("Failed to fetch tags")
+		}
+		found := map[uuid.UUID][]*model.Tag{}
+		for i := range rows {
+			found[rows[i].ComponentID] = append(found[rows[i].ComponentID], &rows[i].Tag)
+		}
+		return found, nil
+	})
+
+	r.variants = graphql.NewLoader(func(ids []uuid.UUID) (map[uuid.UUID][]model.ComponentVariant, error) {
+		var variants []model.ComponentVariant
+		if err := database.DB.WithContext(r.c.Request.Context()).Where("component_id IN ?", ids).Order("framework asc").Find(&variants).Error; err != nil {
+			return nil, errors.New("Failed to fetch variants")
+		}
+		found := map[uuid.UUID][]model.ComponentVariant{}
+		for _, variant := range variants {
+			found[variant.ComponentID] = append(found[variant.ComponentID], variant)
+		}
+		return found, nil
+	})
+}
+
+func requestOf(ctx *graphql.Context) *graphqlRequest {
+	return ctx.Data.(*graphqlRequest)
+}
+
+var graphqlErrorCodes = map[int]string{
+	http.StatusBadRequest:      "BAD_REQUEST",
+	http.StatusUnauthorized:    "UNAUTHENTICATED",
+	http.StatusForbidden:       "FORBIDDEN",
+	http.StatusNotFound:        "NOT_FOUND",
+	http.StatusConflict:        "CONFLICT",
+	http.StatusTooManyRequests: "RATE_LIMITED",
+}
+
+// dispatch menjalankan mutation lewat endpoint REST-nya (lihat Dispatch)
+// dengan header request GraphQL. Dispatch ditandai internal sehingga tidak
+// melewati rate limit; request /graphql sudah mengambil satu token write, dan
+// setiap mutation setelah yang pertama mengambil satu token write lagi.
+func (r *graphqlRequest) dispatch(method, path string, body interface{}) (json.RawMessage, error) {
+	r.mutations++
+	if r.mutations > 1 {
+		if retryAfter, ok := middleware.TakeWriteToken(r.c); !ok {
+			message := "Too many requests, retry in " + strconv.Itoa(int(retryAfter.Seconds())) + "s"
+			return nil, &graphql.Error{Message: message, Extensions: map[string]interface{}{"code": graphqlErrorCodes[http.StatusTooManyRequests], "status": http.StatusTooManyRequests}}
+		}
+	}
+	defer r.resetLoaders()
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	result, err := Dispatch(middleware.WithInternal(r.c.Request.Context()), r.router, method, path, r.c.Request.Header, r.c.Request.RemoteAddr, payload)
+	if err != nil {
+		return nil, err
+	}
+	if result.Status >= http.StatusBadRequest {
+		code, ok := graphqlErrorCodes[result.Status]
+		if !ok {
+			code = "INTERNAL_SERVER_ERROR"
+		}
+		return nil, &graphql.Error{Message: result.Error, Extensions: map[string]interface{}{"code": code, "status": result.Status}}
+	}
+	return result.Data, nil
+}
+
+// mutateComponent menjalankan mutation komponen lalu memuat ulang komponen
+// hasilnya dari database.
+func (r *graphqlRequest) mutateComponent(method, path string, body interface{}) ([]interface{}, error) {
+	data, err := r.dispatch(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		ID uuid.UUID `json:"id"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.New("Failed to read component")
+	}
+	found, err := r.components.LoadMany([]uuid.UUID{result.ID})
+	if err != nil {
+		return nil, err
+	}
+	component, ok := found[result.ID]
+	if !ok {
+		return nil, errors.New("Component Not Found")
+	}
+	return []interface{}{component}, nil
+}
+
+func componentPath(args map[string]interface{}, suffix string) string {
+	return "/components/" + url.PathEscape(args["slug"].(string)) + suffix
+}
+
+// restBody mengubah input GraphQL (camelCase) menjadi body REST
+// (snake_case). Hanya key level atas yang diubah; nilai JSON seperti
+// propsDefinition diteruskan apa adanya.
+func restBody(input map[string]interface{}) map[string]interface{} {
+	body := make(map[string]interface{}, len(input))
+	for key, value := range input {
+		var name strings.Builder
+		for _, r := range key {
+			if unicode.IsUpper(r) {
+				name.WriteByte('_')
+				r = unicode.ToLower(r)
+			}
+			name.WriteRune(r)
+		}
+		body[name.String()] = value
+	}
+	return body
+}
+
+// componentConnection adalah satu halaman komponen. Posisi dihitung dari 1
+// dan menjadi cursor edge.
+type componentConnection struct {
+	ids    []uuid.UUID
+	offset int
+	total  int64
+}
+
+type componentEdge struct {
+	id       uuid.UUID
+	position int
+}
+
+func encodeCursor(position int) string {
+	return base64.StdEncoding.EncodeToString([]byte("cursor:" + strconv.Itoa(position)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(raw), "cursor:") {
+		if position, err := strconv.Atoi(strings.TrimPrefix(string(raw), "cursor:")); err == nil && position >= 0 {
+			return position, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid cursor %q", cursor)
+}
+
+// pageSize membaca argumen first: default 20, maksimal maxPageSize.
+func pageSize(args map[string]interface{}) int {
+	first, ok := args["first"].(int)
+	if !ok {
+		return 20
+	}
+	if first < 0 {
+		return 0
+	}
+	if first > maxPageSize {
+		return maxPageSize
+	}
+	return first
+}
+
+// filterFromArgs membaca input ComponentFilter ke componentFilter yang sama
+// dengan GET /components.
+func filterFromArgs(args map[string]interface{}) componentFilter {
+	filter := componentFilter{Deprecated: "false"}
+	input, _ := args["filter"].(map[string]interface{})
+	if input == nil {
+		return filter
+	}
+	if tags, ok := input["tags"].([]interface{}); ok {
+		for _, tag := range tags {
+			filter.Tags = append(filter.Tags, tag.(string))
+		}
+	}
+	filter.Category, _ = input["category"].(string)
+	filter.Status, _ = input["status"].(string)
+	filter.Approval, _ = input["approval"].(string)
+	filter.Framework, _ = input["framework"].(string)
+	filter.Search, _ = input["search"].(string)
+	switch input["deprecated"] {
+	case "DEPRECATED":
+		filter.Deprecated = "true"
+	case "ALL":
+		filter.Deprecated = "all"
+	}
+	return filter
+}
+
+// loadComponentPages memuat satu halaman komponen untuk setiap parent dengan
+// dua query: jumlah per parent dan ROW_NUMBER() per parent untuk halamannya.
+// parent adalah kolom pengelompokan (kosong untuk daftar root) dan join
+// adalah tabel yang dibutuhkan kolom tersebut.
+func loadComponentPages(r *graphqlRequest, args map[string]interface{}, parent, join string, parentIDs []uuid.UUID) (map[uuid.UUID]*componentConnection, error) {
+	if first, ok := args["first"].(int); ok && first < 0 {
+		return nil, errors.New("first must not be negative")
+	}
+	offset := 0
+	if after, ok := args["after"].(string); ok {
+		var err error
+		if offset, err = decodeCursor(after); err != nil {
+			return nil, err
+		}
+	}
+	filterScope, err := filterFromArgs(args).scope()
+	if err != nil {
+		return nil, err
+	}
+
+	base := func() *gorm.DB {
+		query := database.DB.WithContext(r.c.Request.Context()).Model(&model.Component{}).Scopes(visibleTo(r.c), filterScope)
+		if join != "" {
+			query = query.Joins(join)
+		}
+		if parent != "" {
+			query = query.Where(parent+" IN ?", parentIDs)
+		}
+		return query
+	}
+	parentColumn, over := "NULL", "ORDER BY components.created_at DESC, components.id"
+	if parent != "" {
+		parentColumn, over = parent, "PARTITION BY "+parent+" "+over
+	}
+
+	pages := make(map[uuid.UUID]*componentConnection, len(parentIDs))
+	for _, id := range parentIDs {
+		pages[id] = &componentConnection{offset: offset}
+	}
+
+	var totals []struct {
+		Parent uuid.UUID
+		Total  int64
+	}
+	countQuery := base().Select(parentColumn + " AS parent, COUNT(*) AS total")
+	if parent != "" {
+		countQuery = countQuery.Group(parent)
+	}
+	if err := countQuery.Scan(&totals).Error; err != nil {
+		return nil, errors.New("Failed to fetch components")
+	}
+	for _, row := range totals {
+		if page, ok := pages[row.Parent]; ok {
+			page.total = row.Total
+		}
+	}
+
+	var rows []struct {
+		Parent uuid.UUID
+		ID     uuid.UUID
+	}
+	ranked := base().Select(parentColumn + " AS parent, components.id AS id, ROW_NUMBER() OVER (" + over + ") AS rn")
+	err = database.DB.WithContext(r.c.Request.Context()).Table("(?) AS ranked", ranked).Select("parent, id").
+		Where("rn > ? AND rn <= ?", offset, offset+pageSize(args)).Order("parent, rn").Scan(&rows).Error
+	if err != nil {
+		return nil, errors.New("Failed to fetch components")
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+		if page, ok := pages[row.Parent]; ok {
+			page.ids = append(page.ids, row.ID)
+		}
+	}
+	if len(ids) > 0 {
+		var components []*model.Component
+		if err := database.DB.WithContext(r.c.Request.Context()).Where("id IN ?", ids).Find(&components).Error; err != nil {
+			return nil, errors.New("Failed to fetch components")
+		}
+		for _, component := range components {
+			r.components.Prime(component.ID, component)
+		}
+	}
+	return pages, nil
+}
+
+// componentsOf membuat resolver connection komponen untuk setiap source.
+func componentsOf(parent, join string, id func(source interface{}) uuid.UUID) graphql.ResolveFunc {
+	return func(ctx *graphql.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+		ids := make([]uuid.UUID, len(sources))
+		for i, source := range sources {
+			ids[i] = id(source)
+		}
+		pages, err := loadComponentPages(requestOf(ctx), args, parent, join, ids)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, len(sources))
+		for i := range sources {
+			values[i] = pages[ids[i]]
+		}
+		return values, nil
+	}
+}
+
+// loadComponents me-resolve field komponen berdasarkan ID; ID nil berarti
+// null.
+func loadComponents(ctx *graphql.Context, ids []*uuid.UUID) ([]interface{}, error) {
+	var keys []uuid.UUID
+	for _, id := range ids {
+		if id != nil {
+			keys = append(keys, *id)
+		}
+	}
+	found, err := requestOf(ctx).components.LoadMany(keys)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if id != nil {
+			if component, ok := found[*id]; ok {
+				values[i] = component
+			}
+		}
+	}
+	return values, nil
+}
+
+func optionalString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+var (
+	timeScalar = &graphql.Scalar{
+		Name:        "Time",
+		Description: "An RFC 3339 timestamp.",
+		Serialize: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case time.Time:
+				return v.Format(time.RFC3339Nano), nil
+			case *time.Time:
+				return v.Format(time.RFC3339Nano), nil
+			}
+			return nil, fmt.Errorf("Time cannot represent value: %v", value)
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			s, ok := value.(string)
+			if !ok {
+				return nil, errors.New("Time must be an RFC 3339 string")
+			}
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return nil, fmt.Errorf("Time cannot represent value %q", s)
+			}
+			return t, nil
+		},
+	}
+	jsonScalar = &graphql.Scalar{
+		Name:        "JSON",
+		Description: "Arbitrary JSON value.",
+		Serialize: func(value interface{}) (interface{}, error) {
+			if raw, ok := value.(datatypes.JSON); ok {
+				if len(raw) == 0 {
+					return nil, nil
+				}
+				return json.RawMessage(raw), nil
+			}
+			return value, nil
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			return value, nil
+		},
+	}
+)
+
+var catalogSchema = newCatalogSchema()
+
+func newCatalogSchema() *graphql.Schema {
+	nonNull := func(t graphql.Type) graphql.Type { return &graphql.NonNull{OfType: t} }
+	listOf := func(t graphql.Type) graphql.Type {
+		return &graphql.NonNull{OfType: &graphql.List{OfType: &graphql.NonNull{OfType: t}}}
+	}
+	slugArg := &graphql.ArgumentDefinition{Name: "slug", Type: nonNull(graphql.String)}
+
+	deprecatedFilter := &graphql.Enum{
+		Name:        "DeprecatedFilter",
+		Description: "Which components to include by deprecation state.",
+		Values: []*graphql.EnumValueDefinition{
+			{Name: "ACTIVE", Description: "Only components that are not deprecated."},
+			{Name: "DEPRECATED", Description: "Only deprecated components."},
+			{Name: "ALL", Description: "Both."},
+		},
+	}
+	componentFilterInput := &graphql.InputObject{
+		Name:        "ComponentFilter",
+		Description: "Same filters as GET /components.",
+		Fields: []*graphql.ArgumentDefinition{
+			{Name: "tags", Description: "Tag names; a component matches if it has any of them.", Type: &graphql.List{OfType: nonNull(graphql.String)}},
+			{Name: "category", Description: "Category slug.", Type: graphql.String},
+			{Name: "status", Type: graphql.String},
+			{Name: "approval", Type: graphql.String},
+			{Name: "framework", Description: "Only components with a variant for this framework.", Type: graphql.String},
+			{Name: "search", Description: "Case-insensitive match on name or description.", Type: graphql.String},
+			{Name: "deprecated", Type: deprecatedFilter, DefaultValue: "ACTIVE"},
+		},
+	}
+	connectionArgs := []*graphql.ArgumentDefinition{
+		{Name: "filter", Type: componentFilterInput},
+		{Name: "first", Description: fmt.Sprintf("Page size, at most %d.", maxPageSize), Type: graphql.Int, DefaultValue: 20},
+		{Name: "after", Description: "endCursor of the previous page.", Type: graphql.String},
+	}
+	connectionComplexity := func(args map[string]interface{}, child int) int {
+		return 1 + pageSize(args)*child
+	}
+
+	component := &graphql.Object{Name: "Component"}
+	category := &graphql.Object{Name: "Category"}
+	tag := &graphql.Object{Name: "Tag"}
+
+	pageInfo := &graphql.Object{
+		Name: "PageInfo",
+		Fields: []*graphql.FieldDefinition{
+			{Name: "hasNextPage", Type: nonNull(graphql.Boolean), Resolve: graphql.Property(func(c *componentConnection) interface{} {
+				return int64(c.offset+len(c.ids)) < c.total
+			})},
+			{Name: "hasPreviousPage", Type: nonNull(graphql.Boolean), Resolve: graphql.Property(func(c *componentConnection) interface{} {
+				return c.offset > 0
+			})},
+			{Name: "startCursor", Type: graphql.String, Resolve: graphql.Property(func(c *componentConnection) interface{} {
+				if len(c.ids) == 0 {
+					return nil
+				}
+				return encodeCursor(c.offset + 1)
+			})},
+			{Name: "endCursor", Type: graphql.String, Resolve: graphql.Property(func(c *componentConnection) interface{} {
+				if len(c.ids) == 0 {
+					return nil
+				}
+				return encodeCursor(c.offset + len(c.ids))
+			})},
+		},
+	}
+	componentEdgeType := &graphql.Object{
+		Name: "ComponentEdge",
+		Fields: []*graphql.FieldDefinition{
+			{Name: "cursor", Type: nonNull(graphql.String), Resolve: graphql.Property(func(e *componentEdge) interface{} {
+				return encodeCursor(e.position)
+			})},
+			{Name: "node", Type: nonNull(component), Resolve: func(ctx *graphql.Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+				ids := make([]*uuid.UUID, len(sources))
+				for i, source := range sources {
+					ids[i] = &source.(*componentEdge).id
+				}
+				return loadComponents(ctx, ids)
+			}},
+		},
+	}
+	componentConnectionType := &graphql.Object{
+		Name: "ComponentConnection",
+		Fields: []*graphql.FieldDefinition{
+			{Name: "totalCount", Type: nonNull(graphql.Int), Resolve: graphql.Property(func(c *componentConnection) interface{} {
+				return int(c.total)
+			})},
+			{Name: "edges", Type: listOf(componentEdgeType), Resolve: graphql.Property(func(c *componentConnection) interface{} {
+				edges := make([]*componentEdge, len(c.ids))
+				for i, id := range c.ids {
+					edges[i] = &componentEdge{id: id, position: c.offset + i + 1}
+				}
+				return edges
+			})},
+			{Name: "nodes", Type: listOf(component), Resolve: func(ctx *graphql.Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+				values := make([]interface{}, len(sources))
+				for i, source := range sources {
+					connection := source.(*componentConnection)
+					ids := make([]*uuid.UUID, len(connection.ids))
+					for j := range connection.ids {
+						ids[j] = &connection.ids[j]
+					}
+					nodes, err := loadComponents(ctx, ids)
+					if err != nil {
+						return nil, err
+					}
+					values[i] = nodes
+				}
+				return values, nil
+			}},
+			{Name: "pageInfo", Type: nonNull(pageInfo), Resolve: graphql.Property(func(c *componentConnection) interface{} {
+				return c
+			})},
+		},
+	}
+
+	variant := &graphql.Object{
+		Name: "ComponentVariant",
+		Fields: []*graphql.FieldDefinition{
+			{Name: "framework", Type: nonNull(graphql.String), Resolve: graphql.Property(func(v model.ComponentVariant) interface{} { return v.Framework })},
+			{Name: "code", Type: nonNull(graphql.String), Resolve: graphql.Property(func(v model.ComponentVariant) interface{} { return v.Code })},
+			{Name: "styles", Type: graphql.String, Resolve: graphql.Property(func(v model.ComponentVariant) interface{} { return optionalString(v.Styles) })},
+			{Name: "createdAt", Type: nonNull(timeScalar), Resolve: graphql.Property(func(v model.ComponentVariant) interface{} { return v.CreatedAt })},
+			{Name: "updatedAt", Type: nonNull(timeScalar), Resolve: graphql.Property(func(v model.ComponentVariant) interface{} { return v.UpdatedAt })},
+		},
+	}
+
+	component.Fields = []*graphql.FieldDefinition{
+		{Name: "id", Type: nonNull(graphql.ID), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.ID })},
+		{Name: "slug", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.Slug })},
+		{Name: "name", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.Name })},
+		{Name: "description", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.Description })},
+		{Name: "codeJsx", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.CodeJSX })},
+		{Name: "codeCss", Type: graphql.String, Resolve: graphql.Property(func(c *model.Component) interface{} { return optionalString(c.CodeCSS) })},
+		{Name: "propsDefinition", Type: jsonScalar, Resolve: graphql.Property(func(c *model.Component) interface{} { return c.PropsDefinition })},
+		{Name: "status", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.Status })},
+		{Name: "approvalStatus", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.ApprovalStatus })},
+		{Name: "userId", Type: nonNull(graphql.ID), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.UserID })},
+		{Name: "workspaceId", Type: graphql.ID, Resolve: graphql.Property(func(c *model.Component) interface{} { return c.WorkspaceID })},
+		{Name: "visibility", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.Visibility })},
+		{Name: "latestVersion", Type: graphql.String, Resolve: graphql.Property(func(c *model.Component) interface{} { return optionalString(c.LatestVersion) })},
+		{Name: "deprecatedAt", Type: timeScalar, Resolve: graphql.Property(func(c *model.Component) interface{} { return c.DeprecatedAt })},
+		{Name: "deprecationMessage", Type: graphql.String, Resolve: graphql.Property(func(c *model.Component) interface{} { return optionalString(c.DeprecationMessage) })},
+		{Name: "sunsetAt", Type: timeScalar, Resolve: graphql.Property(func(c *model.Component) interface{} { return c.SunsetAt })},
+		{Name: "forkedFromVersion", Type: graphql.String, Resolve: graphql.Property(func(c *model.Component) interface{} { return optionalString(c.ForkedFromVersion) })},
+		{Name: "createdAt", Type: nonNull(timeScalar), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.CreatedAt })},
+		{Name: "updatedAt", Type: nonNull(timeScalar), Resolve: graphql.Property(func(c *model.Component) interface{} { return c.UpdatedAt })},
+		{Name: "category", Type: nonNull(category), Resolve: func(ctx *graphql.Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+			ids := make([]uuid.UUID, len(sources))
+			for i, source := range sources {
+				ids[i] = source.(*model.Component).CategoryID
+			}
+			found, err := requestOf(ctx).categories.LoadMany(ids)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]interface{}, len(sources))
+			for i, id := range ids {
+				values[i] = found[id]
+			}
+			return values, nil
+		}},
+		{Name: "tags", Type: listOf(tag), Resolve: func(ctx *graphql.Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+			ids := make([]uuid.UUID, len(sources))
+			for i, source := range sources {
+				ids[i] = source.(*model.Component).ID
+			}
+			found, err := requestOf(ctx).tags.LoadMany(ids)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]interface{}, len(sources))
+			for i, id := range ids {
+				tags := found[id]
+				if tags == nil {
+					tags = []*model.Tag{}
+				}
+				values[i] = tags
+			}
+			return values, nil
+		}},
+		{Name: "variants", Type: listOf(variant), Resolve: func(ctx *graphql.Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+			ids := make([]uuid.UUID, len(sources))
+			for i, source := range sources {
+				ids[i] = source.(*model.Component).ID
+			}
+			found, err := requestOf(ctx).variants.LoadMany(ids)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]interface{}, len(sources))
+			for i, id := range ids {
+				variants := found[id]
+				if variants == nil {
+					variants = []model.ComponentVariant{}
+				}
+				values[i] = variants
+			}
+			return values, nil
+		}},
+		{Name: "replacedBy", Description: "Replacement suggested by the deprecation, if visible to the caller.", Type: component, Resolve: func(ctx *graphql.Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+			ids := make([]*uuid.UUID, len(sources))
+			for i, source := range sources {
+				ids[i] = source.(*model.Component).ReplacedByID
+			}
+			return loadComponents(ctx, ids)
+		}},
+		{Name: "forkedFrom", Description: "Component this one was forked from, if visible to the caller.", Type: component, Resolve: func(ctx *graphql.Context, sources []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+			ids := make([]*uuid.UUID, len(sources))
+			for i, source := range sources {
+				ids[i] = source.(*model.Component).ForkedFromID
+			}
+			return loadComponents(ctx, ids)
+		}},
+	}
+
+	category.Fields = []*graphql.FieldDefinition{
+		{Name: "id", Type: nonNull(graphql.ID), Resolve: graphql.Property(func(c *model.Category) interface{} { return c.ID })},
+		{Name: "slug", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Category) interface{} { return c.Slug })},
+		{Name: "name", Type: nonNull(graphql.String), Resolve: graphql.Property(func(c *model.Category) interface{} { return c.Name })},
+		{Name: "createdAt", Type: nonNull(timeScalar), Resolve: graphql.Property(func(c *model.Category) interface{} { return c.CreatedAt })},
+		{Name: "updatedAt", Type: nonNull(timeScalar), Resolve: graphql.Property(func(c *model.Category) interface{} { return c.UpdatedAt })},
+		{Name: "components", Type: nonNull(componentConnectionType), Args: connectionArgs, Complexity: connectionComplexity,
+			Resolve: componentsOf("components.category_id", "", func(source interface{}) uuid.UUID { return source.(*model.Category).ID })},
+	}
+
+	tag.Fields = []*graphql.FieldDefinition{
+		{Name: "id", Type: nonNull(graphql.ID), Resolve: graphql.Property(func(t *model.Tag) interface{} { return t.ID })},
+		{Name: "slug", Type: nonNull(graphql.String), Resolve: graphql.Property(func(t *model.Tag) interface{} { return t.Slug })},
+		{Name: "name", Type: nonNull(graphql.String), Resolve: graphql.Property(func(t *model.Tag) interface{} { return t.Name })},
+		{Name: "createdAt", Type: nonNull(timeScalar), Resolve: graphql.Property(func(t *model.Tag) interface{} { return t.CreatedAt })},
+		{Name: "updatedAt", Type: nonNull(timeScalar), Resolve: graphql.Property(func(t *model.Tag) interface{} { return t.UpdatedAt })},
+		{Name: "components", Type: nonNull(componentConnectionType), Args: connectionArgs, Complexity: connectionComplexity,
+			Resolve: componentsOf("component_tags.tag_id", "JOIN component_tags ON component_tags.component_id = components.id",
+				func(source interface{}) uuid.UUID { return source.(*model.Tag).ID })},
+	}
+
+	query := &graphql.Object{
+		Name: "Query",
+		Fields: []*graphql.FieldDefinition{
+			{Name: "components", Type: nonNull(componentConnectionType), Args: connectionArgs, Complexity: connectionComplexity,
+				Resolve: componentsOf("", "", func(interface{}) uuid.UUID { return uuid.Nil })},
+			{Name: "component", Type: component, Args: []*graphql.ArgumentDefinition{slugArg},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					r := requestOf(ctx)
+					var found model.Component
+					err := database.DB.WithContext(r.c.Request.Context()).Scopes(visibleTo(r.c)).Where("components.slug = ?", args["slug"]).First(&found).Error
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return []interface{}{nil}, nil
+					}
+					if err != nil {
+						return nil, errors.New("Failed to fetch component")
+					}
+					r.components.Prime(found.ID, &found)
+					return []interface{}{&found}, nil
+				}},
+			{Name: "categories", Type: listOf(category),
+				Resolve: func(ctx *graphql.Context, _ []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+					var categories []*model.Category
+					if err := database.DB.WithContext(requestOf(ctx).c.Request.Context()).Order("name asc").Find(&categories).Error; err != nil {
+						return nil, errors.New("Gagal mengambil data kategori")
+					}
+					return []interface{}{categories}, nil
+				}},
+			{Name: "category", Type: category, Args: []*graphql.ArgumentDefinition{slugArg},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					var found model.Category
+					err := database.DB.WithContext(requestOf(ctx).c.Request.Context()).Where("slug = ?", args["slug"]).First(&found).Error
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return []interface{}{nil}, nil
+					}
+					if err != nil {
+						return nil, errors.New("Gagal mengambil data kategori")
+					}
+					return []interface{}{&found}, nil
+				}},
+			{Name: "tags", Type: listOf(tag),
+				Resolve: func(ctx *graphql.Context, _ []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+					var tags []*model.Tag
+					if err := database.DB.WithContext(requestOf(ctx).c.Request.Context()).Order("name asc").Find(&tags).Error; err != nil {
+						return nil, errors.New("Gagal mengambil data tag")
+					}
+					return []interface{}{tags}, nil
+				}},
+			{Name: "tag", Type: tag, Args: []*graphql.ArgumentDefinition{slugArg},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					var found model.Tag
+					err := database.DB.WithContext(requestOf(ctx).c.Request.Context()).Where("slug = ?", args["slug"]).First(&found).Error
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return []interface{}{nil}, nil
+					}
+					if err != nil {
+						return nil, errors.New("Gagal mengambil data tag")
+					}
+					return []interface{}{&found}, nil
+				}},
+		},
+	}
+
+	variantInput := &graphql.InputObject{
+		Name: "ComponentVariantInput",
+		Fields: []*graphql.ArgumentDefinition{
+			{Name: "framework", Type: nonNull(graphql.String)},
+			{Name: "code", Type: nonNull(graphql.String)},
+			{Name: "styles", Type: graphql.String},
+		},
+	}
+	fileInput := &graphql.InputObject{
+		Name: "ComponentFileInput",
+		Fields: []*graphql.ArgumentDefinition{
+			{Name: "framework", Type: graphql.String},
+			{Name: "path", Type: nonNull(graphql.String)},
+			{Name: "language", Type: graphql.String},
+			{Name: "role", Type: graphql.String},
+			{Name: "content", Type: graphql.String},
+		},
+	}
+	createComponentInput := &graphql.InputObject{
+		Name:        "CreateComponentInput",
+		Description: "Same fields as POST /components.",
+		Fields: []*graphql.ArgumentDefinition{
+			{Name: "name", Type: nonNull(graphql.String)},
+			{Name: "description", Type: graphql.String},
+			{Name: "categoryId", Type: nonNull(graphql.ID)},
+			{Name: "codeJsx", Type: graphql.String},
+			{Name: "codeCss", Type: graphql.String},
+			{Name: "propsDefinition", Type: jsonScalar},
+			{Name: "variants", Type: &graphql.List{OfType: nonNull(variantInput)}},
+			{Name: "files", Type: &graphql.List{OfType: nonNull(fileInput)}},
+			{Name: "workspace", Description: "Workspace slug.", Type: graphql.String},
+			{Name: "visibility", Type: graphql.String},
+		},
+	}
+	updateComponentInput := &graphql.InputObject{
+		Name: "UpdateComponentInput",
+		Fields: []*graphql.ArgumentDefinition{
+			{Name: "name", Type: graphql.String},
+			{Name: "description", Type: graphql.String},
+		},
+	}
+	deprecateComponentInput := &graphql.InputObject{
+		Name: "DeprecateComponentInput",
+		Fields: []*graphql.ArgumentDefinition{
+			{Name: "message", Type: nonNull(graphql.String)},
+			{Name: "sunsetAt", Description: "Date (2006-01-02) or RFC 3339 timestamp.", Type: graphql.String},
+			{Name: "replacedBy", Description: "Slug of the replacement component.", Type: graphql.String},
+		},
+	}
+
+	// Hasil mutation nullable supaya mutation yang gagal tidak membuat hasil
+	// mutation lain di request yang sama ikut menjadi null.
+	mutation := &graphql.Object{
+		Name: "Mutation",
+		Fields: []*graphql.FieldDefinition{
+			{Name: "createComponent", Type: component,
+				Args: []*graphql.ArgumentDefinition{{Name: "input", Type: nonNull(createComponentInput)}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					return requestOf(ctx).mutateComponent(http.MethodPost, "/components", restBody(args["input"].(map[string]interface{})))
+				}},
+			{Name: "updateComponent", Type: component,
+				Args: []*graphql.ArgumentDefinition{slugArg, {Name: "input", Type: nonNull(updateComponentInput)}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					return requestOf(ctx).mutateComponent(http.MethodPatch, componentPath(args, ""), restBody(args["input"].(map[string]interface{})))
+				}},
+			{Name: "deleteComponent", Type: graphql.Boolean, Args: []*graphql.ArgumentDefinition{slugArg},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					if _, err := requestOf(ctx).dispatch(http.MethodDelete, componentPath(args, ""), nil); err != nil {
+						return nil, err
+					}
+					return []interface{}{true}, nil
+				}},
+			{Name: "addComponentTag", Type: component,
+				Args: []*graphql.ArgumentDefinition{slugArg, {Name: "tagId", Type: nonNull(graphql.ID)}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					return requestOf(ctx).mutateComponent(http.MethodPost, componentPath(args, "/tags"), map[string]interface{}{"tag_id": args["tagId"]})
+				}},
+			{Name: "updateComponentStatus", Type: component,
+				Args: []*graphql.ArgumentDefinition{slugArg, {Name: "status", Type: nonNull(graphql.String)}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					return requestOf(ctx).mutateComponent(http.MethodPatch, componentPath(args, "/status"), map[string]interface{}{"status": args["status"]})
+				}},
+			{Name: "updateComponentApproval", Type: component,
+				Args: []*graphql.ArgumentDefinition{slugArg, {Name: "approvalStatus", Type: nonNull(graphql.String)}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					body := map[string]interface{}{"approval_status": args["approvalStatus"]}
+					return requestOf(ctx).mutateComponent(http.MethodPatch, componentPath(args, "/approval"), body)
+				}},
+			{Name: "updateComponentVisibility", Type: component,
+				Args: []*graphql.ArgumentDefinition{slugArg, {Name: "visibility", Type: nonNull(graphql.String)}, {Name: "workspace", Description: "Workspace slug.", Type: graphql.String}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					body := map[string]interface{}{"visibility": args["visibility"], "workspace": args["workspace"]}
+					return requestOf(ctx).mutateComponent(http.MethodPatch, componentPath(args, "/visibility"), body)
+				}},
+			{Name: "deprecateComponent", Type: component,
+				Args: []*graphql.ArgumentDefinition{slugArg, {Name: "input", Type: nonNull(deprecateComponentInput)}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					return requestOf(ctx).mutateComponent(http.MethodPut, componentPath(args, "/deprecation"), restBody(args["input"].(map[string]interface{})))
+				}},
+			{Name: "undeprecateComponent", Type: component, Args: []*graphql.ArgumentDefinition{slugArg},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					return requestOf(ctx).mutateComponent(http.MethodDelete, componentPath(args, "/deprecation"), nil)
+				}},
+			{Name: "createCategory", Type: category,
+				Args: []*graphql.ArgumentDefinition{{Name: "name", Type: nonNull(graphql.String)}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					data, err := requestOf(ctx).dispatch(http.MethodPost, "/categories", map[string]interface{}{"name": args["name"]})
+					if err != nil {
+						return nil, err
+					}
+					var created model.Category
+					if err := json.Unmarshal(data, &created); err != nil {
+						return nil, errors.New("Failed to read category")
+					}
+					return []interface{}{&created}, nil
+				}},
+			{Name: "createTag", Type: tag,
+				Args: []*graphql.ArgumentDefinition{{Name: "name", Type: nonNull(graphql.String)}},
+				Resolve: func(ctx *graphql.Context, _ []interface{}, args map[string]interface{}) ([]interface{}, error) {
+					data, err := requestOf(ctx).dispatch(http.MethodPost, "/tags", map[string]interface{}{"name": args["name"]})
+					if err != nil {
+						return nil, err
+					}
+					var created model.Tag
+					if err := json.Unmarshal(data, &created); err != nil {
+						return nil, errors.New("Failed to read tag")
+					}
+					return []interface{}{&created}, nil
+				}},
+		},
+	}
+
+	return graphql.NewSchema(query, mutation)
+}
+
+// GraphQL menjalankan query GraphQL untuk komponen, kategori dan tag beserta
+// relasinya. Filter components sama dengan GET /components, daftar memakai
+// connection (first/after). Mutation dijalankan lewat endpoint REST yang sama
+// sehingga butuh scope dan hak akses yang sama. Query dibatasi kedalaman
+// (GRAPHQL_MAX_DEPTH) dan complexity (GRAPHQL_MAX_COMPLEXITY). GET dengan
+// parameter query, operationName dan variables hanya untuk query, bukan
+// mutation. Response mengikuti format GraphQL ({data, errors}), bukan envelope
+// REST.
+func GraphQL(router *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input GraphQLRequest
+		if c.Request.Method == http.MethodGet {
+			input.Query = c.Query("query")
+			input.OperationName = c.Query("operationName")
+			if variables := c.Query("variables"); variables != "" {
+				decoder := json.NewDecoder(strings.NewReader(variables))
+				decoder.UseNumber()
+				if err := decoder.Decode(&input.Variables); err != nil {
+					utils.Error(c, http.StatusBadRequest, "variables must be a JSON object")
+					return
+				}
+			}
+		} else {
+			decoder := json.NewDecoder(c.Request.Body)
+			decoder.UseNumber()
+			if err := decoder.Decode(&input); err != nil {
+				utils.Error(c, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		if input.Query == "" {
+			utils.Error(c, http.StatusBadRequest, "query is required")
+			return
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        catalogSchema,
+			Query:         input.Query,
+			OperationName: input.OperationName,
+			Variables:     input.Variables,
+			Context:       c.Request.Context(),
+			Data:          newGraphQLRequest(c, router),
+			ReadOnly:      c.Request.Method == http.MethodGet,
+		})
+		status := http.StatusOK
+		if !result.Executed {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, result)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// HealthCheck selalu menjawab 200 dengan status "ok" dan nama service. Tidak
+// mengecek database; cukup untuk memastikan proses masih melayani HTTP.
 func HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status": "ok",
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"service_components/internal/auth"
+	"service_components/internal/database"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/testdb"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// testPassword adalah password semua user yang dibuat newUser.
+const testPassword = "correct-horse"
+
+// openTestDB memasang database SQLite in-memory sebagai database.DB selama
+// test berjalan.
+func openTestDB(t *testing.T) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	previous := database.DB
+	database.DB = testdb.Open(t,
+		&model.Category{}, &model.Tag{}, &model.Component{}, &model.ComponentVariant{}, &model.ComponentFile{},
+		&model.Asset{}, &model.Screenshot{}, &model.ComponentRelease{}, &model.Workspace{}, &model.WorkspaceMember{},
+		&model.ShareLink{}, &model.User{}, &model.Session{}, &model.PasswordReset{}, &model.UserIdentity{},
+		&model.OIDCLogin{}, &model.APIKey{}, &model.AuditEvent{}, &model.Webhook{}, &model.WebhookDelivery{},
+		&model.OutboxEvent{},
+	)
+	t.Cleanup(func() { database.DB = previous })
+}
+
+// newUser membuat user dengan role tersebut beserta sesi login, lalu
+// mengembalikan user dan access token-nya.
+func newUser(t *testing.T, email, role string) (model.User, string) {
+	t.Helper()
+	hash, err := auth.HashPassword(testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := model.User{Email: email, Name: email, Role: role, PasswordHash: hash}
+	if err := database.DB.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	session := model.Session{UserID: user.ID}
+	tokens, err := newTokens(&session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.DB.Create(&session).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user, tokens.AccessToken
+}
+
+// serve menjalankan satu request lewat middleware.Authenticate ke handlers
+// yang didaftarkan pada route, seperti di router. body dikirim sebagai JSON
+// jika tidak nil; token kosong berarti request anonim.
+func serve(t *testing.T, token, method, route, path string, body interface{}, handlers ...gin.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	engine := gin.New()
+	engine.Use(middleware.Authenticate())
+	engine.Handle(method, route, handlers...)
+
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+// newAPIKey membuat API key milik userID dengan semua scope, dibatasi ke
+// workspaceID jika tidak nil.
+func newAPIKey(t *testing.T, userID uuid.UUID, workspaceID *uuid.UUID) string {
+	t.Helper()
+	token, err := auth.NewAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := model.APIKey{
+		Name:        "test",
+		Prefix:      token[:8],
+		KeyHash:     auth.HashToken(token),
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Scopes:      []string{model.ScopeComponentsRead, model.ScopeComponentsWrite, model.ScopeReview},
+	}
+	if err := database.DB.Create(&key).Error; err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// newWorkspace membuat workspace dengan member dan role-nya.
+func newWorkspace(t *testing.T, slug string, members map[uuid.UUID]string) model.Workspace {
+	t.Helper()
+	workspace := model.Workspace{Slug: slug, Name: slug}
+	if err := database.DB.Create(&workspace).Error; err != nil {
+		t.Fatal(err)
+	}
+	for userID, role := range members {
+		member := model.WorkspaceMember{WorkspaceID: workspace.ID, UserID: userID, Role: role}
+		if err := database.DB.Create(&member).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return workspace
+}
+
+// newComponent membuat komponen milik author di workspace (nil untuk tanpa
+// workspace) dengan visibility tersebut.
+func newComponent(t *testing.T, slug string, author uuid.UUID, workspace *model.Workspace, visibility string) *model.Component {
+	t.Helper()
+	component := model.Component{
+		Slug:       slug,
+		Name:       slug,
+		CategoryID: uuid.New(),
+		CodeJSX:    "export default function C() { return null }",
+		UserID:     author,
+		Visibility: visibility,
+		Status:     model.StatusDraft,
+	}
+	if workspace != nil {
+		component.WorkspaceID = &workspace.ID
+	}
+	if err := database.DB.Create(&component).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &component
+}
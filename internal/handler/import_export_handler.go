@@ -0,0 +1,663 @@
+package handler
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictRename    = "rename"
+
+	RecordCategory  = "category"
+	RecordTag       = "tag"
+	RecordComponent = "component"
+
+	catalogFileName = "catalog.ndjson"
+	maxImportSize   = 50 << 20
+)
+
+var (
+	errDryRun         = errors.New("dry run")
+	errImportTooLarge = fmt.Errorf("import file exceeds the %d MB limit", maxImportSize>>20)
+)
+
+// CatalogRecord adalah satu baris NDJSON pada file export. Field yang dipakai
+// tergantung Kind; relasi kategori, tag dan workspace ditulis sebagai slug.
+// Untuk komponen, Files adalah sumber utama; jika kosong, file dibentuk dari
+// CodeJSX/CodeCSS dan Variants. Status dan ApprovalStatus hanya informasi:
+// komponen hasil import selalu mulai sebagai draft yang menunggu review.
+type CatalogRecord struct {
+	Kind            string                    `json:"kind"`
+	Slug            string                    `json:"slug"`
+	Name            string                    `json:"name"`
+	Description     string                    `json:"description,omitempty"`
+	Category        string                    `json:"category,omitempty"`
+	Tags            []string                  `json:"tags,omitempty"`
+	CodeJSX         string                    `json:"code_jsx,omitempty"`
+	CodeCSS         string                    `json:"code_css,omitempty"`
+	PropsDefinition datatypes.JSON            `json:"props_definition,omitempty"`
+	Variants        []ComponentVariantRequest `json:"variants,omitempty"`
+	Files           []ComponentFileRequest    `json:"files,omitempty"`
+	Workspace       string                    `json:"workspace,omitempty"`
+	Visibility      string                    `json:"visibility,omitempty"`
+	Status          string                    `json:"status,omitempty"`
+	ApprovalStatus  string                    `json:"approval_status,omitempty"`
+}
+
+type ImportItemResult struct {
+	Kind    string `json:"kind"`
+	Slug    string `json:"slug"`
+	Action  string `json:"action"`
+	NewSlug string `json:"new_slug,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool               `json:"dry_run"`
+	Policy  string             `json:"policy"`
+	Summary map[string]int     `json:"summary"`
+	Items   []ImportItemResult `json:"items"`
+}
+
+// ExportCatalog mengekspor seluruh kategori, tag dan komponen sebagai NDJSON
+// (default) atau arsip zip (format=zip).
+func ExportCatalog(c *gin.Context) {
+	format := c.DefaultQuery("format", "ndjson")
+	if format != "ndjson" && format != "zip" {
+		utils.Error(c, http.StatusBadRequest, "format must be ndjson or zip")
+		return
+	}
+
+	records, err := exportRecords(c)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to export catalog")
+		return
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to encode catalog")
+			return
+		}
+	}
+
+	stamp := time.Now().UTC().Format("20060102-150405")
+	if format == "ndjson" {
+		c.Header("Content-Disposition", `attachment; filename="catalog-`+stamp+`.ndjson"`)
+		c.Data(http.StatusOK, "application/x-ndjson", buf.Bytes())
+		return
+	}
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	w, err := zw.Create(catalogFileName)
+	if err == nil {
+		_, err = w.Write(buf.Bytes())
+	}
+	if err == nil {
+		err = zw.Close()
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to build archive")
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="catalog-`+stamp+`.zip"`)
+	c.Data(http.StatusOK, "application/zip", archive.Bytes())
+}
+
+func exportRecords(c *gin.Context) ([]CatalogRecord, error) {
+	var categories []model.Category
+	var tags []model.Tag
+	var components []model.Component
+
+	if err := database.DB.WithContext(c.Request.Context()).Order("slug asc").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	if err := database.DB.WithContext(c.Request.Context()).Order("slug asc").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	if err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Preload("Category").Preload("Tags").Order("slug asc").Find(&components).Error; err != nil {
+		return nil, err
+	}
+
+	var workspaceIDs []uuid.UUID
+	for _, component := range components {
+		if component.WorkspaceID != nil {
+			workspaceIDs = append(workspaceIDs, *component.WorkspaceID)
+		}
+	}
+	var workspaces []model.Workspace
+	if len(workspaceIDs) > 0 {
+		if err := database.DB.WithContext(c.Request.Context()).Where("id IN ?", workspaceIDs).Find(&workspaces).Error; err != nil {
+			return nil, err
+		}
+	}
+	workspaceSlugs := make(map[uuid.UUID]string, len(workspaces))
+	for _, workspace := range workspaces {
+		workspaceSlugs[workspace.ID] = workspace.Slug
+	}
+
+	records := make([]CatalogRecord, 0, len(categories)+len(tags)+len(components))
+	for _, category := range categories {
+		records = append(records, CatalogRecord{Kind: RecordCategory, Slug: category.Slug, Name: category.Name})
+	}
+	for _, tag := range tags {
+		records = append(records, CatalogRecord{Kind: RecordTag, Slug: tag.Slug, Name: tag.Name})
+	}
+	for _, component := range components {
+		tagSlugs := make([]string, 0, len(component.Tags))
+		for _, tag := range component.Tags {
+			tagSlugs = append(tagSlugs, tag.Slug)
+		}
+		files, err := loadComponentFiles(database.DB.WithContext(c.Request.Context()), &component, "")
+		if err != nil {
+			return nil, err
+		}
+		fileRecords := make([]ComponentFileRequest, 0, len(files))
+		for _, file := range files {
+			fileRecords = append(fileRecords, ComponentFileRequest{
+				Framework: file.Framework,
+				Path:      file.Path,
+				Language:  file.Language,
+				Role:      file.Role,
+				Content:   file.Content,
+			})
+		}
+		workspace := ""
+		if component.WorkspaceID != nil {
+			workspace = workspaceSlugs[*component.WorkspaceID]
+		}
+		records = append(records, CatalogRecord{
+			Kind:            RecordComponent,
+			Slug:            component.Slug,
+			Name:            component.Name,
+			Description:     component.Description,
+			Category:        component.Category.Slug,
+			Tags:            tagSlugs,
+			CodeJSX:         component.CodeJSX,
+			CodeCSS:         component.CodeCSS,
+			PropsDefinition: component.PropsDefinition,
+			Files:           fileRecords,
+			Workspace:       workspace,
+			Visibility:      component.Visibility,
+			Status:          component.Status,
+			ApprovalStatus:  component.ApprovalStatus,
+		})
+	}
+	return records, nil
+}
+
+// ImportCatalog meng-upsert kategori, tag dan komponen berdasarkan slug dari
+// file NDJSON atau zip hasil export. Hanya untuk reviewer dan admin; komponen
+// baru dibuat atas nama pemanggil sebagai draft yang menunggu review.
+func ImportCatalog(c *gin.Context) {
+	if !requireReviewer(c) {
+		return
+	}
+
+	policy := c.DefaultQuery("policy", ConflictSkip)
+	if policy != ConflictSkip && policy != ConflictOverwrite && policy != ConflictRename {
+		utils.Error(c, http.StatusBadRequest, "policy must be skip, overwrite or rename")
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	body, err := readImport(c.Request.Body)
+	if errors.Is(err, errImportTooLarge) {
+		utils.Error(c, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	records, err := decodeCatalog(body)
+	if errors.Is(err, errImportTooLarge) {
+		utils.Error(c, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	callerID, _ := middleware.CallerID(c)
+	importer := &catalogImporter{
+		c:        c,
+		callerID: callerID,
+		policy:   policy,
+		renamed:  map[string]map[string]string{RecordCategory: {}, RecordTag: {}},
+		report:   ImportReport{DryRun: dryRun, Policy: policy, Summary: map[string]int{}},
+	}
+
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		importer.tx = tx
+		if err := importer.run(records); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		utils.Error(c, http.StatusInternalServerError, "Failed to import catalog")
+		return
+	}
+
+	utils.Success(c, importer.report)
+}
+
+// readImport membaca r sampai maxImportSize. Satu byte lebih dibaca supaya
+// file yang terlalu besar ditolak, bukan terpotong diam-diam.
+func readImport(r io.Reader) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, maxImportSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxImportSize {
+		return nil, errImportTooLarge
+	}
+	return body, nil
+}
+
+// decodeCatalog menerima NDJSON mentah atau zip yang berisi catalog.ndjson.
+// Isi zip dibatasi maxImportSize setelah didekompresi.
+func decodeCatalog(body []byte) ([]CatalogRecord, error) {
+	if bytes.HasPrefix(body, []byte("PK\x03\x04")) {
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid zip archive: %w", err)
+		}
+		f, err := zr.Open(catalogFileName)
+		if err != nil {
+			return nil, fmt.Errorf("archive does not contain %s", catalogFileName)
+		}
+		defer f.Close()
+		if body, err = readImport(f); err != nil {
+			return nil, err
+		}
+	}
+
+	var records []CatalogRecord
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record CatalogRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if record.Slug == "" {
+			return nil, fmt.Errorf("line %d: slug is required", line)
+		}
+		switch record.Kind {
+		case RecordCategory, RecordTag, RecordComponent:
+		default:
+			return nil, fmt.Errorf("line %d: unknown kind %q", line, record.Kind)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+type catalogImporter struct {
+	c        *gin.Context
+	callerID uuid.UUID
+	tx       *gorm.DB
+	policy   string
+	renamed  map[string]map[string]string
+	report   ImportReport
+}
+
+// run mengimpor kategori dan tag terlebih dahulu supaya relasi komponen bisa
+// di-resolve. Setiap item dijalankan di savepoint sendiri sehingga satu item
+// yang gagal tidak membatalkan seluruh import. Error savepoint sendiri
+// membatalkan import karena transaksinya tidak bisa dipakai lagi.
+func (im *catalogImporter) run(records []CatalogRecord) error {
+	for _, kind := range []string{RecordCategory, RecordTag, RecordComponent} {
+		for _, record := range records {
+			if record.Kind != kind {
+				continue
+			}
+
+			if err := im.tx.SavePoint("import_item").Error; err != nil {
+				return err
+			}
+			result, err := im.importRecord(record)
+			if err != nil {
+				if err := im.tx.RollbackTo("import_item").Error; err != nil {
+					return err
+				}
+				result = ImportItemResult{Action: "error", Error: err.Error()}
+			}
+			result.Kind = record.Kind
+			result.Slug = record.Slug
+
+			im.report.Items = append(im.report.Items, result)
+			im.report.Summary[result.Action]++
+		}
+	}
+	return nil
+}
+
+func (im *catalogImporter) importRecord(record CatalogRecord) (ImportItemResult, error) {
+	switch record.Kind {
+	case RecordCategory:
+		return im.importCategory(record)
+	case RecordTag:
+		return im.importTag(record)
+	default:
+		return im.importComponent(record)
+	}
+}
+
+func (im *catalogImporter) importCategory(record CatalogRecord) (ImportItemResult, error) {
+	var existing model.Category
+	found, err := im.find(&existing, record.Slug)
+	if err != nil {
+		return ImportItemResult{}, err
+	}
+
+	if !found {
+		category := model.Category{Slug: record.Slug, Name: record.Name}
+		if err := im.tx.Create(&category).Error; err != nil {
+			return ImportItemResult{}, err
+		}
+		return ImportItemResult{Action: "created"}, im.audit("create", model.AuditEntityCategory, category.ID, category.Slug, nil, &category)
+	}
+
+	switch im.policy {
+	case ConflictOverwrite:
+		before := existing
+		existing.Name = record.Name
+		if err := im.tx.Save(&existing).Error; err != nil {
+			return ImportItemResult{}, err
+		}
+		return ImportItemResult{Action: "updated"}, im.audit("update", model.AuditEntityCategory, existing.ID, existing.Slug, &before, &existing)
+	case ConflictRename:
+		slug, err := freeSlug(im.tx, &model.Category{}, record.Slug)
+		if err != nil {
+			return ImportItemResult{}, err
+		}
+		im.renamed[RecordCategory][record.Slug] = slug
+		category := model.Category{Slug: slug, Name: record.Name}
+		if err := im.tx.Create(&category).Error; err != nil {
+			return ImportItemResult{}, err
+		}
+		return ImportItemResult{Action: "renamed", NewSlug: slug}, im.audit("create", model.AuditEntityCategory, category.ID, category.Slug, nil, &category)
+	}
+	return ImportItemResult{Action: "skipped"}, nil
+}
+
+func (im *catalogImporter) importTag(record CatalogRecord) (ImportItemResult, error) {
+	var existing model.Tag
+	found, err := im.find(&existing, record.Slug)
+	if err != nil {
+		return ImportItemResult{}, err
+	}
+
+	if !found {
+		tag := model.Tag{Slug: record.Slug, Name: record.Name}
+		if err := im.tx.Create(&tag).Error; err != nil {
+			return ImportItemResult{}, err
+		}
+		return ImportItemResult{Action: "created"}, im.audit("create", model.AuditEntityTag, tag.ID, tag.Slug, nil, &tag)
+	}
+
+	switch im.policy {
+	case ConflictOverwrite:
+		before := existing
+		existing.Name = record.Name
+		if err := im.tx.Save(&existing).Error; err != nil {
+			return ImportItemResult{}, err
+		}
+		return ImportItemResult{Action: "updated"}, im.audit("update", model.AuditEntityTag, existing.ID, existing.Slug, &before, &existing)
+	case ConflictRename:
+		slug, err := freeSlug(im.tx, &model.Tag{}, record.Slug)
+		if err != nil {
+			return ImportItemResult{}, err
+		}
+		im.renamed[RecordTag][record.Slug] = slug
+		tag := model.Tag{Slug: slug, Name: record.Name}
+		if err := im.tx.Create(&tag).Error; err != nil {
+			return ImportItemResult{}, err
+		}
+		return ImportItemResult{Action: "renamed", NewSlug: slug}, im.audit("create", model.AuditEntityTag, tag.ID, tag.Slug, nil, &tag)
+	}
+	return ImportItemResult{Action: "skipped"}, nil
+}
+
+func (im *catalogImporter) importComponent(record CatalogRecord) (ImportItemResult, error) {
+	var category model.Category
+	if err := im.tx.Where("slug = ?", im.resolve(RecordCategory, record.Category)).First(&category).Error; err != nil {
+		return ImportItemResult{}, fmt.Errorf("category %q not found", record.Category)
+	}
+
+	tags := make([]*model.Tag, 0, len(record.Tags))
+	for _, slug := range record.Tags {
+		var tag model.Tag
+		if err := im.tx.Where("slug = ?", im.resolve(RecordTag, slug)).First(&tag).Error; err != nil {
+			return ImportItemResult{}, fmt.Errorf("tag %q not found", slug)
+		}
+		tags = append(tags, &tag)
+	}
+
+	var component model.Component
+	found, err := im.findComponent(&component, record.Slug)
+	if err != nil {
+		return ImportItemResult{}, err
+	}
+
+	result := ImportItemResult{Action: "created"}
+	var before *model.Component
+	if found {
+		switch im.policy {
+		case ConflictSkip:
+			return ImportItemResult{Action: "skipped"}, nil
+		case ConflictOverwrite:
+			allowed, err := canEdit(im.c, &component)
+			if err != nil {
+				return ImportItemResult{}, err
+			}
+			if !allowed {
+				return ImportItemResult{}, fmt.Errorf("only the author or workspace admins can overwrite component %s", record.Slug)
+			}
+			result.Action = "updated"
+			previous := component
+			if err := im.tx.Model(&component).Association("Tags").Find(&previous.Tags); err != nil {
+				return ImportItemResult{}, err
+			}
+			before = &previous
+		case ConflictRename:
+			slug, err := freeSlug(im.tx, &model.Component{}, record.Slug)
+			if err != nil {
+				return ImportItemResult{}, err
+			}
+			component = model.Component{Slug: slug}
+			result = ImportItemResult{Action: "renamed", NewSlug: slug}
+		}
+	} else {
+		component.Slug = record.Slug
+	}
+
+	// File dibentuk dari slug akhir supaya komponen yang di-rename tidak
+	// berisi file bernama slug lama.
+	files, err := recordFiles(record, component.Slug)
+	if err != nil {
+		return ImportItemResult{}, err
+	}
+
+	// Komponen yang di-overwrite tetap milik pembuatnya dan tetap di
+	// workspace, visibility, status dan approval-nya sekarang.
+	if component.ID == uuid.Nil {
+		workspaceID, visibility, err := im.workspace(record)
+		if err != nil {
+			return ImportItemResult{}, err
+		}
+		component.UserID = im.callerID
+		component.WorkspaceID = workspaceID
+		component.Visibility = visibility
+		component.Status = model.StatusDraft
+		component.ApprovalStatus = model.ApprovalPending
+	}
+	component.Name = record.Name
+	component.Description = record.Description
+	component.CategoryID = category.ID
+	component.PropsDefinition = record.PropsDefinition
+
+	if err := im.tx.Omit("Category", "Tags", "Variants", "Files").Save(&component).Error; err != nil {
+		return ImportItemResult{}, err
+	}
+	if err := im.tx.Model(&component).Association("Tags").Replace(tags); err != nil {
+		return ImportItemResult{}, err
+	}
+
+	if err := im.tx.Where("component_id = ?", component.ID).Delete(&model.ComponentFile{}).Error; err != nil {
+		return ImportItemResult{}, err
+	}
+	for i := range files {
+		files[i].ComponentID = component.ID
+	}
+	if err := im.tx.Create(&files).Error; err != nil {
+		return ImportItemResult{}, err
+	}
+	if err := syncVariants(im.tx, component.ID); err != nil {
+		return ImportItemResult{}, err
+	}
+	action := "create"
+	if before != nil {
+		action = "update"
+	}
+	return result, recordComponentChange(im.c, im.tx, action, before, &component)
+}
+
+// audit mencatat perubahan di savepoint item yang sedang diimpor, sehingga
+// item yang gagal atau dry run tidak meninggalkan audit event.
+func (im *catalogImporter) audit(action, entityType string, entityID uuid.UUID, slug string, before, after interface{}) error {
+	return recordAudit(im.c, im.tx, action, entityType, entityID, slug, before, after)
+}
+
+func recordFiles(record CatalogRecord, slug string) ([]model.ComponentFile, error) {
+	if len(record.Files) == 0 {
+		variants, err := buildVariants(record.CodeJSX, record.CodeCSS, record.Variants)
+		if err != nil {
+			return nil, err
+		}
+		return buildFiles(slug, variants, nil)
+	}
+
+	files := make([]model.ComponentFile, 0, len(record.Files))
+	for _, req := range record.Files {
+		file, err := newComponentFile(req)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, checkFileSet(files)
+}
+
+func (im *catalogImporter) find(dest interface{}, slug string) (bool, error) {
+	err := im.tx.Where("slug = ?", slug).First(dest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// findComponent hanya menemukan komponen yang terlihat oleh pemanggil. Slug
+// yang dipakai komponen lain (termasuk yang sudah dihapus) dianggap konflik
+// yang tidak bisa di-overwrite.
+func (im *catalogImporter) findComponent(component *model.Component, slug string) (bool, error) {
+	err := im.tx.Scopes(visibleTo(im.c)).Where("slug = ?", slug).First(component).Error
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	var taken int64
+	if err := im.tx.Unscoped().Model(&model.Component{}).Where("slug = ?", slug).Count(&taken).Error; err != nil {
+		return false, err
+	}
+	if taken > 0 && im.policy == ConflictOverwrite {
+		return false, fmt.Errorf("slug %q is already used by another component", slug)
+	}
+	return taken > 0, nil
+}
+
+// workspace memvalidasi workspace dan visibility komponen baru seperti
+// resolveWorkspace: pemanggil harus menjadi member workspace tujuan.
+func (im *catalogImporter) workspace(record CatalogRecord) (*uuid.UUID, string, error) {
+	visibility := record.Visibility
+	if visibility == "" {
+		visibility = model.VisibilityPublic
+	}
+	if !validVisibility(visibility) {
+		return nil, "", errors.New("visibility must be public, internal or private")
+	}
+	if record.Workspace == "" {
+		if visibility != model.VisibilityPublic {
+			return nil, "", errors.New("internal and private components need a workspace")
+		}
+		return nil, visibility, nil
+	}
+
+	var workspace model.Workspace
+	err := im.tx.Where("slug = ?", record.Workspace).First(&workspace).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && scopeFor(im.c).role(workspace.ID) == "") {
+		return nil, "", fmt.Errorf("you are not a member of workspace %s", record.Workspace)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return &workspace.ID, visibility, nil
+}
+
+// freeSlug mencari slug yang belum dipakai dengan menambahkan suffix -2, -3, ...
+// Baris yang sudah di-soft delete ikut dicek karena tetap terkena unique index.
+func freeSlug(tx *gorm.DB, table interface{}, slug string) (string, error) {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", slug, i)
+		var count int64
+		if err := tx.Unscoped().Model(table).Where("slug = ?", candidate).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+	}
+}
+
+func (im *catalogImporter) resolve(kind, slug string) string {
+	if renamed, ok := im.renamed[kind][slug]; ok {
+		return renamed
+	}
+	return slug
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"service_components/internal/database"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importCatalog mengirim body mentah ke ImportCatalog dengan query tersebut.
+func importCatalog(t *testing.T, token, query string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	engine := gin.New()
+	engine.Use(middleware.Authenticate())
+	engine.POST("/import", ImportCatalog)
+
+	req := httptest.NewRequest(http.MethodPost, "/import?"+query, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+// catalogFile menyusun file NDJSON dari records.
+func catalogFile(t *testing.T, records ...CatalogRecord) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func importReport(t *testing.T, w *httptest.ResponseRecorder) ImportReport {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var response struct {
+		Data ImportReport `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	return response.Data
+}
+
+var (
+	formsRecord  = CatalogRecord{Kind: RecordCategory, Slug: "forms", Name: "Forms"}
+	buttonRecord = CatalogRecord{Kind: RecordComponent, Slug: "button", Name: "Imported Button", Category: "forms", CodeJSX: "export default function Button() { return <button/> }", CodeCSS: ".button {}"}
+)
+
+func TestImportCatalogTooLarge(t *testing.T) {
+	openTestDB(t)
+	_, token := newUser(t, "reviewer@example.com", model.UserRoleReviewer)
+
+	oversized := bytes.Repeat([]byte(" "), maxImportSize+1)
+	if w := importCatalog(t, token, "", oversized); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("ndjson: status %d, want 413", w.Code)
+	}
+
+	// Zip kecil yang isinya melebihi batas setelah didekompresi.
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	f, err := zw.Create(catalogFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(oversized)
+	zw.Close()
+	if w := importCatalog(t, token, "", archive.Bytes()); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("zip: status %d, want 413", w.Code)
+	}
+
+	if w := importCatalog(t, token, "", catalogFile(t, formsRecord)); w.Code != http.StatusOK {
+		t.Errorf("small file: status %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestImportCatalogRenameUsesNewSlug(t *testing.T) {
+	openTestDB(t)
+	reviewer, token := newUser(t, "reviewer@example.com", model.UserRoleReviewer)
+	newComponent(t, "button", reviewer.ID, nil, model.VisibilityPublic)
+
+	report := importReport(t, importCatalog(t, token, "policy=rename", catalogFile(t, formsRecord, buttonRecord)))
+	last := report.Items[len(report.Items)-1]
+	if last.Action != "renamed" || last.NewSlug != "button-2" {
+		t.Fatalf("item %+v, want renamed to button-2", last)
+	}
+
+	var renamed model.Component
+	if err := database.DB.Preload("Files").First(&renamed, "slug = ?", "button-2").Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(renamed.Files) != 2 {
+		t.Fatalf("%d files, want jsx and css", len(renamed.Files))
+	}
+	for _, file := range renamed.Files {
+		if !strings.HasPrefix(file.Path, "button-2.") {
+			t.Errorf("file %s is not named after the new slug", file.Path)
+		}
+	}
+}
+
+func TestImportCatalogOverwriteRequiresEditor(t *testing.T) {
+	openTestDB(t)
+	author, authorToken := newUser(t, "author@example.com", model.UserRoleReviewer)
+	_, reviewerToken := newUser(t, "reviewer@example.com", model.UserRoleReviewer)
+	_, adminToken := newUser(t, "admin@example.com", model.UserRoleAdmin)
+	newComponent(t, "button", author.ID, nil, model.VisibilityPublic)
+
+	tests := []struct {
+		caller string
+		token  string
+		action string
+	}{
+		{"other reviewer", reviewerToken, "error"},
+		{"author", authorToken, "updated"},
+		{"site admin", adminToken, "updated"},
+	}
+	for _, tt := range tests {
+		report := importReport(t, importCatalog(t, tt.token, "policy=overwrite&dry_run=true", catalogFile(t, formsRecord, buttonRecord)))
+		last := report.Items[len(report.Items)-1]
+		if last.Action != tt.action {
+			t.Errorf("%s: action %q (%s), want %q", tt.caller, last.Action, last.Error, tt.action)
+		}
+	}
+
+	// Dry run tidak menyimpan apa pun.
+	var component model.Component
+	database.DB.First(&component, "slug = ?", "button")
+	var categories int64
+	database.DB.Model(&model.Category{}).Count(&categories)
+	if component.Name != "button" || categories != 0 {
+		t.Errorf("dry run changed the catalog: name %q, %d categories", component.Name, categories)
+	}
+
+	report := importReport(t, importCatalog(t, reviewerToken, "policy=overwrite", catalogFile(t, formsRecord, buttonRecord)))
+	if report.Summary["created"] != 1 || report.Summary["error"] != 1 {
+		t.Errorf("summary %v, want the category created and the component rejected", report.Summary)
+	}
+	database.DB.First(&component, "slug = ?", "button")
+	if component.Name != "button" {
+		t.Errorf("component overwritten by a non-editor: name %q", component.Name)
+	}
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"service_components/internal/auth"
+	"service_components/internal/database"
+	"service_components/internal/logging"
+	"service_components/internal/model"
+	"service_components/internal/oidc"
+	"service_components/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const oidcLoginTTL = 10 * time.Minute
+
+// roleRank dipakai untuk memilih role tertinggi jika user ada di beberapa
+// group yang dipetakan.
+var roleRank = map[string]int{
+	model.UserRoleUser:     0,
+	model.UserRoleReviewer: 1,
+	model.UserRoleAdmin:    2,
+}
+
+// mappedRole menentukan role hub dari group di ID token.
+func mappedRole(groups []string) string {
+	role := model.UserRoleUser
+	for _, group := range groups {
+		mapped, ok := oidc.RoleMapping[group]
+		if _, known := roleRank[mapped]; ok && known && roleRank[mapped] > roleRank[role] {
+			role = mapped
+		}
+	}
+	return role
+}
+
+func oidcProvider(c *gin.Context) (*oidc.Provider, bool) {
+	if oidc.Default == nil {
+		utils.Error(c, http.StatusNotFound, "OIDC login is not configured")
+		return nil, false
+	}
+	return oidc.Default, true
+}
+
+// OIDCLogin mengarahkan browser ke halaman login identity provider (OIDC
+// authorization code dengan PKCE). Setelah login, provider memanggil
+// /auth/oidc/callback.
+func OIDCLogin(c *gin.Context) {
+	provider, ok := oidcProvider(c)
+	if !ok {
+		return
+	}
+
+	var secrets [3]string
+	for i := range secrets {
+		value, err := oidc.NewVerifier()
+		if err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to generate state")
+			return
+		}
+		secrets[i] = value
+	}
+	state, nonce, verifier := secrets[0], secrets[1], secrets[2]
+
+	redirect, err := provider.AuthCodeURL(c.Request.Context(), state, nonce, verifier)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("oidc login failed", "error", err)
+		utils.Error(c, http.StatusBadGateway, "Identity provider is unavailable")
+		return
+	}
+
+	now := time.Now().UTC()
+	if err := database.DB.WithContext(c.Request.Context()).Where("expires_at < ?", now).Delete(&model.OIDCLogin{}).Error; err != nil {
+		logging.FromContext(c.Request.Context()).Error("failed to clean up expired oidc logins", "error", err)
+	}
+	login := model.OIDCLogin{
+		StateHash:    auth.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(oidcLoginTTL),
+	}
+	if err := database.DB.WithContext(c.Request.Context()).Create(&login).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to start login")
+		return
+	}
+
+	c.Redirect(http.StatusFound, redirect)
+}
+
+// OIDCCallback menukar authorization code dari identity provider dengan sesi
+// hub. User dibuat otomatis saat login pertama, dan role diperbarui dari group
+// sesuai OIDC_ROLE_MAPPING.
+func OIDCCallback(c *gin.Context) {
+	provider, ok := oidcProvider(c)
+	if !ok {
+		return
+	}
+	if providerErr := c.Query("error"); providerErr != "" {
+		utils.Error(c, http.StatusBadRequest, strings.TrimSpace("Identity provider returned "+providerErr+": "+c.Query("error_description")))
+		return
+	}
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		utils.Error(c, http.StatusBadRequest, "code and state are required")
+		return
+	}
+
+	// State hanya bisa dipakai sekali.
+	var login model.OIDCLogin
+	err := database.DB.WithContext(c.Request.Context()).Where("state_hash = ? AND expires_at > ?", auth.HashToken(state), time.Now().UTC()).First(&login).Error
+	if err == nil {
+		result := database.DB.WithContext(c.Request.Context()).Delete(&login)
+		if err = result.Error; err == nil && result.RowsAffected == 0 {
+			err = gorm.ErrRecordNotFound
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusBadRequest, "Login state is invalid or expired")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to verify login state")
+		return
+	}
+
+	token, err := provider.Exchange(c.Request.Context(), code, login.CodeVerifier)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("oidc code exchange failed", "error", err)
+		utils.Error(c, http.StatusBadGateway, "Failed to exchange authorization code")
+		return
+	}
+	claims, err := provider.VerifyIDToken(c.Request.Context(), token.IDToken, login.Nonce)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Warn("oidc id token verification failed", "error", err)
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			utils.Error(c, http.StatusUnauthorized, "Invalid ID token")
+			return
+		}
+		utils.Error(c, http.StatusBadGateway, "Failed to verify ID token")
+		return
+	}
+
+	user, status, err := provisionUser(database.DB.WithContext(c.Request.Context()), claims)
+	if err != nil {
+		if status == http.StatusInternalServerError {
+			logging.FromContext(c.Request.Context()).Error("oidc provisioning failed", "error", err)
+			utils.Error(c, status, "Failed to provision user")
+			return
+		}
+		utils.Error(c, status, err.Error())
+		return
+	}
+
+	tokens, err := startSession(c, user.ID)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create session")
+		return
+	}
+
+	utils.Success(c, AuthResponse{User: *user, TokenResponse: *tokens})
+}
+
+// provisionUser mencari user dari identity OIDC, menghubungkan akun dengan
+// email terverifikasi yang sama, atau membuat user baru (just-in-time).
+func provisionUser(db *gorm.DB, claims oidc.Claims) (*model.User, int, error) {
+	issuer, subject := claims.String("iss"), claims.String("sub")
+	email := normalizeEmail(claims.String("email"))
+
+	var user model.User
+	status := http.StatusInternalServerError
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var identity model.UserIdentity
+		err := tx.Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error
+		switch {
+		case err == nil:
+			if err := tx.First(&user, "id = ?", identity.UserID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					status = http.StatusForbidden
+					return errors.New("the linked account has been deleted")
+				}
+				return err
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if email == "" {
+				status = http.StatusBadRequest
+				return errors.New("the identity provider did not return an email")
+			}
+
+			err := tx.Unscoped().Where("email = ?", email).First(&user).Error
+			switch {
+			case err == nil && user.DeletedAt.Valid:
+				status = http.StatusForbidden
+				return errors.New("the account for " + email + " has been deleted")
+			case err == nil && !claims.Bool("email_verified"):
+				// Tanpa email terverifikasi, akun yang ada tidak boleh diambil
+				// alih oleh identity baru.
+				status = http.StatusConflict
+				return errors.New("an account with email " + email + " already exists")
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				user = model.User{Email: email, Name: oidcName(claims, email), Role: model.UserRoleUser}
+				if err := tx.Create(&user).Error; err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			}
+
+			identity = model.UserIdentity{UserID: user.ID, Issuer: issuer, Subject: subject, Email: email}
+			if err := tx.Create(&identity).Error; err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		if len(oidc.RoleMapping) > 0 {
+			role := mappedRole(claims.Strings(oidc.GroupsClaim))
+			if role != user.Role {
+				if err := tx.Model(&user).Update("role", role).Error; err != nil {
+					return fmt.Errorf("update role: %w", err)
+				}
+				user.Role = role
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, status, err
+	}
+	return &user, http.StatusOK, nil
+}
+
+func oidcName(claims oidc.Claims, email string) string {
+	for _, claim := range []string{"name", "preferred_username"} {
+		if name := strings.TrimSpace(claims.String(claim)); name != "" {
+			return name
+		}
+	}
+	return email
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"service_components/internal/model"
+	"service_components/internal/oidc"
+	"testing"
+)
+
+func TestMappedRole(t *testing.T) {
+	mapping := oidc.RoleMapping
+	t.Cleanup(func() { oidc.RoleMapping = mapping })
+	oidc.RoleMapping = map[string]string{
+		"engineering":   model.UserRoleUser,
+		"design-review": model.UserRoleReviewer,
+		"platform":      model.UserRoleAdmin,
+		"root":          "superuser",
+	}
+
+	tests := []struct {
+		name   string
+		groups []string
+		want   string
+	}{
+		{"no groups", nil, model.UserRoleUser},
+		{"unmapped group", []string{"marketing"}, model.UserRoleUser},
+		{"reviewer", []string{"design-review"}, model.UserRoleReviewer},
+		{"admin", []string{"platform"}, model.UserRoleAdmin},
+		{"highest role wins", []string{"design-review", "platform", "engineering"}, model.UserRoleAdmin},
+		{"order does not matter", []string{"engineering", "design-review"}, model.UserRoleReviewer},
+		{"unknown role is ignored", []string{"root"}, model.UserRoleUser},
+		{"group names are case sensitive", []string{"Platform"}, model.UserRoleUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mappedRole(tt.groups); got != tt.want {
+				t.Errorf("mappedRole(%v) = %q, want %q", tt.groups, got, tt.want)
+			}
+		})
+	}
+}
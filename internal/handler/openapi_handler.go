@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"net/http"
+	"service_components/docs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOpenAPISpec mengembalikan spesifikasi OpenAPI 3 (docs/openapi.yaml),
+// yang juga ditampilkan Swagger UI di /swagger/index.html.
+func GetOpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/yaml", docs.OpenAPI)
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	registrySchema     = "https://ui.shadcn.com/schema/registry.json"
+	registryItemSchema = "https://ui.shadcn.com/schema/registry-item.json"
+)
+
+// registryDirs adalah folder default shadcn CLI per tipe registry, dipakai
+// sebagai target file yang tidak punya alias sendiri (CSS, asset).
+var registryDirs = map[string]string{
+	"registry:ui":        "components/ui",
+	"registry:component": "components",
+	"registry:block":     "components",
+	"registry:page":      "app",
+	"registry:hook":      "hooks",
+	"registry:lib":       "lib",
+}
+
+// registryTypes memetakan slug kategori ke tipe item registry shadcn.
+// Kategori yang tidak dikenal dianggap sebagai registry:component.
+var registryTypes = map[string]string{
+	"ui-kit":    "registry:ui",
+	"ui":        "registry:ui",
+	"dashboard": "registry:block",
+	"block":     "registry:block",
+	"page":      "registry:page",
+	"hook":      "registry:hook",
+	"lib":       "registry:lib",
+}
+
+var importPattern = regexp.MustCompile(`(?m)^\s*import\s+(?:[^'"]*?\s+from\s+)?['"]([^'"]+)['"]`)
+
+// PublicURL adalah URL publik service tanpa "/" di akhir, misal
+// "https://hub.example.com". Jika kosong, URL diturunkan dari request.
+var PublicURL string
+
+// publicURL mengembalikan URL publik service. Tanpa PublicURL, skema diambil
+// dari koneksi TLS atau header X-Forwarded-Proto dari reverse proxy.
+func publicURL(c *gin.Context) string {
+	if PublicURL != "" {
+		return PublicURL
+	}
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto, _, _ := strings.Cut(c.GetHeader("X-Forwarded-Proto"), ","); proto != "" {
+		switch proto = strings.ToLower(strings.TrimSpace(proto)); proto {
+		case "http", "https":
+			scheme = proto
+		}
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+type RegistryFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content,omitempty"`
+	Type    string `json:"type"`
+	Target  string `json:"target,omitempty"`
+}
+
+type RegistryItem struct {
+	Schema               string         `json:"$schema,omitempty"`
+	Name                 string         `json:"name"`
+	Type                 string         `json:"type"`
+	Title                string         `json:"title"`
+	Description          string         `json:"description,omitempty"`
+	Dependencies         []string       `json:"dependencies,omitempty"`
+	RegistryDependencies []string       `json:"registryDependencies,omitempty"`
+	Files                []RegistryFile `json:"files"`
+	Categories           []string       `json:"categories,omitempty"`
+	Docs                 string         `json:"docs,omitempty"`
+	Meta                 map[string]any `json:"meta,omitempty"`
+}
+
+type Registry struct {
+	Schema   string         `json:"$schema"`
+	Name     string         `json:"name"`
+	Homepage string         `json:"homepage"`
+	Items    []RegistryItem `json:"items"`
+}
+
+// GetRegistryFile melayani endpoint registry yang kompatibel dengan shadcn CLI:
+// /registry/index.json untuk daftar item dan /registry/{slug}.json untuk detail.
+func GetRegistryFile(c *gin.Context) {
+	file := c.Param("file")
+	if !strings.HasSuffix(file, ".json") {
+		utils.Error(c, http.StatusNotFound, "Registry item not found")
+		return
+	}
+
+	name := strings.TrimSuffix(file, ".json")
+	if name == "index" {
+		getRegistryIndex(c)
+		return
+	}
+	getRegistryItem(c, name)
+}
+
+func registryQuery(db *gorm.DB) *gorm.DB {
+	return db.Preload("Category").
+		Where("status = ? AND approval_status = ?", model.StatusPublished, model.ApprovalApproved).
+		Where("(workspace_id IS NULL OR visibility = ?)", model.VisibilityPublic)
+}
+
+func getRegistryIndex(c *gin.Context) {
+	var components []model.Component
+	if err := registryQuery(database.DB.WithContext(c.Request.Context())).Order("slug asc").Find(&components).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch registry")
+		return
+	}
+
+	refs := make([]*model.Component, len(components))
+	for i := range components {
+		refs[i] = &components[i]
+	}
+	if err := fillRelatedSlugs(database.DB.WithContext(c.Request.Context()), refs...); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch registry")
+		return
+	}
+	files, err := registryFiles(database.DB.WithContext(c.Request.Context()), refs)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch registry")
+		return
+	}
+
+	items := make([]RegistryItem, 0, len(components))
+	for _, component := range components {
+		item := toRegistryItem(component, files[component.ID])
+		for i := range item.Files {
+			item.Files[i].Content = ""
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, Registry{
+		Schema:   registrySchema,
+		Name:     "componenthub",
+		Homepage: publicURL(c),
+		Items:    items,
+	})
+}
+
+func getRegistryItem(c *gin.Context, slug string) {
+	var component model.Component
+	err := registryQuery(database.DB.WithContext(c.Request.Context())).Where("slug = ?", slug).First(&component).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Registry item not found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch registry item")
+		return
+	}
+
+	if err := fillRelatedSlugs(database.DB.WithContext(c.Request.Context()), &component); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch registry item")
+		return
+	}
+	files, err := registryFiles(database.DB.WithContext(c.Request.Context()), []*model.Component{&component})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch registry item")
+		return
+	}
+
+	item := toRegistryItem(component, files[component.ID])
+	item.Schema = registryItemSchema
+	setDeprecationHeaders(c, &component)
+	c.JSON(http.StatusOK, item)
+}
+
+// registryFiles mengambil file React komponen untuk registry dalam satu
+// query. Komponen lama tanpa file memakai varian atau CodeJSX/CodeCSS-nya.
+func registryFiles(db *gorm.DB, components []*model.Component) (map[uuid.UUID][]model.ComponentFile, error) {
+	ids := make([]uuid.UUID, len(components))
+	for i, component := range components {
+		ids[i] = component.ID
+	}
+
+	var rows []model.ComponentFile
+	err := db.Where("component_id IN ? AND framework = ?", ids, model.FrameworkReact).Order("path asc").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	files := map[uuid.UUID][]model.ComponentFile{}
+	for _, file := range rows {
+		files[file.ComponentID] = append(files[file.ComponentID], file)
+	}
+
+	// Komponen yang punya file, tetapi tidak untuk React, tidak punya file
+	// registry.
+	var withFiles []uuid.UUID
+	if err := db.Model(&model.ComponentFile{}).Where("component_id IN ?", ids).Distinct().Pluck("component_id", &withFiles).Error; err != nil {
+		return nil, err
+	}
+	var legacy []*model.Component
+	var legacyIDs []uuid.UUID
+	for _, component := range components {
+		if !slices.Contains(withFiles, component.ID) {
+			legacy = append(legacy, component)
+			legacyIDs = append(legacyIDs, component.ID)
+		}
+	}
+	if len(legacy) == 0 {
+		return files, nil
+	}
+
+	// Varian semua komponen lama diambil sekaligus, bukan per komponen.
+	var variants []model.ComponentVariant
+	if err := db.Where("component_id IN ?", legacyIDs).Order("framework asc").Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	byComponent := map[uuid.UUID][]model.ComponentVariant{}
+	for _, variant := range variants {
+		byComponent[variant.ComponentID] = append(byComponent[variant.ComponentID], variant)
+	}
+	for _, component := range legacy {
+		files[component.ID] = filterFramework(variantFiles(component, byComponent[component.ID]), model.FrameworkReact)
+	}
+	return files, nil
+}
+
+// registryFile memetakan file komponen ke file registry. File komponen
+// memakai tipe item, hook menjadi registry:hook dan util registry:lib, yang
+// semuanya ditempatkan shadcn CLI sesuai alias project. CSS dan asset menjadi
+// registry:file dengan target di folder yang sama (folder default shadcn).
+// Story dan test tidak ikut dipasang.
+func registryFile(itemType, slug string, file model.ComponentFile) (RegistryFile, bool) {
+	fileType := itemType
+	switch file.Role {
+	case model.FileRoleComponent:
+	case model.FileRoleHook:
+		fileType = "registry:hook"
+	case model.FileRoleUtil:
+		fileType = "registry:lib"
+	case model.FileRoleStyle, model.FileRoleAsset:
+		fileType = "registry:file"
+	default:
+		return RegistryFile{}, false
+	}
+
+	out := RegistryFile{
+		Path:    "registry/" + strings.TrimPrefix(itemType, "registry:") + "/" + slug + "/" + file.Path,
+		Content: file.Content,
+		Type:    fileType,
+	}
+	// registry:file dan registry:page wajib punya target.
+	if fileType == "registry:file" || fileType == "registry:page" {
+		out.Target = registryDirs[itemType] + "/" + slug + "/" + file.Path
+	}
+	return out, true
+}
+
+func toRegistryItem(component model.Component, componentFiles []model.ComponentFile) RegistryItem {
+	itemType, ok := registryTypes[component.Category.Slug]
+	if !ok {
+		itemType = "registry:component"
+	}
+
+	files := make([]RegistryFile, 0, len(componentFiles))
+	var code strings.Builder
+	for _, componentFile := range componentFiles {
+		file, ok := registryFile(itemType, component.Slug, componentFile)
+		if !ok {
+			continue
+		}
+		files = append(files, file)
+		if file.Type != "registry:file" {
+			code.WriteString(componentFile.Content)
+			code.WriteString("\n")
+		}
+	}
+
+	deps, registryDeps := parseImports(code.String())
+
+	item := RegistryItem{
+		Name:                 component.Slug,
+		Type:                 itemType,
+		Title:                component.Name,
+		Description:          component.Description,
+		Dependencies:         deps,
+		RegistryDependencies: registryDeps,
+		Files:                files,
+	}
+	if component.Category.Slug != "" {
+		item.Categories = []string{component.Category.Slug}
+	}
+
+	// shadcn CLI menampilkan docs setelah item dipasang, sehingga peringatan
+	// deprecation ikut terlihat oleh consumer.
+	if warning := deprecationWarning(&component); warning != "" {
+		item.Docs = warning
+		item.Meta = map[string]any{"deprecated": true, "deprecationMessage": component.DeprecationMessage}
+		if component.ReplacedBy != "" {
+			item.Meta["replacedBy"] = component.ReplacedBy
+		}
+		if component.SunsetAt != nil {
+			item.Meta["sunset"] = component.SunsetAt.Format(time.DateOnly)
+		}
+	}
+	return item
+}
+
+// parseImports membaca statement import pada kode JSX. Paket npm menjadi
+// dependencies, sedangkan import "@/components/ui/x" menjadi registryDependencies.
+// Import relatif dan react sendiri diabaikan karena sudah tersedia di project.
+func parseImports(code string) ([]string, []string) {
+	deps := map[string]bool{}
+	registryDeps := map[string]bool{}
+
+	for _, match := range importPattern.FindAllStringSubmatch(code, -1) {
+		source := match[1]
+		switch {
+		case strings.HasPrefix(source, "@/components/ui/"):
+			registryDeps[strings.TrimPrefix(source, "@/components/ui/")] = true
+		case strings.HasPrefix(source, ".") || strings.HasPrefix(source, "@/"):
+			continue
+		default:
+			pkg := packageName(source)
+			if pkg != "react" && pkg != "react-dom" {
+				deps[pkg] = true
+			}
+		}
+	}
+
+	return sortedKeys(deps), sortedKeys(registryDeps)
+}
+
+// packageName memotong subpath import, misal "lodash/debounce" -> "lodash"
+// dan "@radix-ui/react-slot/dist" -> "@radix-ui/react-slot".
+func packageName(source string) string {
+	parts := strings.Split(source, "/")
+	if strings.HasPrefix(source, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
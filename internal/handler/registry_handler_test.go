@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TestParseImports(t *testing.T) {
+	code := `import * as React from "react"
+import { createRoot } from 'react-dom/client'
+import { Slot } from "@radix-ui/react-slot"
+import { cva, type VariantProps } from "class-variance-authority"
+import debounce from "lodash/debounce"
+import { format } from "date-fns"
+import "./button.css"
+import { useToggle } from "../hooks/use-toggle"
+import { cn } from "@/lib/utils"
+import { Button } from "@/components/ui/button"
+import {
+  Dialog,
+  DialogContent,
+} from "@/components/ui/dialog"
+  import { Label } from "@/components/ui/label"
+const lazy = import("not-a-static-import")
+// import { Ignored } from "commented-out"
+export default function Example() { return null }
+`
+	deps, registryDeps := parseImports(code)
+	wantDeps := []string{"@radix-ui/react-slot", "class-variance-authority", "date-fns", "lodash"}
+	wantRegistry := []string{"button", "dialog", "label"}
+	if !reflect.DeepEqual(deps, wantDeps) {
+		t.Errorf("dependencies = %v, want %v", deps, wantDeps)
+	}
+	if !reflect.DeepEqual(registryDeps, wantRegistry) {
+		t.Errorf("registryDependencies = %v, want %v", registryDeps, wantRegistry)
+	}
+
+	if deps, registryDeps := parseImports(`import * as React from "react"`); deps != nil || registryDeps != nil {
+		t.Errorf("react only: %v, %v; want nil", deps, registryDeps)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	for source, want := range map[string]string{
+		"lodash":                    "lodash",
+		"lodash/debounce":           "lodash",
+		"@radix-ui/react-slot":      "@radix-ui/react-slot",
+		"@radix-ui/react-slot/dist": "@radix-ui/react-slot",
+		"@scope":                    "@scope",
+	} {
+		if got := packageName(source); got != want {
+			t.Errorf("packageName(%q) = %q, want %q", source, got, want)
+		}
+	}
+}
+
+func TestRegistryFile(t *testing.T) {
+	tests := []struct {
+		itemType string
+		role     string
+		path     string
+		want     RegistryFile
+		ok       bool
+	}{
+		{"registry:ui", model.FileRoleComponent, "button.tsx", RegistryFile{Path: "registry/ui/button/button.tsx", Type: "registry:ui"}, true},
+		{"registry:ui", model.FileRoleHook, "use-button.ts", RegistryFile{Path: "registry/ui/button/use-button.ts", Type: "registry:hook"}, true},
+		{"registry:ui", model.FileRoleUtil, "utils.ts", RegistryFile{Path: "registry/ui/button/utils.ts", Type: "registry:lib"}, true},
+		{"registry:ui", model.FileRoleStyle, "button.css", RegistryFile{Path: "registry/ui/button/button.css", Type: "registry:file", Target: "components/ui/button/button.css"}, true},
+		{"registry:component", model.FileRoleAsset, "icons/arrow.svg", RegistryFile{Path: "registry/component/button/icons/arrow.svg", Type: "registry:file", Target: "components/button/icons/arrow.svg"}, true},
+		{"registry:page", model.FileRoleComponent, "page.tsx", RegistryFile{Path: "registry/page/button/page.tsx", Type: "registry:page", Target: "app/button/page.tsx"}, true},
+		{"registry:block", model.FileRoleComponent, "button.tsx", RegistryFile{Path: "registry/block/button/button.tsx", Type: "registry:block"}, true},
+		{"registry:ui", model.FileRoleStory, "button.stories.tsx", RegistryFile{}, false},
+		{"registry:ui", model.FileRoleTest, "button.test.tsx", RegistryFile{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := registryFile(tt.itemType, "button", model.ComponentFile{Role: tt.role, Path: tt.path})
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("%s %s: %+v, %v; want %+v, %v", tt.itemType, tt.role, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPublicURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		tls        bool
+		forwarded  string
+		want       string
+	}{
+		{"plain http", "", false, "", "http://hub.test"},
+		{"tls", "", true, "", "https://hub.test"},
+		{"behind a proxy", "", false, "https", "https://hub.test"},
+		{"first proxy wins", "", false, "HTTPS, http", "https://hub.test"},
+		{"unknown scheme", "", false, "gopher", "http://hub.test"},
+		{"configured", "https://components.example.com", false, "http", "https://components.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := PublicURL
+			PublicURL = tt.configured
+			t.Cleanup(func() { PublicURL = previous })
+
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "http://hub.test/registry/index.json", nil)
+			if tt.tls {
+				c.Request.TLS = &tls.ConnectionState{}
+			}
+			if tt.forwarded != "" {
+				c.Request.Header.Set("X-Forwarded-Proto", tt.forwarded)
+			}
+			if got := publicURL(c); got != tt.want {
+				t.Errorf("publicURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// registryFiles memuat file semua komponen, termasuk komponen lama tanpa
+// file, dengan jumlah query tetap.
+func TestRegistryFilesBatchesLegacyComponents(t *testing.T) {
+	openTestDB(t)
+	author, _ := newUser(t, "author@example.com", model.UserRoleUser)
+
+	withFiles := newComponent(t, "card", author.ID, nil, model.VisibilityPublic)
+	vueOnly := newComponent(t, "toggle", author.ID, nil, model.VisibilityPublic)
+	database.DB.Create(&[]model.ComponentFile{
+		{ComponentID: withFiles.ID, Framework: model.FrameworkReact, Path: "card.tsx", Role: model.FileRoleComponent, Content: "<div/>"},
+		{ComponentID: withFiles.ID, Framework: model.FrameworkVue, Path: "card.vue", Role: model.FileRoleComponent, Content: "<template/>"},
+		{ComponentID: vueOnly.ID, Framework: model.FrameworkVue, Path: "toggle.vue", Role: model.FileRoleComponent, Content: "<template/>"},
+	})
+
+	components := []*model.Component{withFiles, vueOnly}
+	for _, slug := range []string{"alert", "badge", "button"} {
+		legacy := newComponent(t, slug, author.ID, nil, model.VisibilityPublic)
+		if slug == "badge" {
+			database.DB.Create(&model.ComponentVariant{ComponentID: legacy.ID, Framework: model.FrameworkReact, Code: "<span/>", Styles: ".badge {}"})
+		}
+		components = append(components, legacy)
+	}
+
+	var queries int
+	database.DB.Callback().Query().After("gorm:query").Register("test:count", func(*gorm.DB) { queries++ })
+	database.DB.Callback().Raw().After("gorm:raw").Register("test:count", func(*gorm.DB) { queries++ })
+
+	files, err := registryFiles(database.DB, components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queries != 3 {
+		t.Errorf("%d queries, want 3 regardless of the number of legacy components", queries)
+	}
+
+	paths := map[string][]string{}
+	for _, component := range components {
+		for _, file := range files[component.ID] {
+			paths[component.Slug] = append(paths[component.Slug], file.Path)
+		}
+		slices.Sort(paths[component.Slug])
+	}
+	want := map[string][]string{
+		"card":   {"card.tsx"},
+		"alert":  {"alert.jsx"},
+		"badge":  {"badge.css", "badge.jsx"},
+		"button": {"button.jsx"},
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("files = %v, want %v", paths, want)
+	}
+}
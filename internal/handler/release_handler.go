@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/semver"
+	"service_components/internal/utils"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CreateReleaseRequest struct {
+	Version   string `json:"version" binding:"required"`
+	Changelog string `json:"changelog" binding:"required"`
+}
+
+// propTypes membaca PropsDefinition menjadi peta nama prop ke tipenya.
+// Bentuk yang dikenali: {"size": {"type": "string"}}, {"size": "string"},
+// [{"name": "size", "type": "string"}] dan JSON Schema dengan "properties".
+func propTypes(raw datatypes.JSON) map[string]string {
+	props := map[string]string{}
+	if len(raw) == 0 {
+		return props
+	}
+
+	var list []map[string]interface{}
+	if json.Unmarshal(raw, &list) == nil {
+		for _, prop := range list {
+			if name, ok := prop["name"].(string); ok && name != "" {
+				props[name] = propType(prop)
+			}
+		}
+		return props
+	}
+
+	var object map[string]interface{}
+	if json.Unmarshal(raw, &object) != nil {
+		return props
+	}
+	if properties, ok := object["properties"].(map[string]interface{}); ok {
+		object = properties
+	}
+	for name, value := range object {
+		switch v := value.(type) {
+		case string:
+			props[name] = v
+		case map[string]interface{}:
+			props[name] = propType(v)
+		default:
+			props[name] = ""
+		}
+	}
+	return props
+}
+
+func propType(prop map[string]interface{}) string {
+	switch t := prop["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			types = append(types, fmt.Sprint(item))
+		}
+		sort.Strings(types)
+		return strings.Join(types, "|")
+	}
+	return ""
+}
+
+// breakingChanges membandingkan props rilis sebelumnya dengan props sekarang.
+// Prop yang dihapus atau tipenya berubah dianggap breaking.
+func breakingChanges(previous, current datatypes.JSON) []string {
+	before := propTypes(previous)
+	after := propTypes(current)
+
+	var changes []string
+	for name, oldType := range before {
+		newType, ok := after[name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("prop %q removed", name))
+		case oldType != "" && newType != oldType:
+			changes = append(changes, fmt.Sprintf("prop %q changed type from %s to %s", name, oldType, newType))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func latestRelease(db *gorm.DB, componentID interface{}) (*model.ComponentRelease, error) {
+	var release model.ComponentRelease
+	err := db.Where("component_id = ?", componentID).
+		Order("major desc, minor desc, patch desc").First(&release).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// CreateComponentRelease membekukan file dan props komponen saat ini sebagai
+// rilis semver. Perubahan props yang breaking (prop dihapus atau tipenya
+// berubah) wajib menaikkan versi major.
+func CreateComponentRelease(c *gin.Context) {
+	var input CreateReleaseRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	version, err := semver.Parse(input.Version)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if strings.TrimSpace(input.Changelog) == "" {
+		utils.Error(c, http.StatusBadRequest, "changelog is required")
+		return
+	}
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	release := model.ComponentRelease{
+		ComponentID: component.ID,
+		Version:     version.String(),
+		Major:       version.Major,
+		Minor:       version.Minor,
+		Patch:       version.Patch,
+		Changelog:   strings.TrimSpace(input.Changelog),
+	}
+
+	status := http.StatusInternalServerError
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		// Kunci baris komponen supaya dua rilis tidak dibuat bersamaan, lalu
+		// bekukan props dan file yang dibaca di bawah kunci itu.
+		var locked model.Component
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", component.ID).First(&locked).Error; err != nil {
+			return err
+		}
+		files, err := loadComponentFiles(tx, &locked, "")
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			status = http.StatusBadRequest
+			return errors.New("Component has no code to release")
+		}
+		release.PropsDefinition = locked.PropsDefinition
+		release.Files = make(datatypes.JSONSlice[model.ReleaseFile], 0, len(files))
+		for _, file := range files {
+			release.Files = append(release.Files, model.ReleaseFile{
+				Framework: file.Framework,
+				Path:      file.Path,
+				Language:  file.Language,
+				Role:      file.Role,
+				Content:   file.Content,
+			})
+		}
+
+		previous, err := latestRelease(tx, component.ID)
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			last := semver.Version{Major: previous.Major, Minor: previous.Minor, Patch: previous.Patch}
+			if version.Compare(last) <= 0 {
+				status = http.StatusConflict
+				return fmt.Errorf("version must be greater than the latest release %s", previous.Version)
+			}
+
+			changes := breakingChanges(previous.PropsDefinition, locked.PropsDefinition)
+			release.Breaking = len(changes) > 0
+			if release.Breaking && version.Major == last.Major {
+				status = http.StatusBadRequest
+				return fmt.Errorf("breaking props changes require a major version bump: %s", strings.Join(changes, "; "))
+			}
+		}
+
+		if err := tx.Create(&release).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Component{}).Where("id = ?", component.ID).
+			UpdateColumn("latest_version", release.Version).Error; err != nil {
+			return err
+		}
+		// Isi file rilis sudah tersimpan di rilis itu sendiri, tidak perlu
+		// disalin ke audit log.
+		return recordAudit(c, tx, "release.create", model.AuditEntityComponent, component.ID, component.Slug, nil, map[string]interface{}{
+			"id":        release.ID,
+			"version":   release.Version,
+			"changelog": release.Changelog,
+			"breaking":  release.Breaking,
+		})
+	})
+	if err != nil {
+		if status == http.StatusInternalServerError {
+			utils.Error(c, status, "Failed to create release")
+			return
+		}
+		utils.Error(c, status, err.Error())
+		return
+	}
+
+	utils.Created(c, release)
+}
+
+// GetComponentReleases mengembalikan rilis komponen beserta changelog, dari
+// versi terbaru, tanpa isi file dan props.
+func GetComponentReleases(c *gin.Context) {
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var releases []model.ComponentRelease
+	err := database.DB.WithContext(c.Request.Context()).Omit("files", "props_definition").Where("component_id = ?", component.ID).
+		Order("major desc, minor desc, patch desc").Find(&releases).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch releases")
+		return
+	}
+
+	utils.Success(c, releases)
+}
+
+func findRelease(c *gin.Context, component *model.Component, version string) (*model.ComponentRelease, bool) {
+	parsed, err := semver.Parse(version)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	var release model.ComponentRelease
+	err = database.DB.WithContext(c.Request.Context()).Where("component_id = ? AND version = ?", component.ID, parsed.String()).First(&release).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Release Not Found")
+		return nil, false
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find release")
+		return nil, false
+	}
+	return &release, true
+}
+
+func releaseFiles(component *model.Component, release *model.ComponentRelease) []model.ComponentFile {
+	files := make([]model.ComponentFile, 0, len(release.Files))
+	for _, file := range release.Files {
+		files = append(files, model.ComponentFile{
+			ComponentID: component.ID,
+			Framework:   file.Framework,
+			Path:        file.Path,
+			Language:    file.Language,
+			Role:        file.Role,
+			Content:     file.Content,
+		})
+	}
+	return files
+}
+
+// versionFiles mengambil file komponen, atau file rilis jika query version
+// diisi.
+func versionFiles(c *gin.Context, component *model.Component, framework string) ([]model.ComponentFile, bool) {
+	version := c.Query("version")
+	if version == "" {
+		files, err := loadComponentFiles(database.DB.WithContext(c.Request.Context()), component, framework)
+		if err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to fetch files")
+			return nil, false
+		}
+		return files, true
+	}
+
+	release, ok := findRelease(c, component, version)
+	if !ok {
+		return nil, false
+	}
+	return filterFramework(releaseFiles(component, release), framework), true
+}
+
+// applyRelease mengganti kode komponen dengan isi rilis.
+func applyRelease(component *model.Component, release *model.ComponentRelease) {
+	files := releaseFiles(component, release)
+	derived := deriveVariants(component.ID, files)
+
+	component.Version = release.Version
+	component.Files = files
+	component.PropsDefinition = release.PropsDefinition
+	component.CodeJSX, component.CodeCSS = legacyCode(files)
+	component.Variants = make([]model.ComponentVariant, 0, len(derived))
+	for _, framework := range model.Frameworks {
+		if variant, ok := derived[framework]; ok {
+			component.Variants = append(component.Variants, *variant)
+		}
+	}
+}
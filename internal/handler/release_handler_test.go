@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestPropTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want map[string]string
+	}{
+		{"empty", ``, map[string]string{}},
+		{"invalid json", `{`, map[string]string{}},
+		{"object of types", `{"size":"string","disabled":"boolean"}`, map[string]string{"size": "string", "disabled": "boolean"}},
+		{"object of definitions", `{"size":{"type":"string"},"onClick":{}}`, map[string]string{"size": "string", "onClick": ""}},
+		{"list", `[{"name":"size","type":"string"},{"name":"count","type":"number"},{"type":"string"},{"name":""}]`, map[string]string{"size": "string", "count": "number"}},
+		{"json schema", `{"type":"object","properties":{"size":{"type":"string"},"count":{"type":["number","null"]}}}`, map[string]string{"size": "string", "count": "null|number"}},
+		{"union types are sorted", `{"value":{"type":["string","number"]}}`, map[string]string{"value": "number|string"}},
+		{"unknown value", `{"size":42}`, map[string]string{"size": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := propTypes(datatypes.JSON(tt.raw)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("propTypes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakingChanges(t *testing.T) {
+	tests := []struct {
+		name              string
+		previous, current string
+		want              []string
+	}{
+		{"no props", ``, ``, nil},
+		{"unchanged", `{"size":"string"}`, `[{"name":"size","type":"string"}]`, nil},
+		{"prop added", `{"size":"string"}`, `{"size":"string","color":"string"}`, nil},
+		{"prop removed", `{"size":"string","color":"string"}`, `{"size":"string"}`, []string{`prop "color" removed`}},
+		{"type changed", `{"size":"string"}`, `{"size":"number"}`, []string{`prop "size" changed type from string to number`}},
+		{"type dropped", `{"size":"string"}`, `{"size":{}}`, []string{`prop "size" changed type from string to `}},
+		{"untyped prop gains a type", `{"size":{}}`, `{"size":"string"}`, nil},
+		{"union order is ignored", `{"v":{"type":["string","number"]}}`, `{"v":{"type":["number","string"]}}`, nil},
+		{"sorted", `{"b":"string","a":"string","c":"string"}`, `{"c":"number"}`, []string{
+			`prop "a" removed`,
+			`prop "b" removed`,
+			`prop "c" changed type from string to number`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := breakingChanges(datatypes.JSON(tt.previous), datatypes.JSON(tt.current))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("breakingChanges = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateComponentRelease(t *testing.T) {
+	openTestDB(t)
+	author, token := newUser(t, "author@example.com", model.UserRoleUser)
+	component := newComponent(t, "button", author.ID, nil, model.VisibilityPublic)
+	setProps := func(props string) {
+		t.Helper()
+		if err := database.DB.Model(component).Update("props_definition", datatypes.JSON(props)).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	release := func(version string) (int, model.ComponentRelease) {
+		t.Helper()
+		body := map[string]string{"version": version, "changelog": "Changes"}
+		w := serve(t, token, http.MethodPost, "/components/:slug/releases", "/components/button/releases", body, CreateComponentRelease)
+		var response struct {
+			Data model.ComponentRelease `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	setProps(`{"size":"string","color":"string"}`)
+	code, first := release("1.0.0")
+	if code != http.StatusCreated || len(first.Files) == 0 || string(first.PropsDefinition) != `{"size":"string","color":"string"}` {
+		t.Fatalf("first release: status %d, %+v", code, first)
+	}
+
+	// Langkah dijalankan berurutan.
+	steps := []struct {
+		name     string
+		props    string
+		version  string
+		want     int
+		breaking bool
+	}{
+		{"version must increase", `{"size":"string","color":"string"}`, "1.0.0", 409, false},
+		{"prop added", `{"size":"string","color":"string","variant":"string"}`, "1.1.0", 201, false},
+		{"prop removed without a major bump", `{"size":"string","variant":"string"}`, "1.2.0", 400, false},
+		{"prop removed with a major bump", `{"size":"string","variant":"string"}`, "2.0.0", 201, true},
+		{"type changed without a major bump", `{"size":"number","variant":"string"}`, "2.0.1", 400, false},
+		{"type changed with a major bump", `{"size":"number","variant":"string"}`, "v3.0.0", 201, true},
+	}
+	for _, step := range steps {
+		setProps(step.props)
+		code, created := release(step.version)
+		if code != step.want {
+			t.Fatalf("%s: status %d, want %d", step.name, code, step.want)
+		}
+		if code == http.StatusCreated && (created.Breaking != step.breaking || string(created.PropsDefinition) != step.props) {
+			t.Errorf("%s: breaking %v, props %s", step.name, created.Breaking, created.PropsDefinition)
+		}
+	}
+
+	var stored model.Component
+	database.DB.First(&stored, "id = ?", component.ID)
+	if stored.LatestVersion != "3.0.0" {
+		t.Errorf("latest version %q, want 3.0.0", stored.LatestVersion)
+	}
+}
@@ -0,0 +1,498 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/storage"
+	"service_components/internal/thumbnail"
+	"service_components/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const thumbnailWidth = 400
+
+var screenshotTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+type UpdateScreenshotRequest struct {
+	Theme     *string `json:"theme"`
+	Viewport  *string `json:"viewport"`
+	Caption   *string `json:"caption"`
+	IsPrimary *bool   `json:"is_primary"`
+}
+
+type ReorderScreenshotsRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required"`
+}
+
+func validateScreenshotLabels(theme, viewport string) error {
+	switch theme {
+	case "", model.ThemeLight, model.ThemeDark:
+	default:
+		return errors.New("theme must be light or dark")
+	}
+	switch viewport {
+	case "", model.ViewportMobile, model.ViewportTablet, model.ViewportDesktop:
+	default:
+		return errors.New("viewport must be mobile, tablet or desktop")
+	}
+	return nil
+}
+
+func screenshotURL(id uuid.UUID, size string) string {
+	expires := time.Now().Add(storage.SignedURLTTL)
+	target := "screenshot/" + id.String() + "/" + size
+	return "/api/v1/screenshots/" + id.String() + "/" + size + "?expires=" + strconv.FormatInt(expires.Unix(), 10) +
+		"&signature=" + storage.Sign(target, expires)
+}
+
+func withScreenshotURLs(screenshot *model.Screenshot) {
+	screenshot.URL = screenshotURL(screenshot.ID, "full")
+	screenshot.ThumbnailURL = screenshotURL(screenshot.ID, "thumb")
+}
+
+// attachScreenshots mengisi URL galeri komponen dan PrimaryImage dari
+// screenshot yang sudah di-preload.
+func attachScreenshots(component *model.Component) {
+	for i := range component.Screenshots {
+		withScreenshotURLs(&component.Screenshots[i])
+		if component.Screenshots[i].IsPrimary {
+			component.PrimaryImage = &component.Screenshots[i]
+		}
+	}
+}
+
+// attachPrimaryImages mengambil screenshot utama untuk daftar komponen dalam
+// satu query.
+func attachPrimaryImages(db *gorm.DB, components []model.Component) error {
+	if len(components) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(components))
+	for _, component := range components {
+		ids = append(ids, component.ID)
+	}
+
+	var primaries []model.Screenshot
+	if err := db.Where("component_id IN ? AND is_primary = ?", ids, true).Find(&primaries).Error; err != nil {
+		return err
+	}
+
+	byComponent := make(map[uuid.UUID]*model.Screenshot, len(primaries))
+	for i := range primaries {
+		withScreenshotURLs(&primaries[i])
+		byComponent[primaries[i].ComponentID] = &primaries[i]
+	}
+	for i := range components {
+		components[i].PrimaryImage = byComponent[components[i].ID]
+	}
+	return nil
+}
+
+func preloadScreenshots(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc")
+}
+
+// UploadComponentScreenshots menyimpan satu atau lebih screenshot (PNG, JPEG,
+// GIF; field "file") dengan label theme/viewport dan membuat thumbnail-nya.
+// Semua file disimpan dalam satu transaksi.
+func UploadComponentScreenshots(c *gin.Context) {
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, storage.MaxUploadSize*maxAssetsPerUpload+(1<<20))
+	form, err := c.MultipartForm()
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			utils.Error(c, http.StatusRequestEntityTooLarge, "Upload is too large")
+			return
+		}
+		utils.Error(c, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	theme := c.PostForm("theme")
+	viewport := c.PostForm("viewport")
+	if err := validateScreenshotLabels(theme, viewport); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	headers := form.File["file"]
+	if len(headers) == 0 {
+		utils.Error(c, http.StatusBadRequest, "At least one file is required")
+		return
+	}
+	if len(headers) > maxAssetsPerUpload {
+		utils.Error(c, http.StatusBadRequest, fmt.Sprintf("At most %d files per upload", maxAssetsPerUpload))
+		return
+	}
+
+	var position int
+	var primaries int64
+	database.DB.WithContext(c.Request.Context()).Model(&model.Screenshot{}).Where("component_id = ?", component.ID).
+		Select("COALESCE(MAX(position), -1) + 1").Scan(&position)
+	database.DB.WithContext(c.Request.Context()).Model(&model.Screenshot{}).Where("component_id = ? AND is_primary = ?", component.ID, true).Count(&primaries)
+
+	uploads := make([]screenshotUpload, 0, len(headers))
+	for _, header := range headers {
+		if header.Size > storage.MaxUploadSize {
+			utils.Error(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("%s exceeds the %d MB limit", header.Filename, storage.MaxUploadSize>>20))
+			return
+		}
+
+		f, err := header.Open()
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		data, err := io.ReadAll(io.LimitReader(f, storage.MaxUploadSize))
+		f.Close()
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		contentType := sniffContentType(header.Filename, data)
+		if !screenshotTypes[contentType] {
+			utils.Error(c, http.StatusUnsupportedMediaType, fmt.Sprintf("%s: screenshots must be PNG, JPEG or GIF", header.Filename))
+			return
+		}
+
+		width, height, _, err := thumbnail.Info(data)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, fmt.Sprintf("%s: %s", header.Filename, err.Error()))
+			return
+		}
+		thumb, thumbType, err := thumbnail.Generate(data, thumbnailWidth)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, fmt.Sprintf("%s: %s", header.Filename, err.Error()))
+			return
+		}
+
+		hash, key := storage.HashKey(data)
+		_, thumbKey := storage.HashKey(thumb)
+		uploads = append(uploads, screenshotUpload{
+			screenshot: model.Screenshot{
+				ComponentID:  component.ID,
+				Theme:        theme,
+				Viewport:     viewport,
+				Caption:      c.PostForm("caption"),
+				Position:     position + len(uploads),
+				IsPrimary:    primaries == 0 && len(uploads) == 0,
+				ContentType:  contentType,
+				Width:        width,
+				Height:       height,
+				Hash:         hash,
+				StorageKey:   key,
+				ThumbnailKey: thumbKey,
+			},
+			data:      data,
+			thumb:     thumb,
+			thumbType: thumbType,
+		})
+	}
+
+	// Sama seperti asset: semua screenshot disimpan dalam satu transaksi dan
+	// blob dari upload yang gagal dibersihkan.
+	var stored []string
+	screenshots := make([]model.Screenshot, 0, len(uploads))
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		for _, upload := range uploads {
+			screenshot := upload.screenshot
+			for _, blob := range []struct {
+				key, contentType string
+				data             []byte
+			}{
+				{screenshot.StorageKey, screenshot.ContentType, upload.data},
+				{screenshot.ThumbnailKey, upload.thumbType, upload.thumb},
+			} {
+				put, err := putBlob(tx, blob.key, blob.data, blob.contentType)
+				if err != nil {
+					return err
+				}
+				if put {
+					stored = append(stored, blob.key)
+				}
+			}
+
+			if err := tx.Create(&screenshot).Error; err != nil {
+				return err
+			}
+			if err := recordAudit(c, tx, "screenshot.create", model.AuditEntityComponent, component.ID, component.Slug, nil, &screenshot); err != nil {
+				return err
+			}
+			withScreenshotURLs(&screenshot)
+			screenshots = append(screenshots, screenshot)
+		}
+		return nil
+	})
+	if err != nil {
+		discardBlobs(c.Request.Context(), stored)
+		utils.Error(c, http.StatusInternalServerError, "Failed to save screenshots")
+		return
+	}
+
+	utils.Created(c, screenshots)
+}
+
+// screenshotUpload adalah screenshot yang sudah lolos validasi beserta isi
+// file dan thumbnail-nya.
+type screenshotUpload struct {
+	screenshot model.Screenshot
+	data       []byte
+	thumb      []byte
+	thumbType  string
+}
+
+// GetComponentScreenshots mengembalikan screenshot komponen sesuai urutan
+// galeri beserta signed URL gambar dan thumbnail.
+func GetComponentScreenshots(c *gin.Context) {
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var screenshots []model.Screenshot
+	if err := preloadScreenshots(database.DB.WithContext(c.Request.Context())).Where("component_id = ?", component.ID).Find(&screenshots).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch screenshots")
+		return
+	}
+	for i := range screenshots {
+		withScreenshotURLs(&screenshots[i])
+	}
+
+	utils.Success(c, screenshots)
+}
+
+// UpdateComponentScreenshot mengubah theme, viewport, caption atau menjadikan
+// screenshot sebagai gambar utama.
+func UpdateComponentScreenshot(c *gin.Context) {
+	var input UpdateScreenshotRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+	screenshot, ok := findScreenshot(c, component)
+	if !ok {
+		return
+	}
+
+	before := *screenshot
+	if input.Theme != nil {
+		screenshot.Theme = *input.Theme
+	}
+	if input.Viewport != nil {
+		screenshot.Viewport = *input.Viewport
+	}
+	if input.Caption != nil {
+		screenshot.Caption = *input.Caption
+	}
+	if err := validateScreenshotLabels(screenshot.Theme, screenshot.Viewport); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if input.IsPrimary != nil && *input.IsPrimary {
+			if err := tx.Model(&model.Screenshot{}).Where("component_id = ?", component.ID).Update("is_primary", false).Error; err != nil {
+				return err
+			}
+			screenshot.IsPrimary = true
+		}
+		if err := tx.Save(screenshot).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, "screenshot.update", model.AuditEntityComponent, component.ID, component.Slug, &before, screenshot)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to update screenshot")
+		return
+	}
+
+	withScreenshotURLs(screenshot)
+	utils.Success(c, screenshot)
+}
+
+// ReorderComponentScreenshots menyimpan urutan galeri dari daftar ID
+// screenshot. Daftar harus memuat setiap screenshot komponen tepat sekali.
+func ReorderComponentScreenshots(c *gin.Context) {
+	var input ReorderScreenshotsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var screenshots []model.Screenshot
+	if err := preloadScreenshots(database.DB.WithContext(c.Request.Context())).Where("component_id = ?", component.ID).Find(&screenshots).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch screenshots")
+		return
+	}
+
+	known := make(map[uuid.UUID]bool, len(screenshots))
+	previous := make([]uuid.UUID, 0, len(screenshots))
+	for _, screenshot := range screenshots {
+		known[screenshot.ID] = true
+		previous = append(previous, screenshot.ID)
+	}
+	if len(input.IDs) != len(screenshots) {
+		utils.Error(c, http.StatusBadRequest, "ids must list every screenshot of the component exactly once")
+		return
+	}
+	for _, id := range input.IDs {
+		if !known[id] {
+			utils.Error(c, http.StatusBadRequest, "ids must list every screenshot of the component exactly once")
+			return
+		}
+		delete(known, id)
+	}
+
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		for position, id := range input.IDs {
+			if err := tx.Model(&model.Screenshot{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return recordAudit(c, tx, "screenshot.reorder", model.AuditEntityComponent, component.ID, component.Slug,
+			map[string]interface{}{"order": previous}, map[string]interface{}{"order": input.IDs})
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to reorder screenshots")
+		return
+	}
+
+	GetComponentScreenshots(c)
+}
+
+// DeleteComponentScreenshot menghapus screenshot dari galeri. Jika screenshot
+// utama dihapus, screenshot pertama menjadi utama.
+func DeleteComponentScreenshot(c *gin.Context) {
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+	screenshot, ok := findScreenshot(c, component)
+	if !ok {
+		return
+	}
+
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(screenshot).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(c, tx, "screenshot.delete", model.AuditEntityComponent, component.ID, component.Slug, screenshot, nil); err != nil {
+			return err
+		}
+		if err := releaseBlobs(tx, screenshot.StorageKey, screenshot.ThumbnailKey); err != nil {
+			return err
+		}
+		if !screenshot.IsPrimary {
+			return nil
+		}
+
+		var next model.Screenshot
+		err := preloadScreenshots(tx).Where("component_id = ?", component.ID).First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&next).Update("is_primary", true).Error
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to delete screenshot")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func findScreenshot(c *gin.Context, component *model.Component) (*model.Screenshot, bool) {
+	var screenshot model.Screenshot
+	err := database.DB.WithContext(c.Request.Context()).Where("id = ? AND component_id = ?", c.Param("id"), component.ID).First(&screenshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Screenshot Not Found")
+		return nil, false
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find screenshot")
+		return nil, false
+	}
+	return &screenshot, true
+}
+
+// DownloadScreenshot mengirim gambar asli (full) atau thumbnail (thumb) jika
+// signed URL-nya valid.
+func DownloadScreenshot(c *gin.Context) {
+	id := c.Param("id")
+	size := c.Param("size")
+	if size != "full" && size != "thumb" {
+		utils.Error(c, http.StatusNotFound, "Screenshot Not Found")
+		return
+	}
+	if !storage.Verify("screenshot/"+id+"/"+size, c.Query("expires"), c.Query("signature")) {
+		utils.Error(c, http.StatusForbidden, "Invalid or expired signature")
+		return
+	}
+
+	var screenshot model.Screenshot
+	err := database.DB.WithContext(c.Request.Context()).Where("id = ?", id).First(&screenshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Screenshot Not Found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find screenshot")
+		return
+	}
+
+	key, contentType := screenshot.StorageKey, screenshot.ContentType
+	if size == "thumb" {
+		key = screenshot.ThumbnailKey
+		contentType = "image/jpeg"
+		if screenshot.ContentType != "image/jpeg" {
+			contentType = "image/png"
+		}
+	}
+
+	blob, err := storage.Store.Get(c.Request.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		utils.Error(c, http.StatusNotFound, "Screenshot content missing")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to read screenshot")
+		return
+	}
+	defer blob.Close()
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.DataFromReader(http.StatusOK, -1, contentType, blob, nil)
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const maxShareLinkTTL = 30 * 24 * time.Hour
+
+type CreateShareLinkRequest struct {
+	ExpiresInHours int `json:"expires_in_hours"`
+}
+
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateShareLink membuat link unlisted yang memberi akses baca ke komponen
+// tanpa login, berlaku sampai expires_in_hours (default 24, maksimal 720).
+// Token hanya dikembalikan sekali.
+func CreateShareLink(c *gin.Context) {
+	callerID, ok := requireCaller(c)
+	if !ok {
+		return
+	}
+
+	var input CreateShareLinkRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	ttl := 24 * time.Hour
+	if input.ExpiresInHours != 0 {
+		ttl = time.Duration(input.ExpiresInHours) * time.Hour
+	}
+	if ttl <= 0 || ttl > maxShareLinkTTL {
+		utils.Error(c, http.StatusBadRequest, "expires_in_hours must be between 1 and 720")
+		return
+	}
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	link := model.ShareLink{
+		ComponentID: component.ID,
+		TokenHash:   hashShareToken(token),
+		CreatedBy:   callerID,
+		ExpiresAt:   time.Now().Add(ttl).UTC(),
+	}
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&link).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, "share_link.create", model.AuditEntityComponent, component.ID, component.Slug, nil, &link)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create share link")
+		return
+	}
+
+	link.Token = token
+	link.URL = "/api/v1/shared/" + token
+	utils.Created(c, link)
+}
+
+// GetShareLinks mengembalikan share link komponen tanpa token-nya.
+func GetShareLinks(c *gin.Context) {
+	if _, ok := requireCaller(c); !ok {
+		return
+	}
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var links []model.ShareLink
+	if err := database.DB.WithContext(c.Request.Context()).Where("component_id = ?", component.ID).Order("created_at desc").Find(&links).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch share links")
+		return
+	}
+
+	utils.Success(c, links)
+}
+
+// RevokeShareLink mencabut share link sehingga token-nya tidak bisa dipakai
+// lagi.
+func RevokeShareLink(c *gin.Context) {
+	if _, ok := requireCaller(c); !ok {
+		return
+	}
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	result := database.DB.WithContext(c.Request.Context()).Model(&model.ShareLink{}).
+		Where("id = ? AND component_id = ? AND revoked_at IS NULL", c.Param("id"), component.ID).
+		Update("revoked_at", time.Now().UTC())
+	if result.Error != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to revoke share link")
+		return
+	}
+	if result.RowsAffected == 0 {
+		utils.Error(c, http.StatusNotFound, "Share Link Not Found")
+		return
+	}
+	logAudit(c, "share_link.revoke", model.AuditEntityComponent, component.ID, component.Slug, nil, map[string]interface{}{"id": c.Param("id")})
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSharedComponent mengembalikan detail komponen melalui token share link
+// yang masih berlaku, tanpa memperhatikan visibility.
+func GetSharedComponent(c *gin.Context) {
+	var link model.ShareLink
+	err := database.DB.WithContext(c.Request.Context()).Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", hashShareToken(c.Param("token")), time.Now().UTC()).
+		First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Share link is invalid or expired")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find share link")
+		return
+	}
+
+	var component model.Component
+	err = database.DB.WithContext(c.Request.Context()).Preload("Category").Preload("Tags").Preload("Variants").
+		Preload("Files", func(db *gorm.DB) *gorm.DB { return db.Order("framework asc, path asc") }).
+		Preload("Screenshots", preloadScreenshots).
+		Where("id = ?", link.ComponentID).First(&component).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Component Not Found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch component")
+		return
+	}
+
+	attachScreenshots(&component)
+	c.Header("X-Robots-Tag", "noindex")
+	c.Header("Cache-Control", "private, no-store")
+	utils.Success(c, component)
+}
@@ -8,23 +8,15 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type CreateTagRequest struct {
 	Name string `json:"name" binding:"required"`
 }
 
-// CreateCategory godoc
-// @Summary Membuat kategori baru
-// @Description Endpoint untuk menambah kategori baru
-// @Tags Category
-// @Accept json
-// @Produce json
-// @Param data body CreateCategoryRequest true "Data kategori"
-// @Success 201 {object} model.Category
-// @Failure 400 {object} utils.ErrorResponse
-// @Router /categories [post]
-
+// CreateTag membuat tag baru dengan slug dari nama (huruf kecil, spasi menjadi
+// "-") dan mencatatnya di audit log.
 func CreateTag(c *gin.Context) {
 	var input CreateTagRequest
 
@@ -40,7 +32,13 @@ func CreateTag(c *gin.Context) {
 		Slug: slug,
 	}
 
-	if err := database.DB.Create(&tag).Error; err != nil {
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&tag).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, "create", model.AuditEntityTag, tag.ID, tag.Slug, nil, &tag)
+	})
+	if err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Gagal menyimpan tag ke database")
 		return
 	}
@@ -48,10 +46,11 @@ func CreateTag(c *gin.Context) {
 	utils.Created(c, tag)
 }
 
+// GetAllTags mengembalikan semua tag, urut berdasarkan nama.
 func GetAllTags(c *gin.Context) {
 	var tags []model.Tag
 
-	if err := database.DB.Order("name asc").Find(&tags).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Order("name asc").Find(&tags).Error; err != nil {
 		utils.Error(c, http.StatusInternalServerError, "Gagal mengambil data tag")
 		return
 	}
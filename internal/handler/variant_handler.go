@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ComponentVariantRequest struct {
+	Framework string `json:"framework" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+	Styles    string `json:"styles"`
+}
+
+type UpsertComponentVariantRequest struct {
+	Code   string `json:"code" binding:"required"`
+	Styles string `json:"styles"`
+}
+
+// validateVariant melakukan pengecekan ringan bahwa kode cocok dengan
+// framework-nya, supaya kode Vue tidak tersimpan sebagai varian React dan
+// sebaliknya.
+func validateVariant(framework, code string) error {
+	if !slices.Contains(model.Frameworks, framework) {
+		return fmt.Errorf("framework must be one of %s", strings.Join(model.Frameworks, ", "))
+	}
+	if strings.TrimSpace(code) == "" {
+		return fmt.Errorf("%s variant: code is required", framework)
+	}
+
+	hasTemplate := strings.Contains(code, "<template")
+	hasMarkup := strings.Contains(code, "<")
+
+	switch framework {
+	case model.FrameworkReact:
+		if !hasMarkup || hasTemplate {
+			return errors.New("react variant: code must be JSX")
+		}
+	case model.FrameworkVue:
+		if !hasTemplate {
+			return errors.New("vue variant: code must be a single-file component with a <template> block")
+		}
+	case model.FrameworkSvelte:
+		if !hasMarkup || hasTemplate {
+			return errors.New("svelte variant: code must be svelte markup")
+		}
+	case model.FrameworkHTML:
+		if !hasMarkup || strings.Contains(code, "className=") {
+			return errors.New("html variant: code must be plain HTML")
+		}
+	case model.FrameworkWebComponents:
+		if !strings.Contains(code, "customElements.define") {
+			return errors.New("web-components variant: code must register a custom element with customElements.define")
+		}
+	}
+	return nil
+}
+
+// buildVariants menggabungkan code_jsx/code_css dengan daftar variants dari
+// request. code_jsx selalu menjadi varian react.
+func buildVariants(codeJSX, codeCSS string, requests []ComponentVariantRequest) ([]model.ComponentVariant, error) {
+	variants := make([]model.ComponentVariant, 0, len(requests)+1)
+	seen := map[string]bool{}
+
+	if codeJSX != "" {
+		variants = append(variants, model.ComponentVariant{Framework: model.FrameworkReact, Code: codeJSX, Styles: codeCSS})
+		seen[model.FrameworkReact] = true
+	}
+
+	for _, req := range requests {
+		if seen[req.Framework] {
+			return nil, fmt.Errorf("duplicate variant for framework %s", req.Framework)
+		}
+		seen[req.Framework] = true
+		variants = append(variants, model.ComponentVariant{Framework: req.Framework, Code: req.Code, Styles: req.Styles})
+	}
+
+	if len(variants) == 0 {
+		return nil, errors.New("code_jsx or at least one variant is required")
+	}
+	for _, variant := range variants {
+		if err := validateVariant(variant.Framework, variant.Code); err != nil {
+			return nil, err
+		}
+	}
+	return variants, nil
+}
+
+func findComponent(c *gin.Context, slug string) (*model.Component, bool) {
+	var component model.Component
+	err := database.DB.WithContext(c.Request.Context()).Scopes(visibleTo(c)).Where("slug = ?", slug).First(&component).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Component Not Found")
+		return nil, false
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find component")
+		return nil, false
+	}
+	return &component, true
+}
+
+// findEditableComponent seperti findComponent, tetapi juga memastikan
+// pemanggil boleh mengubah komponen tersebut.
+func findEditableComponent(c *gin.Context, slug string) (*model.Component, bool) {
+	component, ok := findComponent(c, slug)
+	if !ok || !requireEditor(c, component) {
+		return nil, false
+	}
+	return component, true
+}
+
+// GetComponentVariants mengembalikan implementasi komponen untuk setiap
+// framework, urut berdasarkan framework.
+func GetComponentVariants(c *gin.Context) {
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var variants []model.ComponentVariant
+	if err := database.DB.WithContext(c.Request.Context()).Where("component_id = ?", component.ID).Order("framework asc").Find(&variants).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch variants")
+		return
+	}
+
+	utils.Success(c, variants)
+}
+
+// UpsertComponentVariant menambah atau mengganti implementasi komponen untuk
+// satu framework (react, vue, svelte, html, web-components).
+func UpsertComponentVariant(c *gin.Context) {
+	framework := c.Param("framework")
+
+	var input UpsertComponentVariantRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validateVariant(framework, input.Code); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var variant model.ComponentVariant
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := ensureFiles(tx, component); err != nil {
+			return err
+		}
+
+		var previous *model.ComponentVariant
+		var existing model.ComponentVariant
+		err := tx.Where("component_id = ? AND framework = ?", component.ID, framework).First(&existing).Error
+		if err == nil {
+			previous = &existing
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var entry model.ComponentFile
+		err = tx.Where("component_id = ? AND framework = ? AND role = ?", component.ID, framework, model.FileRoleComponent).First(&entry).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			path := component.Slug + frameworkExtensions[framework]
+			entry = model.ComponentFile{ComponentID: component.ID, Framework: framework, Path: path, Language: detectLanguage(path), Role: model.FileRoleComponent}
+		} else if err != nil {
+			return err
+		}
+		entry.Content = input.Code
+		if err := tx.Save(&entry).Error; err != nil {
+			return err
+		}
+
+		var style model.ComponentFile
+		err = tx.Where("component_id = ? AND framework = ? AND role = ?", component.ID, framework, model.FileRoleStyle).Order("path asc").First(&style).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if input.Styles != "" {
+				style = model.ComponentFile{ComponentID: component.ID, Framework: framework, Path: component.Slug + ".css", Language: "css", Role: model.FileRoleStyle, Content: input.Styles}
+				err = tx.Create(&style).Error
+			} else {
+				err = nil
+			}
+		case err != nil:
+		case input.Styles == "":
+			err = tx.Delete(&style).Error
+		default:
+			style.Content = input.Styles
+			err = tx.Save(&style).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := syncVariants(tx, component.ID); err != nil {
+			return err
+		}
+		if err := tx.Where("component_id = ? AND framework = ?", component.ID, framework).First(&variant).Error; err != nil {
+			return err
+		}
+		action := "variant.update"
+		if previous == nil {
+			action = "variant.create"
+		}
+		return recordAudit(c, tx, action, model.AuditEntityComponent, component.ID, component.Slug, previous, &variant)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to save variant")
+		return
+	}
+
+	utils.Success(c, variant)
+}
+
+// DeleteComponentVariant menghapus implementasi komponen untuk satu framework.
+func DeleteComponentVariant(c *gin.Context) {
+	framework := c.Param("framework")
+
+	component, ok := findEditableComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	var others int64
+	if err := database.DB.WithContext(c.Request.Context()).Model(&model.ComponentVariant{}).
+		Where("component_id = ? AND framework <> ?", component.ID, framework).Count(&others).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to delete variant")
+		return
+	}
+	if others == 0 {
+		utils.Error(c, http.StatusBadRequest, "A component must keep at least one variant")
+		return
+	}
+
+	var rows int64
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := ensureFiles(tx, component); err != nil {
+			return err
+		}
+
+		var variant model.ComponentVariant
+		if err := tx.Where("component_id = ? AND framework = ?", component.ID, framework).Limit(1).Find(&variant).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("component_id = ? AND framework = ?", component.ID, framework).Delete(&model.ComponentFile{})
+		if result.Error != nil {
+			return result.Error
+		}
+		rows = result.RowsAffected
+		if err := syncVariants(tx, component.ID); err != nil {
+			return err
+		}
+		if rows == 0 {
+			return nil
+		}
+		return recordAudit(c, tx, "variant.delete", model.AuditEntityComponent, component.ID, component.Slug, &variant, nil)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to delete variant")
+		return
+	}
+	if rows == 0 {
+		utils.Error(c, http.StatusNotFound, "Variant Not Found")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
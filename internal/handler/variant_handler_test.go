@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"service_components/internal/model"
+	"strings"
+	"testing"
+)
+
+func TestValidateVariant(t *testing.T) {
+	tests := []struct {
+		framework string
+		code      string
+		err       string
+	}{
+		{model.FrameworkReact, "export default function Button() { return <button className=\"btn\"/> }", ""},
+		{model.FrameworkReact, "export default function Button() { return null }", "must be JSX"},
+		{model.FrameworkReact, "<template><button/></template>", "must be JSX"},
+		{model.FrameworkVue, "<template><button>Click</button></template>\n<script setup></script>", ""},
+		{model.FrameworkVue, "<button>Click</button>", "<template> block"},
+		{model.FrameworkSvelte, "<script>let count = 0</script>\n<button on:click={() => count++}>{count}</button>", ""},
+		{model.FrameworkSvelte, "let count = 0", "svelte markup"},
+		{model.FrameworkSvelte, "<template><button/></template>", "svelte markup"},
+		{model.FrameworkHTML, "<button class=\"btn\">Click</button>", ""},
+		{model.FrameworkHTML, "<button className=\"btn\">Click</button>", "plain HTML"},
+		{model.FrameworkHTML, "Click", "plain HTML"},
+		{model.FrameworkWebComponents, "class XButton extends HTMLElement {}\ncustomElements.define('x-button', XButton)", ""},
+		{model.FrameworkWebComponents, "class XButton extends HTMLElement {}", "customElements.define"},
+		{model.FrameworkReact, "  \n\t", "code is required"},
+		{"angular", "<button/>", "framework must be one of"},
+		{"", "<button/>", "framework must be one of"},
+	}
+	for _, tt := range tests {
+		err := validateVariant(tt.framework, tt.code)
+		if tt.err == "" && err != nil {
+			t.Errorf("%s %q: unexpected error %v", tt.framework, tt.code, err)
+		}
+		if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%s %q: error %v, want %q", tt.framework, tt.code, err, tt.err)
+		}
+	}
+}
+
+func TestBuildVariants(t *testing.T) {
+	jsx := "export default function Button() { return <button/> }"
+	vue := ComponentVariantRequest{Framework: model.FrameworkVue, Code: "<template><button/></template>"}
+
+	variants, err := buildVariants(jsx, ".btn {}", []ComponentVariantRequest{vue})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(variants) != 2 || variants[0].Framework != model.FrameworkReact || variants[0].Styles != ".btn {}" || variants[1].Framework != model.FrameworkVue {
+		t.Errorf("variants = %+v, want react with styles then vue", variants)
+	}
+
+	tests := []struct {
+		name     string
+		codeJSX  string
+		requests []ComponentVariantRequest
+		err      string
+	}{
+		{"nothing", "", nil, "code_jsx or at least one variant is required"},
+		{"react twice", jsx, []ComponentVariantRequest{{Framework: model.FrameworkReact, Code: jsx}}, "duplicate variant for framework react"},
+		{"vue twice", "", []ComponentVariantRequest{vue, vue}, "duplicate variant for framework vue"},
+		{"invalid variant", jsx, []ComponentVariantRequest{{Framework: model.FrameworkVue, Code: "<button/>"}}, "<template> block"},
+	}
+	for _, tt := range tests {
+		if _, err := buildVariants(tt.codeJSX, "", tt.requests); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: error %v, want %q", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"service_components/internal/auth"
+	"service_components/internal/database"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"service_components/internal/webhook"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required"`
+	Secret string   `json:"secret"`
+}
+
+type UpdateWebhookRequest struct {
+	URL    *string  `json:"url"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+// validateWebhook memeriksa URL dan daftar event, lalu mengembalikan event
+// tanpa duplikat.
+func validateWebhook(rawURL string, events []string) ([]string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("url must be an absolute http or https URL")
+	}
+	if len(events) == 0 {
+		return nil, errors.New("events is required")
+	}
+	unique := make([]string, 0, len(events))
+	for _, event := range events {
+		if !webhook.ValidEvent(event) {
+			return nil, errors.New("unknown event " + event + ", expected one of " + strings.Join(model.WebhookEvents, ", ") + " or *")
+		}
+		if !slices.Contains(unique, event) {
+			unique = append(unique, event)
+		}
+	}
+	return unique, nil
+}
+
+func findWebhook(c *gin.Context) (*model.Webhook, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusNotFound, "Webhook Not Found")
+		return nil, false
+	}
+
+	var hook model.Webhook
+	err = database.DB.WithContext(c.Request.Context()).Where("id = ?", id).First(&hook).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Webhook Not Found")
+		return nil, false
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find webhook")
+		return nil, false
+	}
+	return &hook, true
+}
+
+// CreateWebhook mendaftarkan URL yang menerima event komponen
+// (component.created, component.updated, component.published,
+// component.approved, component.rejected, component.deprecated,
+// component.deleted atau * untuk semua). Payload ditandatangani HMAC-SHA256;
+// secret dibuat otomatis jika kosong dan hanya dikembalikan sekali. Admin
+// saja.
+func CreateWebhook(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	callerID, _ := middleware.CallerID(c)
+
+	var input CreateWebhookRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	events, err := validateWebhook(input.URL, input.Events)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		token, err := auth.NewToken()
+		if err != nil {
+			utils.Error(c, http.StatusInternalServerError, "Failed to generate secret")
+			return
+		}
+		secret = webhook.SecretPrefix + token
+	} else if len(secret) < 16 {
+		utils.Error(c, http.StatusBadRequest, "secret must be at least 16 characters")
+		return
+	}
+
+	hook := model.Webhook{URL: input.URL, Events: events, Secret: secret, Active: true, CreatedBy: callerID}
+	if err := database.DB.WithContext(c.Request.Context()).Create(&hook).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create webhook")
+		return
+	}
+
+	hook.PlainSecret = secret
+	utils.Created(c, hook)
+}
+
+// GetWebhooks mengembalikan semua webhook beserta status dan jumlah kegagalan
+// berturut-turut. Admin saja.
+func GetWebhooks(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	hooks := []model.Webhook{}
+	if err := database.DB.WithContext(c.Request.Context()).Order("created_at desc").Find(&hooks).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch webhooks")
+		return
+	}
+
+	utils.Success(c, hooks)
+}
+
+// GetWebhook mengembalikan satu webhook. Admin saja.
+func GetWebhook(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	hook, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	utils.Success(c, hook)
+}
+
+// UpdateWebhook mengubah URL, event atau status aktif webhook. Mengaktifkan
+// kembali webhook yang dinonaktifkan otomatis mereset jumlah kegagalan. Admin
+// saja.
+func UpdateWebhook(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var input UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hook, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	if input.URL != nil {
+		hook.URL = *input.URL
+	}
+	events := []string(hook.Events)
+	if input.Events != nil {
+		events = input.Events
+	}
+	events, err := validateWebhook(hook.URL, events)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	hook.Events = events
+
+	if input.Active != nil {
+		if *input.Active && !hook.Active {
+			hook.FailureCount = 0
+			hook.DisabledAt = nil
+			hook.LastError = ""
+		}
+		hook.Active = *input.Active
+	}
+
+	if err := database.DB.WithContext(c.Request.Context()).Save(hook).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to update webhook")
+		return
+	}
+
+	utils.Success(c, hook)
+}
+
+// DeleteWebhook menghapus webhook beserta log delivery-nya. Admin saja.
+func DeleteWebhook(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	hook, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", hook.ID).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(hook).Error
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to delete webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries mengembalikan riwayat pengiriman webhook (terbaru dulu)
+// beserta status response dan error. Admin saja.
+func GetWebhookDeliveries(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	hook, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	query := database.DB.WithContext(c.Request.Context()).Where("webhook_id = ?", hook.ID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	deliveries := []model.WebhookDelivery{}
+	err := query.Order("created_at desc").Offset((page - 1) * limit).Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch deliveries")
+		return
+	}
+
+	utils.Success(c, deliveries)
+}
+
+// ReplayWebhookDelivery mengantrikan ulang payload delivery sebagai delivery
+// baru dengan event ID yang sama. Webhook harus aktif. Admin saja.
+func ReplayWebhookDelivery(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	hook, ok := findWebhook(c)
+	if !ok {
+		return
+	}
+	if !hook.Active {
+		utils.Error(c, http.StatusConflict, "Webhook is disabled")
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		utils.Error(c, http.StatusNotFound, "Delivery Not Found")
+		return
+	}
+	var delivery model.WebhookDelivery
+	err = database.DB.WithContext(c.Request.Context()).Where("id = ? AND webhook_id = ?", deliveryID, hook.ID).First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Delivery Not Found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find delivery")
+		return
+	}
+
+	replay, err := webhook.Replay(database.DB.WithContext(c.Request.Context()), &delivery)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to replay delivery")
+		return
+	}
+
+	utils.Accepted(c, replay)
+}
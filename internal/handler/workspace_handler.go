@@ -0,0 +1,540 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"service_components/internal/database"
+	"service_components/internal/logging"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+	"service_components/internal/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	scopeKey    = "workspace_scope"
+	userRoleKey = "user_role"
+)
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type AddWorkspaceMemberRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	Role   string    `json:"role"`
+}
+
+type UpdateVisibilityRequest struct {
+	Visibility string `json:"visibility" binding:"required"`
+	Workspace  string `json:"workspace"`
+}
+
+// callerScope berisi workspace yang bisa diakses pemanggil.
+type callerScope struct {
+	UserID        uuid.UUID
+	Authenticated bool
+	Member        []uuid.UUID
+	Admin         []uuid.UUID
+	roles         map[uuid.UUID]string
+	// workspaceKey true untuk API key workspace: komponen private pembuat key
+	// di luar workspace itu tidak ikut terlihat.
+	workspaceKey bool
+}
+
+func (s *callerScope) role(workspaceID uuid.UUID) string {
+	return s.roles[workspaceID]
+}
+
+// scopeFor mengambil membership pemanggil sekali per request.
+func scopeFor(c *gin.Context) *callerScope {
+	if cached, ok := c.Get(scopeKey); ok {
+		return cached.(*callerScope)
+	}
+	scope := loadScope(c)
+	c.Set(scopeKey, scope)
+	return scope
+}
+
+// loadScope membaca membership pemanggil dari database tanpa cache request.
+// Jika gagal, pemanggil dianggap bukan member workspace mana pun.
+func loadScope(c *gin.Context) *callerScope {
+	scope := &callerScope{roles: map[uuid.UUID]string{}}
+	if id, ok := middleware.CallerID(c); ok {
+		scope.UserID = id
+		scope.Authenticated = true
+
+		// API key workspace hanya melihat workspace-nya, sebagai member biasa.
+		var members []model.WorkspaceMember
+		if grant, ok := middleware.APIKey(c); ok && grant.WorkspaceID != nil {
+			scope.workspaceKey = true
+			members = []model.WorkspaceMember{{WorkspaceID: *grant.WorkspaceID, UserID: id, Role: model.WorkspaceRoleMember}}
+		} else if err := database.DB.WithContext(c.Request.Context()).Where("user_id = ?", id).Find(&members).Error; err != nil {
+			logging.FromContext(c.Request.Context()).Error("failed to load workspaces", "user_id", id, "error", err)
+		}
+		for _, member := range members {
+			scope.roles[member.WorkspaceID] = member.Role
+			scope.Member = append(scope.Member, member.WorkspaceID)
+			if member.Role == model.WorkspaceRoleOwner || member.Role == model.WorkspaceRoleAdmin {
+				scope.Admin = append(scope.Admin, member.WorkspaceID)
+			}
+		}
+	}
+	return scope
+}
+
+// visibleTo membatasi query komponen ke yang boleh dilihat pemanggil:
+// public untuk semua orang, internal untuk member workspace, dan private untuk
+// pembuatnya serta owner/admin workspace.
+func visibleTo(c *gin.Context) func(*gorm.DB) *gorm.DB {
+	scope := scopeFor(c)
+	return func(db *gorm.DB) *gorm.DB {
+		if !scope.Authenticated {
+			return db.Where("(components.workspace_id IS NULL OR components.visibility = ?)", model.VisibilityPublic)
+		}
+		own := "components.user_id = ?"
+		ownArgs := []interface{}{scope.UserID}
+		if scope.workspaceKey {
+			own = "(components.user_id = ? AND components.workspace_id IN ?)"
+			ownArgs = append(ownArgs, append(scope.Member, uuid.Nil))
+		}
+		args := []interface{}{model.VisibilityPublic, model.VisibilityInternal, append(scope.Member, uuid.Nil), model.VisibilityPrivate}
+		args = append(append(args, ownArgs...), append(scope.Admin, uuid.Nil))
+		return db.Where("(components.workspace_id IS NULL OR components.visibility = ?"+
+			" OR (components.visibility = ? AND components.workspace_id IN ?)"+
+			" OR (components.visibility = ? AND ("+own+" OR components.workspace_id IN ?)))", args...)
+	}
+}
+
+// resolveWorkspace memvalidasi workspace dan visibility untuk komponen baru
+// atau yang dipindahkan. Pemanggil harus menjadi member workspace tujuan.
+func resolveWorkspace(c *gin.Context, slug, visibility string) (*uuid.UUID, string, bool) {
+	if visibility == "" {
+		visibility = model.VisibilityPublic
+	}
+	if !validVisibility(visibility) {
+		utils.Error(c, http.StatusBadRequest, "visibility must be public, internal or private")
+		return nil, "", false
+	}
+
+	if slug == "" {
+		if visibility != model.VisibilityPublic {
+			utils.Error(c, http.StatusBadRequest, "internal and private components need a workspace")
+			return nil, "", false
+		}
+		return nil, visibility, true
+	}
+
+	var workspace model.Workspace
+	err := database.DB.WithContext(c.Request.Context()).Where("slug = ?", slug).First(&workspace).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && scopeFor(c).role(workspace.ID) == "") {
+		utils.Error(c, http.StatusForbidden, "You are not a member of workspace "+slug)
+		return nil, "", false
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find workspace")
+		return nil, "", false
+	}
+	return &workspace.ID, visibility, true
+}
+
+func validVisibility(visibility string) bool {
+	switch visibility {
+	case model.VisibilityPublic, model.VisibilityInternal, model.VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+func requireCaller(c *gin.Context) (uuid.UUID, bool) {
+	id, ok := middleware.CallerID(c)
+	if !ok {
+		utils.Error(c, http.StatusUnauthorized, "Authentication required")
+	}
+	return id, ok
+}
+
+// userRole mengambil role user pemanggil sekali per request; untuk API key
+// dipakai role pemilik key, dan request anonim tidak punya role.
+func userRole(c *gin.Context) (string, bool) {
+	role, err := callerRole(c)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find user")
+		return "", false
+	}
+	return role, true
+}
+
+// callerRole adalah userRole tanpa menulis response.
+func callerRole(c *gin.Context) (string, error) {
+	if cached, ok := c.Get(userRoleKey); ok {
+		return cached.(string), nil
+	}
+
+	var user model.User
+	if id, ok := middleware.CallerID(c); ok {
+		err := database.DB.WithContext(c.Request.Context()).Select("role").Where("id = ?", id).First(&user).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err
+		}
+	}
+
+	c.Set(userRoleKey, user.Role)
+	return user.Role, nil
+}
+
+// requireReviewer hanya meloloskan user dengan role reviewer atau admin.
+func requireReviewer(c *gin.Context) bool {
+	if _, ok := requireCaller(c); !ok {
+		return false
+	}
+	role, ok := userRole(c)
+	if !ok {
+		return false
+	}
+	if role != model.UserRoleReviewer && role != model.UserRoleAdmin {
+		utils.Error(c, http.StatusForbidden, "Reviewer or admin role required")
+		return false
+	}
+	return true
+}
+
+// requireEditor hanya meloloskan pembuat komponen, owner/admin workspace
+// komponen, dan user dengan role admin. API key workspace hanya bisa mengubah
+// komponen di workspace-nya.
+func requireEditor(c *gin.Context, component *model.Component) bool {
+	if !scopeFor(c).Authenticated {
+		utils.Error(c, http.StatusUnauthorized, "Authentication required")
+		return false
+	}
+	allowed, err := canEdit(c, component)
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find user")
+		return false
+	}
+	if !allowed {
+		utils.Error(c, http.StatusForbidden, "Only the author or workspace admins can change this component")
+		return false
+	}
+	return true
+}
+
+// canEdit adalah aturan requireEditor tanpa menulis response, untuk
+// pemeriksaan per item seperti import.
+func canEdit(c *gin.Context, component *model.Component) (bool, error) {
+	scope := scopeFor(c)
+	if !scope.Authenticated {
+		return false, nil
+	}
+
+	inWorkspace := !scope.workspaceKey
+	if component.WorkspaceID != nil {
+		role := scope.role(*component.WorkspaceID)
+		if role == model.WorkspaceRoleOwner || role == model.WorkspaceRoleAdmin {
+			return true, nil
+		}
+		inWorkspace = role != ""
+	}
+	if component.UserID == scope.UserID && inWorkspace {
+		return true, nil
+	}
+	if !scope.workspaceKey {
+		role, err := callerRole(c)
+		if err != nil {
+			return false, err
+		}
+		if role == model.UserRoleAdmin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// findWorkspace mengambil workspace yang pemanggilnya adalah member.
+func findWorkspace(c *gin.Context) (*model.Workspace, string, bool) {
+	var workspace model.Workspace
+	err := database.DB.WithContext(c.Request.Context()).Where("slug = ?", c.Param("workspace")).First(&workspace).Error
+	role := ""
+	if err == nil {
+		role = scopeFor(c).role(workspace.ID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && role == "") {
+		utils.Error(c, http.StatusNotFound, "Workspace Not Found")
+		return nil, "", false
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find workspace")
+		return nil, "", false
+	}
+	return &workspace, role, true
+}
+
+// CreateWorkspace membuat workspace baru dengan pemanggil sebagai owner.
+func CreateWorkspace(c *gin.Context) {
+	callerID, ok := requireCaller(c)
+	if !ok {
+		return
+	}
+
+	var input CreateWorkspaceRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workspace := model.Workspace{
+		Name: strings.TrimSpace(input.Name),
+		Slug: strings.ToLower(strings.ReplaceAll(strings.TrimSpace(input.Name), " ", "-")),
+	}
+	var taken int64
+	err := database.DB.WithContext(c.Request.Context()).Unscoped().Model(&model.Workspace{}).Where("slug = ?", workspace.Slug).Count(&taken).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create workspace")
+		return
+	}
+	if taken > 0 {
+		utils.Error(c, http.StatusConflict, "Workspace already exists")
+		return
+	}
+
+	err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&workspace).Error; err != nil {
+			return err
+		}
+		owner := model.WorkspaceMember{WorkspaceID: workspace.ID, UserID: callerID, Role: model.WorkspaceRoleOwner}
+		if err := tx.Create(&owner).Error; err != nil {
+			return err
+		}
+		workspace.Members = []model.WorkspaceMember{owner}
+		return nil
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to create workspace")
+		return
+	}
+
+	utils.Created(c, workspace)
+}
+
+// GetMyWorkspaces mengembalikan workspace tempat pemanggil menjadi member.
+func GetMyWorkspaces(c *gin.Context) {
+	if _, ok := requireCaller(c); !ok {
+		return
+	}
+
+	var workspaces []model.Workspace
+	err := database.DB.WithContext(c.Request.Context()).Where("id IN ?", append(scopeFor(c).Member, uuid.Nil)).Order("name asc").Find(&workspaces).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch workspaces")
+		return
+	}
+
+	utils.Success(c, workspaces)
+}
+
+// GetWorkspace mengembalikan workspace beserta member-nya. Hanya untuk member
+// workspace.
+func GetWorkspace(c *gin.Context) {
+	if _, ok := requireCaller(c); !ok {
+		return
+	}
+	workspace, _, ok := findWorkspace(c)
+	if !ok {
+		return
+	}
+
+	if err := database.DB.WithContext(c.Request.Context()).Where("workspace_id = ?", workspace.ID).Order("created_at asc").Find(&workspace.Members).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to fetch members")
+		return
+	}
+
+	utils.Success(c, workspace)
+}
+
+// AddWorkspaceMember menambah member atau mengubah role-nya (owner, admin,
+// member). Hanya owner/admin, dan owner hanya bisa ditambah atau diubah oleh
+// owner.
+func AddWorkspaceMember(c *gin.Context) {
+	if _, ok := requireCaller(c); !ok {
+		return
+	}
+
+	var input AddWorkspaceMemberRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.Role == "" {
+		input.Role = model.WorkspaceRoleMember
+	}
+	switch input.Role {
+	case model.WorkspaceRoleOwner, model.WorkspaceRoleAdmin, model.WorkspaceRoleMember:
+	default:
+		utils.Error(c, http.StatusBadRequest, "role must be owner, admin or member")
+		return
+	}
+
+	workspace, role, ok := findWorkspace(c)
+	if !ok {
+		return
+	}
+	if role != model.WorkspaceRoleOwner && role != model.WorkspaceRoleAdmin {
+		utils.Error(c, http.StatusForbidden, "Only owners and admins can manage members")
+		return
+	}
+	if input.Role == model.WorkspaceRoleOwner && role != model.WorkspaceRoleOwner {
+		utils.Error(c, http.StatusForbidden, "Only owners can add owners")
+		return
+	}
+
+	var member model.WorkspaceMember
+	err := database.DB.WithContext(c.Request.Context()).Where("workspace_id = ? AND user_id = ?", workspace.ID, input.UserID).First(&member).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find member")
+		return
+	}
+	if member.Role == model.WorkspaceRoleOwner && role != model.WorkspaceRoleOwner {
+		utils.Error(c, http.StatusForbidden, "Only owners can change owners")
+		return
+	}
+	if member.Role == model.WorkspaceRoleOwner && input.Role != model.WorkspaceRoleOwner {
+		if !ownerRemains(c, workspace.ID, member.UserID) {
+			return
+		}
+	}
+
+	member.WorkspaceID = workspace.ID
+	member.UserID = input.UserID
+	member.Role = input.Role
+	if err := database.DB.WithContext(c.Request.Context()).Save(&member).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to save member")
+		return
+	}
+
+	utils.Success(c, member)
+}
+
+// RemoveWorkspaceMember mengeluarkan member dari workspace. Boleh dilakukan
+// owner/admin atau member itu sendiri; owner hanya bisa dikeluarkan oleh
+// owner, dan owner terakhir tidak bisa dihapus.
+func RemoveWorkspaceMember(c *gin.Context) {
+	callerID, ok := requireCaller(c)
+	if !ok {
+		return
+	}
+	workspace, role, ok := findWorkspace(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		utils.Error(c, http.StatusNotFound, "Member Not Found")
+		return
+	}
+	if userID != callerID && role != model.WorkspaceRoleOwner && role != model.WorkspaceRoleAdmin {
+		utils.Error(c, http.StatusForbidden, "Only owners and admins can manage members")
+		return
+	}
+
+	var member model.WorkspaceMember
+	err = database.DB.WithContext(c.Request.Context()).Where("workspace_id = ? AND user_id = ?", workspace.ID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Error(c, http.StatusNotFound, "Member Not Found")
+		return
+	}
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to find member")
+		return
+	}
+	if member.Role == model.WorkspaceRoleOwner && role != model.WorkspaceRoleOwner {
+		utils.Error(c, http.StatusForbidden, "Only owners can remove owners")
+		return
+	}
+	if member.Role == model.WorkspaceRoleOwner && !ownerRemains(c, workspace.ID, userID) {
+		return
+	}
+
+	if err := database.DB.WithContext(c.Request.Context()).Delete(&member).Error; err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ownerRemains memastikan workspace tetap punya owner selain userID.
+func ownerRemains(c *gin.Context, workspaceID, userID uuid.UUID) bool {
+	var owners int64
+	err := database.DB.WithContext(c.Request.Context()).Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ? AND user_id <> ?", workspaceID, model.WorkspaceRoleOwner, userID).
+		Count(&owners).Error
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to count owners")
+		return false
+	}
+	if owners == 0 {
+		utils.Error(c, http.StatusConflict, "A workspace needs at least one owner")
+		return false
+	}
+	return true
+}
+
+// UpdateComponentVisibility mengubah visibility komponen (public, internal,
+// private) dan/atau memindahkannya ke workspace lain. Hanya pembuat komponen
+// atau owner/admin workspace.
+func UpdateComponentVisibility(c *gin.Context) {
+	if _, ok := requireCaller(c); !ok {
+		return
+	}
+
+	var input UpdateVisibilityRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	component, ok := findComponent(c, c.Param("slug"))
+	if !ok {
+		return
+	}
+
+	if !requireEditor(c, component) {
+		return
+	}
+
+	// Tanpa workspace di body, komponen tetap di workspace-nya sekarang.
+	workspaceID, visibility := component.WorkspaceID, input.Visibility
+	if input.Workspace != "" || workspaceID == nil {
+		workspaceID, visibility, ok = resolveWorkspace(c, input.Workspace, input.Visibility)
+		if !ok {
+			return
+		}
+	} else if !validVisibility(visibility) {
+		utils.Error(c, http.StatusBadRequest, "visibility must be public, internal or private")
+		return
+	}
+
+	before := *component
+	err := database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(component).Updates(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"visibility":   visibility,
+		}).Error
+		if err != nil {
+			return err
+		}
+		component.WorkspaceID = workspaceID
+		component.Visibility = visibility
+		return recordComponentChange(c, tx, "update", &before, component)
+	})
+	if err != nil {
+		utils.Error(c, http.StatusInternalServerError, "Failed to update visibility")
+		return
+	}
+
+	utils.Success(c, component)
+}
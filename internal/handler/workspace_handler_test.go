@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"net/http"
+	"reflect"
+	"service_components/internal/database"
+	"service_components/internal/model"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// accessFixture berisi pemanggil dan komponen untuk test aturan akses.
+//
+// Workspace acme: owner, admin, member dan author. Workspace beta: author.
+// Komponen: open (tanpa workspace, milik outsider), lalu pub, int dan priv di
+// acme serta beta-int di beta, semuanya milik author.
+type accessFixture struct {
+	acme, beta model.Workspace
+	users      map[string]model.User
+	tokens     map[string]string
+}
+
+// accessCallers diurutkan supaya pesan error test stabil. "user key" adalah
+// API key author tanpa workspace, "workspace key" API key author untuk beta.
+var accessCallers = []string{"anonymous", "outsider", "member", "author", "admin", "owner", "site admin", "user key", "workspace key"}
+
+func newAccessFixture(t *testing.T) *accessFixture {
+	t.Helper()
+	openTestDB(t)
+	f := &accessFixture{users: map[string]model.User{}, tokens: map[string]string{"anonymous": ""}}
+	for _, name := range []string{"outsider", "member", "author", "admin", "owner", "site admin"} {
+		role := model.UserRoleUser
+		if name == "site admin" {
+			role = model.UserRoleAdmin
+		}
+		f.users[name], f.tokens[name] = newUser(t, name+"@example.com", role)
+	}
+
+	f.acme = newWorkspace(t, "acme", map[uuid.UUID]string{
+		f.users["owner"].ID:  model.WorkspaceRoleOwner,
+		f.users["admin"].ID:  model.WorkspaceRoleAdmin,
+		f.users["member"].ID: model.WorkspaceRoleMember,
+		f.users["author"].ID: model.WorkspaceRoleMember,
+	})
+	f.beta = newWorkspace(t, "beta", map[uuid.UUID]string{f.users["author"].ID: model.WorkspaceRoleMember})
+	f.tokens["user key"] = newAPIKey(t, f.users["author"].ID, nil)
+	f.tokens["workspace key"] = newAPIKey(t, f.users["author"].ID, &f.beta.ID)
+
+	author := f.users["author"].ID
+	newComponent(t, "open", f.users["outsider"].ID, nil, model.VisibilityPublic)
+	newComponent(t, "pub", author, &f.acme, model.VisibilityPublic)
+	newComponent(t, "int", author, &f.acme, model.VisibilityInternal)
+	newComponent(t, "priv", author, &f.acme, model.VisibilityPrivate)
+	newComponent(t, "beta-int", author, &f.beta, model.VisibilityInternal)
+	return f
+}
+
+func TestVisibility(t *testing.T) {
+	f := newAccessFixture(t)
+
+	want := map[string][]string{
+		"anonymous":     {"open", "pub"},
+		"outsider":      {"open", "pub"},
+		"member":        {"int", "open", "pub"},
+		"author":        {"beta-int", "int", "open", "priv", "pub"},
+		"admin":         {"int", "open", "priv", "pub"},
+		"owner":         {"int", "open", "priv", "pub"},
+		"site admin":    {"open", "pub"},
+		"user key":      {"beta-int", "int", "open", "priv", "pub"},
+		"workspace key": {"beta-int", "open", "pub"},
+	}
+	for _, caller := range accessCallers {
+		t.Run(caller, func(t *testing.T) {
+			var query, events []string
+			serve(t, f.tokens[caller], http.MethodGet, "/visible", "/visible", nil, func(c *gin.Context) {
+				var visible, all []model.Component
+				database.DB.Scopes(visibleTo(c)).Order("slug").Find(&visible)
+				database.DB.Order("slug").Find(&all)
+				for _, component := range visible {
+					query = append(query, component.Slug)
+				}
+				// canSee (untuk SSE) harus sama persis dengan visibleTo.
+				for _, component := range all {
+					snapshot := eventComponent{CategoryID: component.CategoryID, UserID: component.UserID, WorkspaceID: component.WorkspaceID, Visibility: component.Visibility}
+					if scopeFor(c).canSee(&snapshot) {
+						events = append(events, component.Slug)
+					}
+				}
+			})
+			if !reflect.DeepEqual(query, want[caller]) {
+				t.Errorf("visibleTo = %v, want %v", query, want[caller])
+			}
+			if !reflect.DeepEqual(events, want[caller]) {
+				t.Errorf("canSee = %v, want %v", events, want[caller])
+			}
+		})
+	}
+}
+
+func TestRequireEditor(t *testing.T) {
+	f := newAccessFixture(t)
+
+	slugs := []string{"open", "pub", "int", "priv", "beta-int"}
+	want := map[string][]int{
+		"anonymous":     {401, 401, 404, 404, 404},
+		"outsider":      {204, 403, 404, 404, 404},
+		"member":        {403, 403, 403, 404, 404},
+		"author":        {403, 204, 204, 204, 204},
+		"admin":         {403, 204, 204, 204, 404},
+		"owner":         {403, 204, 204, 204, 404},
+		"site admin":    {204, 204, 404, 404, 404},
+		"user key":      {403, 204, 204, 204, 204},
+		"workspace key": {403, 403, 404, 404, 204},
+	}
+	edit := func(c *gin.Context) {
+		if _, ok := findEditableComponent(c, c.Param("slug")); ok {
+			c.Status(http.StatusNoContent)
+		}
+	}
+	for _, caller := range accessCallers {
+		var got []int
+		for _, slug := range slugs {
+			w := serve(t, f.tokens[caller], http.MethodPatch, "/components/:slug", "/components/"+slug, nil, edit)
+			got = append(got, w.Code)
+		}
+		if !slices.Equal(got, want[caller]) {
+			t.Errorf("%s: %v, want %v (%v)", caller, got, want[caller], slugs)
+		}
+	}
+}
+
+func TestRequireReviewer(t *testing.T) {
+	openTestDB(t)
+	_, user := newUser(t, "user@example.com", model.UserRoleUser)
+	_, reviewer := newUser(t, "reviewer@example.com", model.UserRoleReviewer)
+	_, admin := newUser(t, "admin@example.com", model.UserRoleAdmin)
+
+	review := func(c *gin.Context) {
+		if requireReviewer(c) {
+			c.Status(http.StatusNoContent)
+		}
+	}
+	for token, want := range map[string]int{"": 401, user: 403, reviewer: 204, admin: 204} {
+		if w := serve(t, token, http.MethodPatch, "/review", "/review", nil, review); w.Code != want {
+			t.Errorf("status %d, want %d", w.Code, want)
+		}
+	}
+}
+
+func TestWorkspaceMemberRules(t *testing.T) {
+	f := newAccessFixture(t)
+	id := func(name string) string { return f.users[name].ID.String() }
+	roleOf := func(name string) string {
+		var member model.WorkspaceMember
+		database.DB.Where("workspace_id = ? AND user_id = ?", f.acme.ID, f.users[name].ID).Limit(1).Find(&member)
+		return member.Role
+	}
+
+	// Langkah dijalankan berurutan di workspace acme.
+	steps := []struct {
+		name   string
+		caller string
+		method string
+		user   string
+		role   string
+		want   int
+	}{
+		{"outsider cannot see the workspace", "outsider", http.MethodPost, "outsider", "member", 404},
+		{"members cannot add members", "member", http.MethodPost, "outsider", "member", 403},
+		{"members cannot remove others", "member", http.MethodDelete, "author", "", 403},
+		{"API keys act as plain members", "workspace key", http.MethodPost, "outsider", "member", 404},
+		{"admins cannot add owners", "admin", http.MethodPost, "outsider", "owner", 403},
+		{"admins cannot demote owners", "admin", http.MethodPost, "owner", "member", 403},
+		{"admins cannot remove owners", "admin", http.MethodDelete, "owner", "", 403},
+		{"admins add members", "admin", http.MethodPost, "outsider", "member", 200},
+		{"admins remove members", "admin", http.MethodDelete, "outsider", "", 204},
+		{"invalid role", "owner", http.MethodPost, "outsider", "superuser", 400},
+		{"the last owner cannot leave", "owner", http.MethodDelete, "owner", "", 409},
+		{"the last owner cannot step down", "owner", http.MethodPost, "owner", "admin", 409},
+		{"owners add owners", "owner", http.MethodPost, "admin", "owner", 200},
+		{"owners demote owners", "admin", http.MethodPost, "owner", "member", 200},
+		{"members leave", "member", http.MethodDelete, "member", "", 204},
+		{"unknown member", "admin", http.MethodDelete, "member", "", 404},
+	}
+	for _, step := range steps {
+		var w interface{ Result() *http.Response }
+		if step.method == http.MethodPost {
+			body := map[string]string{"user_id": id(step.user), "role": step.role}
+			w = serve(t, f.tokens[step.caller], step.method, "/workspaces/:workspace/members", "/workspaces/acme/members", body, AddWorkspaceMember)
+		} else {
+			w = serve(t, f.tokens[step.caller], step.method, "/workspaces/:workspace/members/:user_id", "/workspaces/acme/members/"+id(step.user), nil, RemoveWorkspaceMember)
+		}
+		if got := w.Result().StatusCode; got != step.want {
+			t.Fatalf("%s: status %d, want %d", step.name, got, step.want)
+		}
+	}
+
+	want := map[string]string{"owner": "member", "admin": "owner", "author": "member", "member": "", "outsider": ""}
+	for name, role := range want {
+		if got := roleOf(name); got != role {
+			t.Errorf("role of %s = %q, want %q", name, got, role)
+		}
+	}
+}
+
+func TestCreateWorkspace(t *testing.T) {
+	openTestDB(t)
+	user, token := newUser(t, "jane@example.com", model.UserRoleUser)
+
+	body := map[string]string{"name": "Acme Design"}
+	if w := serve(t, "", http.MethodPost, "/workspaces", "/workspaces", body, CreateWorkspace); w.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous: status %d, want 401", w.Code)
+	}
+	if w := serve(t, token, http.MethodPost, "/workspaces", "/workspaces", body, CreateWorkspace); w.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", w.Code, w.Body)
+	}
+	if w := serve(t, token, http.MethodPost, "/workspaces", "/workspaces", body, CreateWorkspace); w.Code != http.StatusConflict {
+		t.Errorf("duplicate: status %d, want 409", w.Code)
+	}
+
+	var member model.WorkspaceMember
+	if err := database.DB.Where("user_id = ?", user.ID).First(&member).Error; err != nil || member.Role != model.WorkspaceRoleOwner {
+		t.Errorf("creator membership %+v, %v", member, err)
+	}
+}
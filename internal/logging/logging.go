@@ -0,0 +1,53 @@
+// Package logging menyediakan logger JSON (log/slog) untuk seluruh service.
+// Setiap request membawa logger dengan request_id di context-nya, dan query
+// GORM yang memakai context request dicatat lewat logger yang sama.
+package logging
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"service_components/internal/config"
+)
+
+type contextKey int
+
+const (
+	loggerKey contextKey = iota
+	requestIDKey
+)
+
+// Logger adalah logger dasar tanpa atribut request.
+var Logger = slog.Default()
+
+func InitLogging(cfg *config.Config) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		log.Fatalf("FATAL: unknown LOG_LEVEL %q, expected debug, info, warn or error", cfg.LogLevel)
+	}
+	Logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level}))
+	// log.Printf yang tersisa juga keluar sebagai JSON lewat Logger.
+	slog.SetDefault(Logger)
+}
+
+// NewContext menyimpan request ID dan logger yang membawa request_id ke ctx.
+func NewContext(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+	return context.WithValue(ctx, loggerKey, Logger.With("request_id", requestID))
+}
+
+// FromContext mengembalikan logger request, atau Logger jika ctx bukan dari
+// request (worker, startup).
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return Logger
+}
+
+// RequestID mengembalikan request ID di ctx, atau "" jika tidak ada.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
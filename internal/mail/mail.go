@@ -0,0 +1,150 @@
+// Package mail mengirim email transaksional (saat ini email reset password)
+// lewat Sender yang dipilih MAIL_DRIVER: "smtp", atau "log" yang hanya
+// menulis email ke log dan hanya boleh dipakai dengan DEV_MODE=true. Tanpa
+// MAIL_DRIVER tidak ada email yang dikirim.
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"service_components/internal/config"
+	"service_components/internal/logging"
+	"strings"
+	"time"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+)
+
+// ErrNotConfigured dikembalikan Send jika MAIL_DRIVER tidak diisi.
+var ErrNotConfigured = errors.New("mail: no mailer configured")
+
+// Message adalah email teks biasa untuk satu penerima.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender mengirim satu email.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+var (
+	// Default adalah Sender yang dipakai Send; nil jika mailer tidak aktif.
+	Default Sender
+
+	// ResetURL adalah URL halaman reset password di frontend; token
+	// ditambahkan di belakangnya.
+	ResetURL string
+)
+
+func InitMail(cfg *config.Config) {
+	ResetURL = cfg.PasswordResetURL
+
+	switch cfg.MailDriver {
+	case "":
+		slog.Warn("MAIL_DRIVER is not set, password reset emails will not be sent")
+	case DriverSMTP:
+		if cfg.SMTPHost == "" || cfg.MailFrom == "" {
+			log.Fatal("FATAL: MAIL_DRIVER=smtp needs SMTP_HOST and MAIL_FROM")
+		}
+		Default = &SMTPSender{
+			Addr:     net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+			Host:     cfg.SMTPHost,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		}
+	case DriverLog:
+		if !cfg.DevMode {
+			log.Fatal("FATAL: MAIL_DRIVER=log writes secrets to the log and needs DEV_MODE=true")
+		}
+		Default = LogSender{}
+	default:
+		log.Fatalf("FATAL: unknown MAIL_DRIVER %q, expected smtp or log", cfg.MailDriver)
+	}
+}
+
+// Send mengirim msg lewat Default.
+func Send(ctx context.Context, msg Message) error {
+	if Default == nil {
+		return ErrNotConfigured
+	}
+	return Default.Send(ctx, msg)
+}
+
+// SMTPSender mengirim email lewat server SMTP. STARTTLS dipakai jika server
+// mendukungnya, dan login hanya dilakukan jika Username diisi.
+type SMTPSender struct {
+	Addr     string
+	Host     string
+	Username string
+	Password string
+	From     string
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	// net/smtp tidak menerima context; batas waktunya diperiksa sebelum
+	// koneksi dibuka.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return smtp.SendMail(s.Addr, auth, s.From, []string{msg.To}, s.format(msg))
+}
+
+func (s *SMTPSender) format(msg Message) []byte {
+	var b strings.Builder
+	header := func(name, value string) {
+		// Baris baru di header bisa dipakai untuk menyisipkan header lain.
+		value = strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+	header("From", s.From)
+	header("To", msg.To)
+	header("Subject", msg.Subject)
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// LogSender menulis email ke log alih-alih mengirimnya. Hanya untuk
+// development karena isi email (mis. token reset) ikut tercatat.
+type LogSender struct{}
+
+func (LogSender) Send(ctx context.Context, msg Message) error {
+	logging.FromContext(ctx).WarnContext(ctx, "email not sent (MAIL_DRIVER=log)", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
+
+// PasswordReset menyusun email reset password untuk token tersebut.
+func PasswordReset(to, token string, ttl time.Duration) Message {
+	link := token
+	if ResetURL != "" {
+		link = ResetURL + token
+	}
+	return Message{
+		To:      to,
+		Subject: "Reset your ComponentHub password",
+		Body: "Someone asked to reset the password of your ComponentHub account.\n\n" +
+			"Use this link within " + ttl.String() + " to choose a new password:\n\n" +
+			link + "\n\n" +
+			"If you did not ask for this, you can ignore this email.\n",
+	}
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"service_components/internal/model"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// scrapeTimeout membatasi query gauge katalog per scrape.
+const scrapeTimeout = 5 * time.Second
+
+var (
+	componentsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "components"),
+		"Components by status and approval state.",
+		[]string{"status", "approval"}, nil,
+	)
+	reviewQueueDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "review_queue_size"),
+		"Components waiting for review (approval state pending).",
+		nil, nil,
+	)
+)
+
+// catalogCollector menghitung gauge katalog dari database setiap kali
+// di-scrape, jadi nilainya selalu sesuai isi tabel tanpa perlu diperbarui
+// di setiap handler.
+type catalogCollector struct {
+	db *gorm.DB
+}
+
+func (c *catalogCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- componentsDesc
+	ch <- reviewQueueDesc
+}
+
+func (c *catalogCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	var rows []struct {
+		Status         string
+		ApprovalStatus string
+		Count          int64
+	}
+	err := c.db.WithContext(ctx).Model(&model.Component{}).
+		Select("status, approval_status, COUNT(*) AS count").
+		Group("status, approval_status").
+		Scan(&rows).Error
+	if err != nil {
+		slog.Error("metrics: failed to count components", "error", err)
+		ch <- prometheus.NewInvalidMetric(componentsDesc, err)
+		ch <- prometheus.NewInvalidMetric(reviewQueueDesc, err)
+		return
+	}
+
+	var pending int64
+	for _, row := range rows {
+		ch <- prometheus.MustNewConstMetric(componentsDesc, prometheus.GaugeValue, float64(row.Count), row.Status, row.ApprovalStatus)
+		if row.ApprovalStatus == model.ApprovalPending {
+			pending += row.Count
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(reviewQueueDesc, prometheus.GaugeValue, float64(pending))
+}
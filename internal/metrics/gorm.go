@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const startKey = "metrics:start"
+
+// gormPlugin mengukur setiap query GORM lewat callback sebelum dan sesudah
+// seluruh callback operasi.
+type gormPlugin struct{}
+
+func (p *gormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	for _, err := range []error{
+		callback.Create().Before("*").Register("metrics:before_create", start),
+		callback.Create().After("*").Register("metrics:after_create", observe("create")),
+		callback.Query().Before("*").Register("metrics:before_query", start),
+		callback.Query().After("*").Register("metrics:after_query", observe("query")),
+		callback.Update().Before("*").Register("metrics:before_update", start),
+		callback.Update().After("*").Register("metrics:after_update", observe("update")),
+		callback.Delete().Before("*").Register("metrics:before_delete", start),
+		callback.Delete().After("*").Register("metrics:after_delete", observe("delete")),
+		callback.Row().Before("*").Register("metrics:before_row", start),
+		callback.Row().After("*").Register("metrics:after_row", observe("row")),
+		callback.Raw().Before("*").Register("metrics:before_raw", start),
+		callback.Raw().After("*").Register("metrics:after_raw", observe("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func start(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(value.(time.Time)).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			dbQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
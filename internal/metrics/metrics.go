@@ -0,0 +1,122 @@
+// Package metrics mengumpulkan metrics Prometheus: request HTTP per route dan
+// status, durasi dan error query GORM, statistik pool koneksi database, dan
+// gauge katalog (komponen per status dan approval, antrean review). Metrics
+// dilayani di GET /metrics, di port HTTP utama atau di METRICS_PORT.
+package metrics
+
+import (
+	"crypto/subtle"
+	"log"
+	"log/slog"
+	"net/http"
+	"service_components/internal/config"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+const namespace = "componenthub"
+
+var (
+	Enabled bool
+	// Token, jika diisi, wajib dikirim sebagai Authorization: Bearer <token>.
+	Token string
+
+	// Registry terpisah dari prometheus.DefaultRegisterer supaya hanya
+	// metrics service ini yang diekspos.
+	Registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "GORM query duration by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	dbQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "GORM queries that failed, by operation and table. Record not found is not an error.",
+	}, []string{"operation", "table"})
+)
+
+func InitMetrics(cfg *config.Config, db *gorm.DB) {
+	Enabled = cfg.MetricsEnabled
+	Token = cfg.MetricsToken
+	if !Enabled {
+		return
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("FATAL: failed to get database pool for metrics: %v", err)
+	}
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(sqlDB, "componenthub"),
+		httpRequests,
+		httpDuration,
+		dbQueryDuration,
+		dbQueryErrors,
+		&catalogCollector{db: db},
+	)
+	if err := db.Use(&gormPlugin{}); err != nil {
+		log.Fatalf("FATAL: failed to register GORM metrics plugin: %v", err)
+	}
+}
+
+// ObserveRequest mencatat satu request HTTP. route adalah template path gin
+// (misalnya /api/v1/components/:slug) supaya jumlah label tetap terbatas.
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// Handler melayani metrics dalam format Prometheus, dengan pemeriksaan Token
+// jika diisi.
+func Handler() http.Handler {
+	metrics := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if Token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		metrics.ServeHTTP(w, r)
+	})
+}
+
+// Serve melayani GET /metrics di port terpisah, misalnya supaya hanya bisa
+// diakses dari jaringan internal.
+func Serve(port string) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", Handler())
+
+	slog.Info("metrics server listening", "port", port)
+	if err := http.ListenAndServe(":"+port, mux); err != nil {
+		log.Fatalf("FATAL: metrics server stopped: %v", err)
+	}
+}
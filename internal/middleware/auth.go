@@ -1,28 +1,176 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"service_components/internal/auth"
+	"service_components/internal/database"
+	"service_components/internal/logging"
+	"service_components/internal/model"
+	"service_components/internal/utils"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+const (
+	callerKey  = "caller_id"
+	sessionKey = "session_id"
+	apiKeyKey  = "api_key"
+)
+
+// lastUsedInterval membatasi update last_used_at API key supaya tidak ada
+// write ke database di setiap request.
+const lastUsedInterval = time.Minute
+
+// APIKeyGrant adalah hak akses request yang memakai API key.
+type APIKeyGrant struct {
+	ID          uuid.UUID
+	Scopes      []string
+	WorkspaceID *uuid.UUID
+}
+
+// Allows memeriksa apakah key punya scope tersebut.
+func (g *APIKeyGrant) Allows(scope string) bool {
+	for _, s := range g.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func unauthorized(c *gin.Context, message string) {
+	utils.Error(c, http.StatusUnauthorized, message)
+	c.Abort()
+}
+
+// Authenticate membaca access token sesi atau API key (prefix chk_) dari
+// header Authorization: Bearer. Request tanpa header diperlakukan sebagai
+// anonim dan hanya melihat komponen public; token yang tidak valid atau
+// kedaluwarsa ditolak dengan 401.
+func Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			unauthorized(c, "Authorization header must be Bearer <token>")
+			return
+		}
+		if strings.HasPrefix(token, auth.APIKeyPrefix) {
+			authenticateAPIKey(c, token)
+			return
+		}
+
+		var session model.Session
+		err := database.DB.WithContext(c.Request.Context()).Select("id", "user_id").
+			Where("access_token_hash = ? AND revoked_at IS NULL AND access_expires_at > ?", auth.HashToken(token), time.Now().UTC()).
+			First(&session).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			unauthorized(c, "Invalid or expired token")
+			return
+		}
+		if err != nil {
+			logging.FromContext(c.Request.Context()).Error("failed to verify access token", "error", err)
+			utils.Error(c, http.StatusInternalServerError, "Failed to verify token")
 			c.Abort()
+			return
 		}
 
-		tokenString := strings.Split(authHeader, "Bearer ")[1]
+		c.Set(callerKey, session.UserID)
+		c.Set(sessionKey, session.ID)
+		c.Next()
+	}
+}
 
-		if tokenString != "valid token" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+func authenticateAPIKey(c *gin.Context, token string) {
+	now := time.Now().UTC()
+	var key model.APIKey
+	err := database.DB.WithContext(c.Request.Context()).Where("key_hash = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", auth.HashToken(token), now).
+		First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		unauthorized(c, "Invalid, revoked or expired API key")
+		return
+	}
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("failed to verify api key", "error", err)
+		utils.Error(c, http.StatusInternalServerError, "Failed to verify API key")
+		c.Abort()
+		return
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > lastUsedInterval {
+		err := database.DB.WithContext(c.Request.Context()).Model(&model.APIKey{}).Where("id = ?", key.ID).UpdateColumn("last_used_at", now).Error
+		if err != nil {
+			logging.FromContext(c.Request.Context()).Error("failed to update last_used_at of api key", "api_key_id", key.ID, "error", err)
+		}
+	}
+
+	c.Set(callerKey, key.UserID)
+	c.Set(apiKeyKey, &APIKeyGrant{ID: key.ID, Scopes: key.Scopes, WorkspaceID: key.WorkspaceID})
+	c.Next()
+}
+
+// RequireScope menolak request API key yang tidak punya scope. Sesi user
+// tidak dibatasi scope. Request anonim hanya diteruskan untuk scope
+// components:read; scope tulis dan review butuh user yang login.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := CallerID(c); !ok && scope != model.ScopeComponentsRead {
+			unauthorized(c, "Authentication required")
+			return
+		}
+		if grant, ok := APIKey(c); ok && !grant.Allows(scope) {
+			utils.Error(c, http.StatusForbidden, "API key is missing scope "+scope)
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
+// RequireAuth menolak request anonim. Dipasang setelah Authenticate.
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := CallerID(c); !ok {
+			unauthorized(c, "Authentication required")
+			return
+		}
 		c.Next()
 	}
 }
+
+// CallerID mengembalikan ID user yang diset oleh Authenticate.
+func CallerID(c *gin.Context) (uuid.UUID, bool) {
+	id, ok := c.Get(callerKey)
+	if !ok {
+		return uuid.Nil, false
+	}
+	return id.(uuid.UUID), true
+}
+
+// SessionID mengembalikan ID sesi dari access token yang dipakai.
+func SessionID(c *gin.Context) (uuid.UUID, bool) {
+	id, ok := c.Get(sessionKey)
+	if !ok {
+		return uuid.Nil, false
+	}
+	return id.(uuid.UUID), true
+}
+
+// APIKey mengembalikan hak akses API key jika request memakai API key.
+func APIKey(c *gin.Context) (*APIKeyGrant, bool) {
+	grant, ok := c.Get(apiKeyKey)
+	if !ok {
+		return nil, false
+	}
+	return grant.(*APIKeyGrant), true
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import "context"
+
+type internalKey struct{}
+
+// WithInternal menandai ctx untuk request yang dijalankan in-process dari
+// request lain yang sudah melewati middleware ini, misalnya mutation GraphQL
+// lewat handler.Dispatch. RequestID, Metrics dan RateLimit melewatkan request
+// seperti itu supaya access log, metrics dan rate limit tidak dihitung dua
+// kali; auth, scope dan validasi tetap berjalan.
+func WithInternal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internalKey{}, true)
+}
+
+// IsInternal melaporkan apakah ctx ditandai WithInternal.
+func IsInternal(ctx context.Context) bool {
+	internal, _ := ctx.Value(internalKey{}).(bool)
+	return internal
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"service_components/internal/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics mencatat jumlah dan durasi setiap request per route dan status.
+// Dipasang pertama supaya request yang ditolak middleware lain (401, 429,
+// 400) juga tercatat. Route yang tidak dikenal dicatat sebagai "unmatched".
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !metrics.Enabled || IsInternal(c.Request.Context()) {
+			c.Next()
+			return
+		}
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
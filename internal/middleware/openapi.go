@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"service_components/internal/logging"
+	"service_components/internal/openapi"
+	"service_components/internal/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIValidator menolak request /api/v1 yang tidak sesuai
+// docs/openapi.yaml dengan 400 dan rincian per field. Pada mode test,
+// response JSON juga divalidasi dan diganti 500 jika tidak sesuai, dan route
+// yang belum terdokumentasi ditolak, supaya spesifikasi tidak tertinggal dari
+// handler.
+func OpenAPIValidator() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if openapi.Mode == openapi.ModeOff || !openapi.Documented(c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		route := openapi.Route(c.Request.Method, c.FullPath())
+		if route == nil {
+			if openapi.Mode == openapi.ModeTest {
+				utils.Error(c, http.StatusInternalServerError, c.Request.Method+" "+c.FullPath()+" is not documented in docs/openapi.yaml")
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		params := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			params[param.Key] = param.Value
+		}
+		input, details := openapi.ValidateRequest(c.Request, route, params)
+		if len(details) > 0 {
+			utils.ValidationError(c, http.StatusBadRequest, "Request does not match the API specification", details)
+			c.Abort()
+			return
+		}
+		if openapi.Mode != openapi.ModeTest {
+			c.Next()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+		if recorder.streaming {
+			return
+		}
+
+		body := recorder.body.Bytes()
+		if details := openapi.ValidateResponse(c.Request.Context(), input, recorder.status, c.Writer.Header(), body); len(details) > 0 {
+			logging.FromContext(c.Request.Context()).Error("response does not match the api specification", "method", c.Request.Method, "route", c.FullPath(), "status", recorder.status, "details", details)
+			c.Writer.Header().Del("Content-Length")
+			utils.ValidationError(c, http.StatusInternalServerError, "Response does not match the API specification", details)
+			return
+		}
+		c.Writer.WriteHeader(recorder.status)
+		c.Writer.WriteHeaderNow()
+		if len(body) > 0 {
+			c.Writer.Write(body)
+		}
+	}
+}
+
+// responseRecorder menahan response JSON supaya bisa divalidasi sebelum
+// dikirim. Response lain (download, stream SSE) langsung diteruskan begitu
+// body pertama ditulis.
+type responseRecorder struct {
+	gin.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	decided   bool
+	streaming bool
+}
+
+func (w *responseRecorder) stream() bool {
+	if !w.decided {
+		w.decided = true
+		if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			w.streaming = true
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+	}
+	return w.streaming
+}
+
+func (w *responseRecorder) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *responseRecorder) WriteHeaderNow() {
+	if w.stream() {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	if w.stream() {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	if w.stream() {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+func (w *responseRecorder) Flush() {
+	if w.stream() {
+		w.ResponseWriter.Flush()
+	}
+}
+
+func (w *responseRecorder) Status() int {
+	if w.streaming {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *responseRecorder) Size() int {
+	if w.streaming {
+		return w.ResponseWriter.Size()
+	}
+	return w.body.Len()
+}
+
+func (w *responseRecorder) Written() bool {
+	if w.streaming {
+		return w.ResponseWriter.Written()
+	}
+	return w.decided
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"service_components/internal/logging"
+	"service_components/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID membatasi X-Request-ID dari client supaya tidak bisa
+// menyisipkan teks sembarang ke log.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID memakai X-Request-ID dari client (misalnya dari load balancer)
+// atau membuat UUID baru, mengirimnya kembali di header response, dan
+// menyimpan logger dengan request_id di context request. Setelah request
+// selesai, satu baris access log dicatat, kecuali untuk request internal
+// (lihat WithInternal). Dipasang pertama.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.NewContext(c.Request.Context(), id))
+
+		start := time.Now()
+		c.Next()
+		if IsInternal(c.Request.Context()) {
+			return
+		}
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).Log(ctx, level, "request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"route", c.FullPath(),
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"bytes", c.Writer.Size(),
+			"client_ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+		)
+	}
+}
+
+// Recovery mengganti gin.Recovery: panic di handler dicatat lewat logger
+// request (dengan stack trace) dan dijawab 500.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, err any) {
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).ErrorContext(ctx, "panic recovered", "error", err, "stack", string(debug.Stack()))
+		utils.Error(c, http.StatusInternalServerError, "Internal server error")
+		c.Abort()
+	})
+}
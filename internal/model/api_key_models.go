@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// APIKey adalah kredensial non-interaktif untuk CI dan service. Key milik user
+// bertindak atas nama user tersebut; key workspace (WorkspaceID diisi) hanya
+// bisa mengakses workspace itu sebagai member. Hanya hash key yang disimpan.
+type APIKey struct {
+	ID          uuid.UUID                   `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name        string                      `gorm:"not null" json:"name"`
+	Prefix      string                      `gorm:"not null" json:"prefix"`
+	KeyHash     string                      `gorm:"unique;not null" json:"-"`
+	Key         string                      `gorm:"-" json:"key,omitempty"`
+	UserID      uuid.UUID                   `gorm:"type:uuid;not null;index" json:"user_id"`
+	WorkspaceID *uuid.UUID                  `gorm:"type:uuid;index" json:"workspace_id,omitempty"`
+	Scopes      datatypes.JSONSlice[string] `gorm:"not null" json:"scopes"`
+	ExpiresAt   *time.Time                  `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time                  `json:"last_used_at,omitempty"`
+	RotatedAt   *time.Time                  `json:"rotated_at,omitempty"`
+	RevokedAt   *time.Time                  `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	UpdatedAt   time.Time                   `json:"updated_at"`
+}
+
+const (
+	ScopeComponentsRead  = "components:read"
+	ScopeComponentsWrite = "components:write"
+	ScopeReview          = "review"
+)
+
+var APIKeyScopes = []string{ScopeComponentsRead, ScopeComponentsWrite, ScopeReview}
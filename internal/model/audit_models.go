@@ -0,0 +1,49 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// AuditEvent mencatat satu perubahan pada katalog (komponen, kategori, tag).
+// Tabel ini append-only: event tidak boleh diubah atau dihapus lewat aplikasi.
+type AuditEvent struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ActorID    *uuid.UUID     `gorm:"type:uuid;index" json:"actor_id,omitempty"`
+	ActorType  string         `gorm:"not null" json:"actor_type"`
+	APIKeyID   *uuid.UUID     `gorm:"type:uuid" json:"api_key_id,omitempty"`
+	Action     string         `gorm:"not null;index" json:"action"`
+	EntityType string         `gorm:"not null;index:idx_audit_entity" json:"entity_type"`
+	EntityID   uuid.UUID      `gorm:"type:uuid;not null;index:idx_audit_entity" json:"entity_id"`
+	EntitySlug string         `json:"entity_slug"`
+	Before     datatypes.JSON `json:"before,omitempty"`
+	After      datatypes.JSON `json:"after,omitempty"`
+	RequestID  string         `json:"request_id,omitempty"`
+	IP         string         `json:"ip"`
+	UserAgent  string         `json:"user_agent,omitempty"`
+	CreatedAt  time.Time      `gorm:"index" json:"created_at"`
+}
+
+const (
+	AuditActorUser      = "user"
+	AuditActorAPIKey    = "api_key"
+	AuditActorAnonymous = "anonymous"
+
+	AuditEntityComponent = "component"
+	AuditEntityCategory  = "category"
+	AuditEntityTag       = "tag"
+)
+
+var ErrAuditImmutable = errors.New("audit events are append-only")
+
+func (AuditEvent) BeforeUpdate(*gorm.DB) error {
+	return ErrAuditImmutable
+}
+
+func (AuditEvent) BeforeDelete(*gorm.DB) error {
+	return ErrAuditImmutable
+}
@@ -27,22 +27,169 @@ type Tag struct {
 }
 
 type Component struct {
-	ID              uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	Slug            string         `gorm:"unique;not null" json:"slug"`
-	Name            string         `gorm:"not null" json:"name"`
-	Description     string         `json:"description"`
-	CategoryID      uuid.UUID      `gorm:"not null" json:"-"`
-	Category        Category       `gorm:"foreignKey:CategoryID" json:"category"`
-	CodeJSX         string         `gorm:"type:text;not null" json:"code_jsx"`
-	CodeCSS         string         `gorm:"type:text" json:"code_css,omitempty"`
-	PropsDefinition datatypes.JSON `json:"props_definition" swaggerignore:"true"`
-	UserID          uuid.UUID      `gorm:"not null" json:"user_id"`
-	Tags            []*Tag         `gorm:"many2many:component_tags;" json:"tags,omitempty"`
-	Status          string         `json:"status"`
-	ApprovalStatus  string         `json:"approval_status"`
-	ReviewerID      uuid.UUID      `json:"reviewer_id"`
+	ID                 uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Slug               string             `gorm:"unique;not null" json:"slug"`
+	Name               string             `gorm:"not null" json:"name"`
+	Description        string             `json:"description"`
+	CategoryID         uuid.UUID          `gorm:"not null" json:"-"`
+	Category           Category           `gorm:"foreignKey:CategoryID" json:"category"`
+	CodeJSX            string             `gorm:"type:text;not null" json:"code_jsx"`
+	CodeCSS            string             `gorm:"type:text" json:"code_css,omitempty"`
+	PropsDefinition    datatypes.JSON     `json:"props_definition"`
+	UserID             uuid.UUID          `gorm:"not null" json:"user_id"`
+	WorkspaceID        *uuid.UUID         `gorm:"type:uuid;index" json:"workspace_id,omitempty"`
+	Visibility         string             `gorm:"not null;default:public" json:"visibility"`
+	Tags               []*Tag             `gorm:"many2many:component_tags;" json:"tags,omitempty"`
+	Variants           []ComponentVariant `gorm:"foreignKey:ComponentID" json:"variants,omitempty"`
+	Files              []ComponentFile    `gorm:"foreignKey:ComponentID" json:"files,omitempty"`
+	Screenshots        []Screenshot       `gorm:"foreignKey:ComponentID" json:"screenshots,omitempty"`
+	PrimaryImage       *Screenshot        `gorm:"-" json:"primary_image,omitempty"`
+	Status             string             `json:"status"`
+	ApprovalStatus     string             `json:"approval_status"`
+	ReviewerID         uuid.UUID          `json:"reviewer_id"`
+	LatestVersion      string             `json:"latest_version,omitempty"`
+	DeprecatedAt       *time.Time         `json:"deprecated_at,omitempty"`
+	DeprecationMessage string             `gorm:"type:text" json:"deprecation_message,omitempty"`
+	SunsetAt           *time.Time         `json:"sunset_at,omitempty"`
+	ReplacedByID       *uuid.UUID         `gorm:"type:uuid" json:"-"`
+	ReplacedBy         string             `gorm:"-" json:"replaced_by,omitempty"`
+	ForkedFromID       *uuid.UUID         `gorm:"type:uuid;index" json:"-"`
+	ForkedFrom         string             `gorm:"-" json:"forked_from,omitempty"`
+	ForkedFromVersion  string             `json:"forked_from_version,omitempty"`
+	Version            string             `gorm:"-" json:"version,omitempty"`
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// ComponentVariant adalah implementasi komponen untuk satu framework. Isinya
+// diturunkan dari ComponentFile dengan role component dan style; CodeJSX/CodeCSS
+// pada Component tetap menjadi salinan dari varian react untuk kompatibilitas.
+type ComponentVariant struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ComponentID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_variant_component_framework" json:"-"`
+	Framework   string    `gorm:"not null;uniqueIndex:idx_variant_component_framework" json:"framework"`
+	Code        string    `gorm:"type:text;not null" json:"code"`
+	Styles      string    `gorm:"type:text" json:"styles,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ComponentFile adalah satu file sumber komponen (kode, hook, util, story,
+// test, asset). Path unik per komponen dan framework.
+type ComponentFile struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ComponentID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_file_component_framework_path" json:"-"`
+	Framework   string    `gorm:"not null;uniqueIndex:idx_file_component_framework_path" json:"framework"`
+	Path        string    `gorm:"not null;uniqueIndex:idx_file_component_framework_path" json:"path"`
+	Language    string    `json:"language"`
+	Role        string    `gorm:"not null" json:"role"`
+	Content     string    `gorm:"type:text" json:"content"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Asset adalah file biner (gambar, icon, font) milik komponen. Isinya
+// disimpan di BlobStore dengan key berdasarkan hash, sehingga upload yang
+// identik hanya disimpan sekali.
+type Asset struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ComponentID uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	FileName    string    `gorm:"not null" json:"file_name"`
+	ContentType string    `gorm:"not null" json:"content_type"`
+	Size        int64     `json:"size"`
+	Hash        string    `gorm:"not null;index" json:"hash"`
+	StorageKey  string    `gorm:"not null" json:"-"`
+	URL         string    `gorm:"-" json:"url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Screenshot adalah gambar galeri komponen. Gambar asli dan thumbnail-nya
+// disimpan di BlobStore; Position menentukan urutan galeri.
+type Screenshot struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ComponentID  uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	Theme        string    `json:"theme,omitempty"`
+	Viewport     string    `json:"viewport,omitempty"`
+	Caption      string    `json:"caption,omitempty"`
+	Position     int       `gorm:"not null;default:0" json:"position"`
+	IsPrimary    bool      `gorm:"not null;default:false" json:"is_primary"`
+	ContentType  string    `gorm:"not null" json:"content_type"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	Hash         string    `gorm:"not null" json:"hash"`
+	StorageKey   string    `gorm:"not null" json:"-"`
+	ThumbnailKey string    `gorm:"not null" json:"-"`
+	URL          string    `gorm:"-" json:"url,omitempty"`
+	ThumbnailURL string    `gorm:"-" json:"thumbnail_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ComponentRelease adalah rilis semver komponen. File dan props komponen
+// dibekukan saat rilis dibuat sehingga perubahan berikutnya tidak
+// mempengaruhi versi yang sudah dirilis.
+type ComponentRelease struct {
+	ID              uuid.UUID                        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ComponentID     uuid.UUID                        `gorm:"type:uuid;not null;uniqueIndex:idx_release_component_version" json:"-"`
+	Version         string                           `gorm:"not null;uniqueIndex:idx_release_component_version" json:"version"`
+	Major           int                              `gorm:"not null" json:"-"`
+	Minor           int                              `gorm:"not null" json:"-"`
+	Patch           int                              `gorm:"not null" json:"-"`
+	Changelog       string                           `gorm:"type:text;not null" json:"changelog"`
+	Breaking        bool                             `gorm:"not null;default:false" json:"breaking"`
+	PropsDefinition datatypes.JSON                   `json:"props_definition,omitempty"`
+	Files           datatypes.JSONSlice[ReleaseFile] `json:"files,omitempty"`
+	CreatedAt       time.Time                        `json:"created_at"`
+}
+
+// ReleaseFile adalah salinan ComponentFile di dalam rilis.
+type ReleaseFile struct {
+	Framework string `json:"framework"`
+	Path      string `json:"path"`
+	Language  string `json:"language"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+}
+
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+
+	ViewportMobile  = "mobile"
+	ViewportTablet  = "tablet"
+	ViewportDesktop = "desktop"
+)
+
+const (
+	FileRoleComponent = "component"
+	FileRoleStyle     = "style"
+	FileRoleHook      = "hook"
+	FileRoleUtil      = "util"
+	FileRoleStory     = "story"
+	FileRoleTest      = "test"
+	FileRoleAsset     = "asset"
+)
+
+var FileRoles = []string{FileRoleComponent, FileRoleStyle, FileRoleHook, FileRoleUtil, FileRoleStory, FileRoleTest, FileRoleAsset}
+
+const (
+	FrameworkReact         = "react"
+	FrameworkVue           = "vue"
+	FrameworkSvelte        = "svelte"
+	FrameworkHTML          = "html"
+	FrameworkWebComponents = "web-components"
+)
+
+var Frameworks = []string{FrameworkReact, FrameworkVue, FrameworkSvelte, FrameworkHTML, FrameworkWebComponents}
+
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+)
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// OutboxEvent adalah domain event yang ditulis di transaksi yang sama dengan
+// perubahannya, lalu dipublikasikan oleh dispatcher. ID bertambah terus dan
+// menentukan urutan publikasi.
+type OutboxEvent struct {
+	ID            int64          `gorm:"primaryKey;autoIncrement" json:"sequence"`
+	EventID       uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"id"`
+	Type          string         `gorm:"not null" json:"type"`
+	AggregateType string         `gorm:"not null" json:"aggregate_type"`
+	AggregateID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"aggregate_id"`
+	Payload       datatypes.JSON `gorm:"not null" json:"data"`
+	Attempts      int            `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time      `json:"next_attempt_at"`
+	LastError     string         `json:"last_error,omitempty"`
+	PublishedAt   *time.Time     `gorm:"index" json:"published_at,omitempty"`
+	// PublishedSeq adalah urutan publikasi, diisi dispatcher dari sequence
+	// outbox_published_seq. Stream SSE di setiap instance mengikuti kolom ini.
+	PublishedSeq *int64    `gorm:"uniqueIndex" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
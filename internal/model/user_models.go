@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// User yang login lewat OIDC tidak punya password (PasswordHash kosong).
+type User struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Email        string         `gorm:"unique;not null" json:"email"`
+	Name         string         `gorm:"not null" json:"name"`
+	PasswordHash string         `json:"-"`
+	Role         string         `gorm:"not null;default:user" json:"role"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Session menyimpan pasangan access token dan refresh token milik satu login.
+// Seperti ShareLink, hanya hash token yang disimpan.
+type Session struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	AccessTokenHash  string     `gorm:"unique;not null" json:"-"`
+	RefreshTokenHash string     `gorm:"unique;not null" json:"-"`
+	AccessExpiresAt  time.Time  `gorm:"not null" json:"access_expires_at"`
+	RefreshExpiresAt time.Time  `gorm:"not null" json:"refresh_expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	UserAgent        string     `json:"user_agent"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type PasswordReset struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenHash string     `gorm:"unique;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+const (
+	UserRoleUser     = "user"
+	UserRoleReviewer = "reviewer"
+	UserRoleAdmin    = "admin"
+)
+
+// UserIdentity menghubungkan user dengan akun di identity provider OIDC.
+type UserIdentity struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Issuer    string    `gorm:"not null;uniqueIndex:idx_identity_issuer_subject" json:"issuer"`
+	Subject   string    `gorm:"not null;uniqueIndex:idx_identity_issuer_subject" json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OIDCLogin menyimpan state, nonce dan code verifier PKCE dari login OIDC
+// yang sedang berjalan sampai provider memanggil callback.
+type OIDCLogin struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	StateHash    string    `gorm:"unique;not null"`
+	Nonce        string    `gorm:"not null"`
+	CodeVerifier string    `gorm:"not null"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
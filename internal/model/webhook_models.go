@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// Webhook adalah langganan event katalog. Payload ditandatangani dengan
+// HMAC-SHA256 memakai Secret; secret hanya ditampilkan saat webhook dibuat.
+type Webhook struct {
+	ID           uuid.UUID                   `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	URL          string                      `gorm:"not null" json:"url"`
+	Events       datatypes.JSONSlice[string] `gorm:"not null" json:"events"`
+	Secret       string                      `gorm:"not null" json:"-"`
+	PlainSecret  string                      `gorm:"-" json:"secret,omitempty"`
+	Active       bool                        `gorm:"not null;default:true" json:"active"`
+	FailureCount int                         `gorm:"not null;default:0" json:"failure_count"`
+	DisabledAt   *time.Time                  `json:"disabled_at,omitempty"`
+	LastError    string                      `json:"last_error,omitempty"`
+	CreatedBy    uuid.UUID                   `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
+
+// WebhookDelivery adalah satu event yang dikirim ke satu webhook, beserta
+// hasil percobaan terakhirnya. Replay membuat delivery baru dengan EventID
+// dan payload yang sama.
+type WebhookDelivery struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WebhookID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"webhook_id"`
+	EventID        uuid.UUID      `gorm:"type:uuid;not null" json:"event_id"`
+	Event          string         `gorm:"not null" json:"event"`
+	Payload        datatypes.JSON `gorm:"not null" json:"payload"`
+	Status         string         `gorm:"not null;default:pending;index:idx_delivery_due" json:"status"`
+	Attempts       int            `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time      `gorm:"index:idx_delivery_due" json:"next_attempt_at"`
+	ResponseStatus int            `json:"response_status,omitempty"`
+	ResponseBody   string         `gorm:"type:text" json:"response_body,omitempty"`
+	Error          string         `json:"error,omitempty"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+const (
+	EventComponentCreated    = "component.created"
+	EventComponentUpdated    = "component.updated"
+	EventComponentPublished  = "component.published"
+	EventComponentApproved   = "component.approved"
+	EventComponentRejected   = "component.rejected"
+	EventComponentDeprecated = "component.deprecated"
+	EventComponentDeleted    = "component.deleted"
+)
+
+var WebhookEvents = []string{
+	EventComponentCreated, EventComponentUpdated, EventComponentPublished, EventComponentApproved,
+	EventComponentRejected, EventComponentDeprecated, EventComponentDeleted,
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Workspace adalah organisasi yang memiliki komponen. Komponen tanpa
+// workspace (data lama) selalu dianggap public.
+type Workspace struct {
+	ID        uuid.UUID         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Slug      string            `gorm:"unique;not null" json:"slug"`
+	Name      string            `gorm:"not null" json:"name"`
+	Members   []WorkspaceMember `gorm:"foreignKey:WorkspaceID" json:"members,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+type WorkspaceMember struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WorkspaceID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_member_workspace_user" json:"-"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_member_workspace_user;index" json:"user_id"`
+	Role        string    `gorm:"not null" json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ShareLink memberi akses baca ke satu komponen tanpa perlu menjadi member
+// workspace. Hanya hash token yang disimpan; token asli dikembalikan sekali
+// saat link dibuat.
+type ShareLink struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ComponentID uuid.UUID  `gorm:"type:uuid;not null;index" json:"-"`
+	TokenHash   string     `gorm:"unique;not null" json:"-"`
+	Token       string     `gorm:"-" json:"token,omitempty"`
+	URL         string     `gorm:"-" json:"url,omitempty"`
+	CreatedBy   uuid.UUID  `gorm:"type:uuid" json:"created_by"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+const (
+	VisibilityPublic   = "public"
+	VisibilityInternal = "internal"
+	VisibilityPrivate  = "private"
+)
+
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
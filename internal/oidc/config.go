@@ -0,0 +1,32 @@
+package oidc
+
+import (
+	"log"
+	"service_components/internal/config"
+)
+
+var (
+	// Default bernilai nil jika login OIDC tidak dikonfigurasi.
+	Default     *Provider
+	GroupsClaim string
+	RoleMapping map[string]string
+)
+
+func InitOIDC(cfg *config.Config) {
+	if cfg.OIDCIssuerURL == "" {
+		return
+	}
+	if cfg.OIDCClientID == "" {
+		log.Fatal("FATAL: OIDC_CLIENT_ID is required when OIDC_ISSUER_URL is set")
+	}
+
+	Default = New(Config{
+		IssuerURL:    cfg.OIDCIssuerURL,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL,
+		Scopes:       cfg.OIDCScopes,
+	})
+	GroupsClaim = cfg.OIDCGroupsClaim
+	RoleMapping = cfg.OIDCRoleMapping
+}
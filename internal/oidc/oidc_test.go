@@ -0,0 +1,451 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubProvider adalah identity provider minimal untuk test: discovery, JWKS
+// yang bisa dirotasi, dan token endpoint yang memeriksa PKCE.
+type stubProvider struct {
+	*httptest.Server
+	t *testing.T
+
+	mu            sync.Mutex
+	keys          []jwk
+	challenges    map[string]string // code -> code_challenge
+	idToken       string
+	discoveryHits int
+	jwksHits      int
+	issuer        string
+}
+
+func newStubProvider(t *testing.T) *stubProvider {
+	s := &stubProvider{t: t, challenges: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.discoveryHits++
+		issuer := s.issuer
+		s.mu.Unlock()
+		json.NewEncoder(w).Encode(Discovery{
+			Issuer:                issuer,
+			AuthorizationEndpoint: s.URL + "/authorize",
+			TokenEndpoint:         s.URL + "/token",
+			JWKSURI:               s.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.jwksHits++
+		json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+	})
+	mux.HandleFunc("/token", s.token)
+	s.Server = httptest.NewServer(mux)
+	s.issuer = s.URL
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *stubProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.t.Fatal(err)
+	}
+	fail := func(code, description string) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": code, "error_description": description})
+	}
+
+	if id, secret, ok := r.BasicAuth(); !ok || id != "hub" || secret != "s3cret" {
+		fail("invalid_client", "bad client credentials")
+		return
+	}
+	if r.Form.Get("grant_type") != "authorization_code" || r.Form.Get("redirect_uri") != "https://hub.example.com/callback" {
+		fail("invalid_request", "bad grant")
+		return
+	}
+
+	s.mu.Lock()
+	challenge, ok := s.challenges[r.Form.Get("code")]
+	delete(s.challenges, r.Form.Get("code"))
+	idToken := s.idToken
+	s.mu.Unlock()
+	if !ok {
+		fail("invalid_grant", "unknown code")
+		return
+	}
+	if CodeChallenge(r.Form.Get("code_verifier")) != challenge {
+		fail("invalid_grant", "PKCE verification failed")
+		return
+	}
+	json.NewEncoder(w).Encode(Token{AccessToken: "at", TokenType: "Bearer", IDToken: idToken, ExpiresIn: 300})
+}
+
+// authorize meniru halaman login provider: code dicatat bersama
+// code_challenge dari URL login.
+func (s *stubProvider) authorize(t *testing.T, loginURL, code string) {
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method := u.Query().Get("code_challenge_method"); method != "S256" {
+		t.Fatalf("code_challenge_method = %q", method)
+	}
+	s.mu.Lock()
+	s.challenges[code] = u.Query().Get("code_challenge")
+	s.mu.Unlock()
+}
+
+func (s *stubProvider) setKeys(keys ...jwk) {
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+}
+
+func (s *stubProvider) provider() *Provider {
+	return New(Config{
+		IssuerURL:    s.URL + "/",
+		ClientID:     "hub",
+		ClientSecret: "s3cret",
+		RedirectURL:  "https://hub.example.com/callback",
+	})
+}
+
+type signer struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func newRSASigner(t *testing.T, kid string) signer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer{kid: kid, alg: "RS256", key: key}
+}
+
+func newECSigner(t *testing.T, kid string) signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer{kid: kid, alg: "ES256", key: key}
+}
+
+func (s signer) jwk() jwk {
+	enc := base64.RawURLEncoding.EncodeToString
+	switch pub := s.key.Public().(type) {
+	case *rsa.PublicKey:
+		return jwk{Kty: "RSA", Kid: s.kid, Use: "sig", N: enc(pub.N.Bytes()), E: enc(big.NewInt(int64(pub.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		return jwk{Kty: "EC", Kid: s.kid, Use: "sig", Crv: "P-256", X: enc(pub.X.FillBytes(make([]byte, 32))), Y: enc(pub.Y.FillBytes(make([]byte, 32)))}
+	}
+	panic("unsupported key")
+}
+
+func (s signer) sign(t *testing.T, header map[string]any, claims Claims) string {
+	if header == nil {
+		header = map[string]any{"alg": s.alg, "kid": s.kid, "typ": "JWT"}
+	}
+	segment := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	input := segment(header) + "." + segment(claims)
+	digest := sha256.Sum256([]byte(input))
+
+	var signature []byte
+	switch key := s.key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, sv, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), sv.FillBytes(make([]byte, 32))...)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (s *stubProvider) claims(nonce string) Claims {
+	now := time.Now()
+	return Claims{
+		"iss":   s.URL,
+		"aud":   "hub",
+		"sub":   "user-1",
+		"email": "jane@example.com",
+		"nonce": nonce,
+		"iat":   float64(now.Unix()),
+		"exp":   float64(now.Add(5 * time.Minute).Unix()),
+	}
+}
+
+func TestDiscovery(t *testing.T) {
+	stub := newStubProvider(t)
+	p := stub.provider()
+
+	doc, err := p.Discover(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.TokenEndpoint != stub.URL+"/token" {
+		t.Errorf("token endpoint = %q", doc.TokenEndpoint)
+	}
+	if _, err := p.Discover(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if stub.discoveryHits != 1 {
+		t.Errorf("discovery fetched %d times, want 1", stub.discoveryHits)
+	}
+}
+
+func TestDiscoveryRejectsOtherIssuer(t *testing.T) {
+	stub := newStubProvider(t)
+	stub.issuer = "https://evil.example.com"
+
+	if _, err := stub.provider().Discover(context.Background()); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("got %v, want issuer mismatch", err)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	stub := newStubProvider(t)
+	loginURL, err := stub.provider().AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             "hub",
+		"redirect_uri":          "https://hub.example.com/callback",
+		"scope":                 "openid email profile",
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        CodeChallenge("verifier-1"),
+		"code_challenge_method": "S256",
+	}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if strings.Contains(loginURL, "verifier-1") {
+		t.Error("login URL leaks the code verifier")
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636, appendix B.
+	if got := CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"); got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("CodeChallenge = %q", got)
+	}
+}
+
+func TestExchangeWithPKCE(t *testing.T) {
+	stub := newStubProvider(t)
+	key := newRSASigner(t, "k1")
+	stub.setKeys(key.jwk())
+	p := stub.provider()
+
+	verifier, err := NewVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loginURL, err := p.AuthCodeURL(context.Background(), "state", "nonce-1", verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub.authorize(t, loginURL, "code-1")
+	stub.idToken = key.sign(t, nil, stub.claims("nonce-1"))
+
+	token, err := p.Exchange(context.Background(), "code-1", verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := p.VerifyIDToken(context.Background(), token.IDToken, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.String("email") != "jane@example.com" {
+		t.Errorf("claims = %v", claims)
+	}
+
+	// Code hanya bisa dipakai sekali.
+	if _, err := p.Exchange(context.Background(), "code-1", verifier); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("reused code: got %v", err)
+	}
+}
+
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	stub := newStubProvider(t)
+	p := stub.provider()
+
+	loginURL, err := p.AuthCodeURL(context.Background(), "state", "nonce", "right-verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub.authorize(t, loginURL, "code-1")
+
+	_, err = p.Exchange(context.Background(), "code-1", "wrong-verifier")
+	if err == nil || !strings.Contains(err.Error(), "PKCE verification failed") {
+		t.Errorf("got %v, want PKCE failure", err)
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	stub := newStubProvider(t)
+	rsaKey := newRSASigner(t, "rsa")
+	ecKey := newECSigner(t, "ec")
+	stub.setKeys(rsaKey.jwk(), ecKey.jwk())
+	otherKey := newRSASigner(t, "rsa")
+
+	with := func(change func(Claims)) Claims {
+		claims := stub.claims("nonce-1")
+		change(claims)
+		return claims
+	}
+	tamper := func(token string) string {
+		parts := strings.Split(token, ".")
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		payload = []byte(strings.Replace(string(payload), "user-1", "admin-1", 1))
+		return parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+	}
+	unsigned := func(alg string) string {
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"` + alg + `","kid":"rsa"}`))
+		payload, _ := json.Marshal(stub.claims("nonce-1"))
+		return header + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  string // "" jika token harus diterima
+	}{
+		{"rs256", rsaKey.sign(t, nil, stub.claims("nonce-1")), ""},
+		{"es256", ecKey.sign(t, nil, stub.claims("nonce-1")), ""},
+		{"audience array", rsaKey.sign(t, nil, with(func(c Claims) { c["aud"] = []any{"hub", "other"}; c["azp"] = "hub" })), ""},
+		{"bad nonce", rsaKey.sign(t, nil, stub.claims("nonce-2")), "nonce mismatch"},
+		{"wrong audience", rsaKey.sign(t, nil, with(func(c Claims) { c["aud"] = "other-client" })), "wrong audience"},
+		{"missing azp", rsaKey.sign(t, nil, with(func(c Claims) { c["aud"] = []any{"hub", "other"} })), "wrong authorized party"},
+		{"wrong issuer", rsaKey.sign(t, nil, with(func(c Claims) { c["iss"] = "https://evil.example.com" })), "wrong issuer"},
+		{"expired", rsaKey.sign(t, nil, with(func(c Claims) { c["exp"] = float64(time.Now().Add(-2 * leeway).Unix()) })), "expired"},
+		{"missing exp", rsaKey.sign(t, nil, with(func(c Claims) { delete(c, "exp") })), "expired"},
+		{"issued in the future", rsaKey.sign(t, nil, with(func(c Claims) { c["iat"] = float64(time.Now().Add(2 * leeway).Unix()) })), "issued in the future"},
+		{"missing sub", rsaKey.sign(t, nil, with(func(c Claims) { delete(c, "sub") })), "missing sub"},
+		{"alg none", unsigned("none"), "unsupported alg"},
+		{"alg HS256", unsigned("HS256"), "unsupported alg"},
+		{"alg RS256 with EC key", rsaKey.sign(t, map[string]any{"alg": "RS256", "kid": "ec"}, stub.claims("nonce-1")), "bad signature"},
+		{"signed by another key", otherKey.sign(t, nil, stub.claims("nonce-1")), "bad signature"},
+		{"tampered payload", tamper(rsaKey.sign(t, nil, stub.claims("nonce-1"))), "bad signature"},
+		{"malformed", "not-a-jwt", "malformed"},
+	}
+
+	p := stub.provider()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.VerifyIDToken(context.Background(), tt.token, "nonce-1")
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("rejected: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidToken) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWKSRotation(t *testing.T) {
+	stub := newStubProvider(t)
+	oldKey := newRSASigner(t, "2024")
+	newKey := newECSigner(t, "2025")
+	stub.setKeys(oldKey.jwk())
+	p := stub.provider()
+
+	if _, err := p.VerifyIDToken(context.Background(), oldKey.sign(t, nil, stub.claims("n")), "n"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Provider merotasi key. Kid yang belum dikenal tepat setelah JWKS diambil
+	// tidak memicu request baru.
+	stub.setKeys(newKey.jwk())
+	_, err := p.VerifyIDToken(context.Background(), newKey.sign(t, nil, stub.claims("n")), "n")
+	if !errors.Is(err, ErrInvalidToken) || !strings.Contains(err.Error(), "unknown key") {
+		t.Fatalf("got %v, want unknown key", err)
+	}
+	if stub.jwksHits != 1 {
+		t.Fatalf("JWKS fetched %d times, want 1", stub.jwksHits)
+	}
+
+	// Setelah keysRefreshInterval, kid baru memicu pengambilan ulang JWKS.
+	p.mu.Lock()
+	p.keysFetched = time.Now().Add(-2 * keysRefreshInterval)
+	p.mu.Unlock()
+	if _, err := p.VerifyIDToken(context.Background(), newKey.sign(t, nil, stub.claims("n")), "n"); err != nil {
+		t.Fatalf("token signed with the rotated key: %v", err)
+	}
+	if stub.jwksHits != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", stub.jwksHits)
+	}
+
+	// Key lama sudah tidak ada di JWKS.
+	if _, err := p.VerifyIDToken(context.Background(), oldKey.sign(t, nil, stub.claims("n")), "n"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token signed with the retired key: got %v", err)
+	}
+}
+
+func TestJWKSkipsEncryptionKeysAndBadCurves(t *testing.T) {
+	stub := newStubProvider(t)
+	key := newRSASigner(t, "enc")
+	encryption := key.jwk()
+	encryption.Use = "enc"
+	badCurve := newECSigner(t, "p384").jwk()
+	badCurve.Crv = "P-384"
+	offCurve := newECSigner(t, "off").jwk()
+	offCurve.Y = offCurve.X
+	stub.setKeys(encryption, badCurve, offCurve)
+	p := stub.provider()
+
+	for _, kid := range []string{"enc", "p384", "off"} {
+		p.mu.Lock()
+		p.keysFetched = time.Time{}
+		p.mu.Unlock()
+		token := key.sign(t, map[string]any{"alg": "RS256", "kid": kid}, stub.claims("n"))
+		if _, err := p.VerifyIDToken(context.Background(), token, "n"); err == nil || !strings.Contains(err.Error(), "unknown key") {
+			t.Errorf("kid %s: got %v, want unknown key", kid, err)
+		}
+	}
+}
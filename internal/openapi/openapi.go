@@ -0,0 +1,205 @@
+// Package openapi memuat spesifikasi OpenAPI 3 (docs/openapi.yaml) dan
+// memvalidasi request dan response HTTP terhadapnya. Route dicari dari path
+// gin (c.FullPath()), jadi tidak ada router kedua yang bisa berbeda hasilnya.
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"service_components/docs"
+	"service_components/internal/config"
+	"service_components/internal/utils"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+const (
+	// ModeOff mematikan validasi.
+	ModeOff = "off"
+	// ModeRequests menolak request yang tidak sesuai spesifikasi.
+	ModeRequests = "requests"
+	// ModeTest juga memvalidasi response dan route yang tidak terdokumentasi,
+	// untuk test dan CI.
+	ModeTest = "test"
+)
+
+var (
+	Mode = ModeRequests
+	Spec *openapi3.T
+
+	// BasePath adalah prefix route dari servers[0].url, misalnya /api/v1.
+	BasePath string
+
+	// routes memetakan "METHOD /path/gin/:param" ke operasi spesifikasi.
+	routes map[string]*routers.Route
+)
+
+func InitOpenAPI(cfg *config.Config) {
+	switch cfg.OpenAPIValidation {
+	case ModeOff, ModeRequests, ModeTest:
+		Mode = cfg.OpenAPIValidation
+	default:
+		log.Fatalf("FATAL: unknown OPENAPI_VALIDATION %q, expected off, requests or test", cfg.OpenAPIValidation)
+	}
+
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForUUIDOfRFC4122))
+
+	spec, err := Load(docs.OpenAPI)
+	if err != nil {
+		log.Fatalf("FATAL: invalid docs/openapi.yaml: %v", err)
+	}
+	Spec = spec
+	BasePath = strings.TrimRight(spec.Servers[0].URL, "/")
+
+	routes = map[string]*routers.Route{}
+	for path, item := range spec.Paths.Map() {
+		for method, operation := range item.Operations() {
+			routes[method+" "+BasePath+ginPath(path)] = &routers.Route{
+				Spec:      spec,
+				Server:    spec.Servers[0],
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: operation,
+			}
+		}
+	}
+}
+
+// Load membaca dan memvalidasi dokumen OpenAPI.
+func Load(data []byte) (*openapi3.T, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	if len(spec.Servers) == 0 {
+		return nil, errors.New("servers must declare the base path")
+	}
+	return spec, nil
+}
+
+// ginPath mengubah template path OpenAPI (/components/{slug}) ke bentuk gin
+// (/components/:slug).
+func ginPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Route mencari operasi untuk method dan path gin; nil jika tidak
+// terdokumentasi.
+func Route(method, fullPath string) *routers.Route {
+	return routes[method+" "+fullPath]
+}
+
+// Documented melaporkan apakah path termasuk API yang dijelaskan spesifikasi.
+func Documented(fullPath string) bool {
+	return fullPath == BasePath || strings.HasPrefix(fullPath, BasePath+"/")
+}
+
+func options() *openapi3filter.Options {
+	return &openapi3filter.Options{
+		MultiError: true,
+		// Auth diperiksa middleware dan handler; spesifikasi hanya
+		// mendokumentasikannya.
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		// Request tidak diubah; default diterapkan oleh handler.
+		SkipSettingDefaults: true,
+	}
+}
+
+// ValidateRequest memvalidasi path, query, header dan body JSON request.
+// Body lain (multipart, NDJSON, zip) dibaca dan divalidasi oleh handler-nya.
+// Body request tetap bisa dibaca ulang oleh handler.
+func ValidateRequest(req *http.Request, route *routers.Route, pathParams map[string]string) (*openapi3filter.RequestValidationInput, []utils.ErrorDetail) {
+	opts := options()
+	if body := route.Operation.RequestBody; body != nil && body.Value.Content.Get("application/json") == nil {
+		opts.ExcludeRequestBody = true
+	}
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    opts,
+	}
+	if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+		return input, Details(err)
+	}
+	return input, nil
+}
+
+// ValidateResponse memvalidasi status, header dan body response. Body hanya
+// divalidasi untuk response JSON.
+func ValidateResponse(ctx context.Context, input *openapi3filter.RequestValidationInput, status int, header http.Header, body []byte) []utils.ErrorDetail {
+	opts := options()
+	opts.IncludeResponseStatus = true
+	if !strings.HasPrefix(header.Get("Content-Type"), "application/json") {
+		opts.ExcludeResponseBody = true
+	}
+	err := openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 status,
+		Header:                 header,
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options:                opts,
+	})
+	if err != nil {
+		return Details(err)
+	}
+	return nil
+}
+
+// Details mengubah error validasi kin-openapi menjadi daftar ErrorDetail.
+func Details(err error) []utils.ErrorDetail {
+	return collect(nil, utils.ErrorDetail{In: "body"}, err)
+}
+
+// collect memeriksa tipe error secara langsung, bukan dengan errors.As,
+// karena RequestError membungkus MultiError dan parameter-nya akan hilang.
+func collect(details []utils.ErrorDetail, at utils.ErrorDetail, err error) []utils.ErrorDetail {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, inner := range e {
+			details = collect(details, at, inner)
+		}
+		return details
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			at = utils.ErrorDetail{In: e.Parameter.In, Field: e.Parameter.Name}
+		}
+		if e.Err == nil {
+			at.Message = e.Reason
+			return append(details, at)
+		}
+		return collect(details, at, e.Err)
+	case *openapi3filter.ResponseError:
+		if e.Err == nil {
+			at.Message = e.Reason
+			return append(details, at)
+		}
+		return collect(details, at, e.Err)
+	case *openapi3.SchemaError:
+		if at.In == "body" {
+			at.Field = "/" + strings.Join(e.JSONPointer(), "/")
+		}
+		at.Message = e.Reason
+		return append(details, at)
+	}
+
+	at.Message = err.Error()
+	return append(details, at)
+}
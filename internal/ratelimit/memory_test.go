@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock dipasang ke MemoryStore.now supaya refill bisa diuji tanpa sleep.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestMemoryStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := NewMemoryStore()
+	store.now = clock.now
+	return store, clock
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	store, clock := newTestMemoryStore()
+	limit := Limit{PerMinute: 60, Burst: 3}
+	ctx := context.Background()
+
+	steps := []struct {
+		advance    time.Duration
+		allowed    bool
+		remaining  int
+		reset      time.Duration
+		retryAfter time.Duration
+	}{
+		{0, true, 2, time.Second, 0},
+		{0, true, 1, 2 * time.Second, 0},
+		{0, true, 0, 3 * time.Second, 0},
+		{0, false, 0, 3 * time.Second, time.Second},
+		// Setengah token terisi: masih ditolak, retry dibulatkan ke atas.
+		{500 * time.Millisecond, false, 0, 3 * time.Second, time.Second},
+		{500 * time.Millisecond, true, 0, 3 * time.Second, 0},
+		// Refill tidak melebihi Burst.
+		{time.Hour, true, 2, time.Second, 0},
+	}
+	for i, step := range steps {
+		clock.advance(step.advance)
+		got, err := store.Take(ctx, "user:1", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := Result{Allowed: step.allowed, Limit: 3, Remaining: step.remaining, Reset: step.reset, RetryAfter: step.retryAfter}
+		if got != want {
+			t.Errorf("step %d: got %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	store, _ := newTestMemoryStore()
+	limit := Limit{PerMinute: 1, Burst: 1}
+	ctx := context.Background()
+
+	if r, _ := store.Take(ctx, "a", limit); !r.Allowed {
+		t.Fatal("first take on a denied")
+	}
+	if r, _ := store.Take(ctx, "a", limit); r.Allowed || r.RetryAfter != time.Minute {
+		t.Fatalf("second take on a = %+v, want denied with RetryAfter 1m", r)
+	}
+	if r, _ := store.Take(ctx, "b", limit); !r.Allowed {
+		t.Fatal("bucket b ikut habis")
+	}
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	store, clock := newTestMemoryStore()
+	ctx := context.Background()
+
+	store.Take(ctx, "fast", Limit{PerMinute: 600, Burst: 1})
+	for range 3 {
+		store.Take(ctx, "slow", Limit{PerMinute: 1, Burst: 5})
+	}
+
+	// "fast" penuh lagi setelah 100ms, "slow" baru setelah 3 menit; sweep
+	// berikutnya hanya menghapus bucket yang sudah penuh.
+	clock.advance(sweepInterval - time.Second)
+	store.Take(ctx, "other", Limit{PerMinute: 60, Burst: 1})
+	if len(store.buckets) != 3 {
+		t.Fatalf("sweep berjalan sebelum interval: %d bucket", len(store.buckets))
+	}
+
+	clock.advance(2 * time.Second)
+	store.Take(ctx, "other", Limit{PerMinute: 60, Burst: 1})
+	if _, ok := store.buckets["fast"]; ok {
+		t.Error("bucket penuh tidak dihapus")
+	}
+	if _, ok := store.buckets["slow"]; !ok {
+		t.Error("bucket yang belum penuh ikut dihapus")
+	}
+}
@@ -0,0 +1,98 @@
+// Package ratelimit membatasi jumlah request dengan token bucket. State bucket
+// disimpan di Store yang bisa diganti: in-memory untuk satu instance, atau
+// server Redis (protokol RESP) jika service dijalankan lebih dari satu
+// instance.
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"math"
+	"service_components/internal/config"
+	"time"
+)
+
+// Limit adalah konfigurasi token bucket: Burst request sekaligus, diisi ulang
+// PerMinute token per menit.
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.PerMinute) / 60
+}
+
+// Result adalah hasil pengambilan satu token.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset adalah waktu sampai bucket penuh lagi.
+	Reset time.Duration
+	// RetryAfter adalah waktu sampai token berikutnya tersedia (hanya diisi
+	// jika request ditolak).
+	RetryAfter time.Duration
+}
+
+// Store menyimpan state bucket per key. Take harus atomik untuk satu key.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// result menyusun Result dari sisa token setelah pengambilan.
+func result(limit Limit, allowed bool, tokens float64) Result {
+	rate := limit.rate()
+	r := Result{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		r.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(math.Max(s, 0))) * time.Second
+}
+
+var (
+	Enabled bool
+	Default Store
+	Limits  map[string]Limit
+)
+
+func InitRateLimit(cfg *config.Config) {
+	Enabled = cfg.RateLimitEnabled
+	if !Enabled {
+		return
+	}
+
+	Limits = map[string]Limit{}
+	for group, limit := range cfg.RateLimits {
+		if limit.PerMinute <= 0 {
+			continue
+		}
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = limit.PerMinute
+		}
+		Limits[group] = Limit{PerMinute: limit.PerMinute, Burst: burst}
+	}
+
+	switch cfg.RateLimitStore {
+	case "redis":
+		store, err := NewRedisStore(cfg.RedisURL)
+		if err != nil {
+			log.Fatalf("FATAL: invalid REDIS_URL: %v", err)
+		}
+		Default = store
+	case "memory", "":
+		Default = NewMemoryStore()
+	default:
+		log.Fatalf("FATAL: unknown RATE_LIMIT_STORE %q", cfg.RateLimitStore)
+	}
+}
@@ -0,0 +1,226 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// takeScript menjalankan token bucket secara atomik di server Redis. Waktu
+// diambil dari TIME milik server supaya semua instance memakai jam yang sama.
+const takeScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`
+
+const (
+	redisKeyPrefix = "ratelimit:"
+	redisPoolSize  = 16
+	redisTimeout   = time.Second
+)
+
+var takeScriptSHA = func() string {
+	sum := sha1.Sum([]byte(takeScript))
+	return hex.EncodeToString(sum[:])
+}()
+
+// RedisStore menyimpan bucket di server yang kompatibel dengan protokol Redis
+// (Redis, Valkey, KeyDB, Dragonfly). Koneksi dipakai ulang lewat pool kecil.
+type RedisStore struct {
+	addr     string
+	password string
+	db       int
+	pool     chan *redisConn
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+
+// NewRedisStore menerima URL redis://[:password@]host:port[/db].
+func NewRedisStore(rawURL string) (*RedisStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "redis" || u.Host == "" {
+		return nil, fmt.Errorf("expected redis://host:port, got %q", rawURL)
+	}
+
+	store := &RedisStore{addr: u.Host, pool: make(chan *redisConn, redisPoolSize)}
+	if !strings.Contains(u.Host, ":") {
+		store.addr = u.Host + ":6379"
+	}
+	if password, ok := u.User.Password(); ok {
+		store.password = password
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if store.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("invalid database %q", db)
+		}
+	}
+	return store, nil
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	conn, err := s.get(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	args := []string{takeScriptSHA, "1", redisKeyPrefix + key, strconv.FormatFloat(limit.rate(), 'f', -1, 64), strconv.Itoa(limit.Burst)}
+	reply, err := conn.do(ctx, append([]string{"EVALSHA"}, args...)...)
+	if err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT") {
+		args[0] = takeScript
+		reply, err = conn.do(ctx, append([]string{"EVAL"}, args...)...)
+	}
+	s.put(conn, err)
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return Result{}, fmt.Errorf("redis: unexpected reply %v", reply)
+	}
+	allowed, _ := values[0].(int64)
+	tokenString, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokenString, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("redis: unexpected tokens %q", tokenString)
+	}
+	return result(limit, allowed == 1, tokens), nil
+}
+
+func (s *RedisStore) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-s.pool:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: redisTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{conn: netConn, reader: bufio.NewReader(netConn)}
+	if s.password != "" {
+		if _, err := conn.do(ctx, "AUTH", s.password); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	if s.db != 0 {
+		if _, err := conn.do(ctx, "SELECT", strconv.Itoa(s.db)); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// put mengembalikan koneksi ke pool. Koneksi yang mengalami error jaringan
+// ditutup karena posisinya di stream tidak bisa dipastikan.
+func (s *RedisStore) put(conn *redisConn, err error) {
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		conn.conn.Close()
+		return
+	}
+	select {
+	case s.pool <- conn:
+	default:
+		conn.conn.Close()
+	}
+}
+
+func (c *redisConn) do(ctx context.Context, args ...string) (interface{}, error) {
+	deadline := time.Now().Add(redisTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.conn.SetDeadline(deadline)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+// read membaca satu reply RESP2.
+func (c *redisConn) read() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.reader, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			if values[i], err = c.read(); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+}
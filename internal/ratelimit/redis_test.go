@@ -0,0 +1,282 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// exchange adalah satu frame request yang diharapkan server beserta balasan
+// mentahnya.
+type exchange struct {
+	request string
+	reply   string
+}
+
+// fakeRedis menerima satu koneksi per elemen conns dan memeriksa byte yang
+// dikirim client persis sama dengan frame golden.
+func fakeRedis(t *testing.T, conns ...[]exchange) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	accepted := make(chan net.Conn, len(conns))
+	t.Cleanup(func() {
+		ln.Close()
+		<-done
+		close(accepted)
+		for conn := range accepted {
+			conn.Close()
+		}
+	})
+
+	go func() {
+		defer close(done)
+		for _, exchanges := range conns {
+			conn, err := ln.Accept()
+			if err != nil {
+				t.Errorf("accept: %v", err)
+				return
+			}
+			accepted <- conn
+			for _, ex := range exchanges {
+				got := make([]byte, len(ex.request))
+				if _, err := io.ReadFull(conn, got); err != nil {
+					t.Errorf("read %q: %v", ex.request, err)
+					break
+				}
+				if string(got) != ex.request {
+					t.Errorf("request frame\n got %q\nwant %q", got, ex.request)
+					break
+				}
+				io.WriteString(conn, ex.reply)
+			}
+			// Koneksi dibiarkan terbuka sampai test selesai supaya client bisa
+			// menyimpannya di pool.
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// evalFrame menyusun frame EVALSHA/EVAL untuk key "ratelimit:user:1" dengan
+// rate 0.5 token/detik dan burst 10.
+func evalFrame(command, script string) string {
+	args := []string{command, script, "1", "ratelimit:user:1", "0.5", "10"}
+	var b strings.Builder
+	b.WriteString("*6\r\n")
+	for _, arg := range args {
+		b.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+	return b.String()
+}
+
+var testLimit = Limit{PerMinute: 30, Burst: 10}
+
+func TestRedisStoreEvalSHA(t *testing.T) {
+	addr := fakeRedis(t, []exchange{
+		{evalFrame("EVALSHA", takeScriptSHA), "*2\r\n:1\r\n$1\r\n9\r\n"},
+		{evalFrame("EVALSHA", takeScriptSHA), "*2\r\n:0\r\n$4\r\n0.25\r\n"},
+	})
+	store, err := NewRedisStore("redis://" + addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Take(context.Background(), "user:1", testLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Result{Allowed: true, Limit: 10, Remaining: 9, Reset: 2 * time.Second}); got != want {
+		t.Errorf("allowed: got %+v, want %+v", got, want)
+	}
+
+	// Request kedua memakai koneksi yang sama dari pool.
+	got, err = store.Take(context.Background(), "user:1", testLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Result{Allowed: false, Limit: 10, Remaining: 0, Reset: 20 * time.Second, RetryAfter: 2 * time.Second}); got != want {
+		t.Errorf("denied: got %+v, want %+v", got, want)
+	}
+}
+
+func TestRedisStoreLoadsScriptOnNoScript(t *testing.T) {
+	addr := fakeRedis(t, []exchange{
+		{evalFrame("EVALSHA", takeScriptSHA), "-NOSCRIPT No matching script. Please use EVAL.\r\n"},
+		{evalFrame("EVAL", takeScript), "*2\r\n:1\r\n$1\r\n9\r\n"},
+	})
+	store, _ := NewRedisStore("redis://" + addr)
+
+	got, err := store.Take(context.Background(), "user:1", testLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Allowed || got.Remaining != 9 {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestRedisStoreAuthAndSelect(t *testing.T) {
+	addr := fakeRedis(t, []exchange{
+		{"*2\r\n$4\r\nAUTH\r\n$6\r\ns3cr3t\r\n", "+OK\r\n"},
+		{"*2\r\n$6\r\nSELECT\r\n$1\r\n2\r\n", "+OK\r\n"},
+		{evalFrame("EVALSHA", takeScriptSHA), "*2\r\n:1\r\n$1\r\n9\r\n"},
+	})
+	store, _ := NewRedisStore("redis://:s3cr3t@" + addr + "/2")
+
+	if _, err := store.Take(context.Background(), "user:1", testLimit); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRedisStoreAuthError(t *testing.T) {
+	addr := fakeRedis(t, []exchange{
+		{"*2\r\n$4\r\nAUTH\r\n$5\r\nwrong\r\n", "-WRONGPASS invalid username-password pair\r\n"},
+	})
+	store, _ := NewRedisStore("redis://:wrong@" + addr)
+
+	_, err := store.Take(context.Background(), "user:1", testLimit)
+	if err == nil || err.Error() != "WRONGPASS invalid username-password pair" {
+		t.Fatalf("err = %v", err)
+	}
+	if len(store.pool) != 0 {
+		t.Error("koneksi yang gagal AUTH masuk pool")
+	}
+}
+
+func TestRedisStorePoolsConnectionAfterReplyError(t *testing.T) {
+	addr := fakeRedis(t, []exchange{
+		{evalFrame("EVALSHA", takeScriptSHA), "-ERR max number of clients reached\r\n"},
+	})
+	store, _ := NewRedisStore("redis://" + addr)
+
+	_, err := store.Take(context.Background(), "user:1", testLimit)
+	var replyErr redisError
+	if !errors.As(err, &replyErr) {
+		t.Fatalf("err = %v, want redisError", err)
+	}
+	// Error reply tidak merusak posisi stream, jadi koneksi tetap dipakai.
+	if len(store.pool) != 1 {
+		t.Errorf("pool = %d koneksi, want 1", len(store.pool))
+	}
+}
+
+func TestRedisStoreDropsConnectionAfterNetworkError(t *testing.T) {
+	// Server menutup koneksi di tengah bulk string.
+	addr := fakeRedis(t, []exchange{
+		{evalFrame("EVALSHA", takeScriptSHA), "*2\r\n:1\r\n$4\r\n9"},
+	})
+	store, _ := NewRedisStore("redis://" + addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := store.Take(ctx, "user:1", testLimit); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(store.pool) != 0 {
+		t.Error("koneksi rusak dikembalikan ke pool")
+	}
+}
+
+func TestRedisStoreUnexpectedReply(t *testing.T) {
+	addr := fakeRedis(t, []exchange{
+		{evalFrame("EVALSHA", takeScriptSHA), "+OK\r\n"},
+		{evalFrame("EVALSHA", takeScriptSHA), "*2\r\n:1\r\n$3\r\nabc\r\n"},
+	})
+	store, _ := NewRedisStore("redis://" + addr)
+
+	for _, want := range []string{"redis: unexpected reply OK", `redis: unexpected tokens "abc"`} {
+		_, err := store.Take(context.Background(), "user:1", testLimit)
+		if err == nil || err.Error() != want {
+			t.Errorf("err = %v, want %q", err, want)
+		}
+	}
+}
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame string
+		want  interface{}
+		err   string
+	}{
+		{"simple string", "+OK\r\n", "OK", ""},
+		{"error", "-ERR unknown command\r\n", nil, "ERR unknown command"},
+		{"integer", ":42\r\n", int64(42), ""},
+		{"negative integer", ":-1\r\n", int64(-1), ""},
+		{"bulk string", "$5\r\nhello\r\n", "hello", ""},
+		{"bulk string with CRLF", "$7\r\nfoo\r\nba\r\n", "foo\r\nba", ""},
+		{"empty bulk string", "$0\r\n\r\n", "", ""},
+		{"null bulk string", "$-1\r\n", nil, ""},
+		{"array", "*2\r\n:1\r\n$4\r\n0.25\r\n", []interface{}{int64(1), "0.25"}, ""},
+		{"nested array", "*2\r\n*1\r\n+a\r\n:2\r\n", []interface{}{[]interface{}{"a"}, int64(2)}, ""},
+		{"empty array", "*0\r\n", []interface{}{}, ""},
+		{"null array", "*-1\r\n", nil, ""},
+		{"unknown type", "%1\r\n", nil, `redis: unknown reply type '%'`},
+		{"empty line", "\r\n", nil, "redis: empty reply"},
+		{"truncated bulk", "$5\r\nhel", nil, "unexpected EOF"},
+		{"truncated array", "*2\r\n:1\r\n", nil, "EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &redisConn{reader: bufio.NewReader(strings.NewReader(tt.frame))}
+			got, err := conn.read()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRedisStore(t *testing.T) {
+	tests := []struct {
+		url      string
+		addr     string
+		password string
+		db       int
+		err      bool
+	}{
+		{url: "redis://localhost", addr: "localhost:6379"},
+		{url: "redis://cache:6380", addr: "cache:6380"},
+		{url: "redis://:pw@cache:6379/3", addr: "cache:6379", password: "pw", db: 3},
+		{url: "redis://user:pw@cache/1", addr: "cache:6379", password: "pw", db: 1},
+		{url: "rediss://cache:6379", err: true},
+		{url: "http://cache:6379", err: true},
+		{url: "redis://", err: true},
+		{url: "redis://cache/x", err: true},
+	}
+	for _, tt := range tests {
+		store, err := NewRedisStore(tt.url)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected error", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.url, err)
+			continue
+		}
+		if store.addr != tt.addr || store.password != tt.password || store.db != tt.db {
+			t.Errorf("%s: addr %q password %q db %d", tt.url, store.addr, store.password, store.db)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package router
+
+import (
+	"log"
+	"service_components/internal/config"
+	"service_components/internal/handler"
+	"service_components/internal/metrics"
+	"service_components/internal/middleware"
+	"service_components/internal/model"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// New menyusun router HTTP: middleware global, route /api/v1, registry dan
+// dokumentasi. Package yang dipakai handler (database, storage, auth, dst.)
+// harus sudah diinisialisasi.
+func New(cfg *config.Config) *gin.Engine {
+	// gin.New tanpa logger teks bawaan; access log dan panic dicatat sebagai
+	// JSON oleh RequestID dan Recovery.
+	router := gin.New()
+	handler.PublicURL = cfg.PublicURL
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("FATAL: invalid TRUSTED_PROXIES: %v", err)
+	}
+	router.Use(middleware.RequestID(), middleware.Recovery())
+	router.Use(middleware.Metrics())
+	// /metrics didaftarkan sebelum Authenticate supaya METRICS_TOKEN tidak
+	// diperiksa sebagai access token user.
+	if cfg.MetricsEnabled && cfg.MetricsPort == "" {
+		router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowAllOrigins = true
+	corsConfig.AddAllowHeaders("Authorization", middleware.RequestIDHeader)
+	corsConfig.AddExposeHeaders(middleware.RequestIDHeader, "Deprecation", "Sunset", "Link", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After")
+	router.Use(cors.New(corsConfig))
+	router.Use(middleware.Authenticate())
+	router.Use(middleware.RateLimit())
+	router.Use(middleware.OpenAPIValidator())
+
+	// Scope membatasi request yang memakai API key; write dan review juga
+	// menolak request anonim.
+	read := middleware.RequireScope(model.ScopeComponentsRead)
+	write := middleware.RequireScope(model.ScopeComponentsWrite)
+	review := middleware.RequireScope(model.ScopeReview)
+
+	api := router.Group("/api/v1")
+	{
+		api.GET("/health", handler.HealthCheck)
+
+		api.POST("/auth/register", handler.Register)
+		api.POST("/auth/login", handler.Login)
+		api.POST("/auth/refresh", handler.RefreshToken)
+		api.POST("/auth/logout", middleware.RequireAuth(), handler.Logout)
+		api.POST("/auth/password/forgot", handler.ForgotPassword)
+		api.POST("/auth/password/reset", handler.ResetPassword)
+		api.GET("/auth/oidc/login", handler.OIDCLogin)
+		api.GET("/auth/oidc/callback", handler.OIDCCallback)
+		api.GET("/me", read, middleware.RequireAuth(), handler.GetMe)
+
+		api.POST("/components", write, middleware.RequireAuth(), handler.CreateComponent)
+		api.GET("/components", read, handler.GetAllComponents)
+		api.GET("/components/:slug", read, handler.GetComponentBySlug)
+		api.PATCH("/components/:slug", write, middleware.RequireAuth(), handler.UpdateComponentBySlug)
+		api.DELETE("/components/:slug", write, middleware.RequireAuth(), handler.DeleteComponentBySlug)
+		api.POST("/components/:slug/tags", write, middleware.RequireAuth(), handler.AddComponentTag)
+		api.GET("/components/:slug/variants", read, handler.GetComponentVariants)
+		api.PUT("/components/:slug/variants/:framework", write, middleware.RequireAuth(), handler.UpsertComponentVariant)
+		api.DELETE("/components/:slug/variants/:framework", write, middleware.RequireAuth(), handler.DeleteComponentVariant)
+		api.GET("/components/:slug/files", read, handler.GetComponentFiles)
+		api.POST("/components/:slug/files", write, middleware.RequireAuth(), handler.AddComponentFile)
+		api.PATCH("/components/:slug/files/:id", write, middleware.RequireAuth(), handler.UpdateComponentFile)
+		api.DELETE("/components/:slug/files/:id", write, middleware.RequireAuth(), handler.DeleteComponentFile)
+		api.GET("/components/:slug/download", read, handler.DownloadComponent)
+		api.POST("/components/:slug/fork", write, middleware.RequireAuth(), handler.ForkComponent)
+		api.GET("/components/:slug/forks", read, handler.GetComponentForks)
+		api.PATCH("/components/:slug/visibility", write, middleware.RequireAuth(), handler.UpdateComponentVisibility)
+		api.GET("/components/:slug/share-links", read, handler.GetShareLinks)
+		api.POST("/components/:slug/share-links", write, middleware.RequireAuth(), handler.CreateShareLink)
+		api.DELETE("/components/:slug/share-links/:id", write, middleware.RequireAuth(), handler.RevokeShareLink)
+		api.GET("/shared/:token", handler.GetSharedComponent)
+		api.PUT("/components/:slug/deprecation", write, middleware.RequireAuth(), handler.DeprecateComponent)
+		api.DELETE("/components/:slug/deprecation", write, middleware.RequireAuth(), handler.UndeprecateComponent)
+		api.GET("/components/:slug/releases", read, handler.GetComponentReleases)
+		api.POST("/components/:slug/releases", write, middleware.RequireAuth(), handler.CreateComponentRelease)
+		api.GET("/components/:slug/assets", read, handler.GetComponentAssets)
+		api.POST("/components/:slug/assets", write, middleware.RequireAuth(), handler.UploadComponentAssets)
+		api.DELETE("/components/:slug/assets/:id", write, middleware.RequireAuth(), handler.DeleteComponentAsset)
+		api.GET("/assets/:id/download", read, handler.DownloadAsset)
+
+		api.GET("/components/:slug/screenshots", read, handler.GetComponentScreenshots)
+		api.POST("/components/:slug/screenshots", write, middleware.RequireAuth(), handler.UploadComponentScreenshots)
+		api.PUT("/components/:slug/screenshots/order", write, middleware.RequireAuth(), handler.ReorderComponentScreenshots)
+		api.PATCH("/components/:slug/screenshots/:id", write, middleware.RequireAuth(), handler.UpdateComponentScreenshot)
+		api.DELETE("/components/:slug/screenshots/:id", write, middleware.RequireAuth(), handler.DeleteComponentScreenshot)
+		api.GET("/screenshots/:id/:size", read, handler.DownloadScreenshot)
+
+		api.POST("/api-keys", handler.CreateAPIKey)
+		api.GET("/api-keys", handler.GetAPIKeys)
+		api.POST("/api-keys/:id/rotate", handler.RotateAPIKey)
+		api.DELETE("/api-keys/:id", handler.RevokeAPIKey)
+
+		api.GET("/audit", handler.GetAuditEvents)
+
+		api.GET("/events/stream", read, handler.StreamEvents)
+
+		api.POST("/graphql", read, handler.GraphQL(router))
+		api.GET("/graphql", read, handler.GraphQL(router))
+
+		api.POST("/webhooks", handler.CreateWebhook)
+		api.GET("/webhooks", handler.GetWebhooks)
+		api.GET("/webhooks/:id", handler.GetWebhook)
+		api.PATCH("/webhooks/:id", handler.UpdateWebhook)
+		api.DELETE("/webhooks/:id", handler.DeleteWebhook)
+		api.GET("/webhooks/:id/deliveries", handler.GetWebhookDeliveries)
+		api.POST("/webhooks/:id/deliveries/:delivery_id/replay", handler.ReplayWebhookDelivery)
+
+		api.POST("/workspaces", write, middleware.RequireAuth(), handler.CreateWorkspace)
+		api.GET("/workspaces", read, handler.GetMyWorkspaces)
+		api.GET("/workspaces/:workspace", read, handler.GetWorkspace)
+		api.POST("/workspaces/:workspace/members", write, middleware.RequireAuth(), handler.AddWorkspaceMember)
+		api.DELETE("/workspaces/:workspace/members/:user_id", write, middleware.RequireAuth(), handler.RemoveWorkspaceMember)
+
+		api.POST("/categories", write, middleware.RequireAuth(), handler.CreateCategory)
+		api.GET("/categories", read, handler.GetAllCategories)
+
+		api.PATCH("/components/:slug/status", review, middleware.RequireAuth(), handler.UpdateComponentStatus)
+		api.PATCH("/components/:slug/approval", review, middleware.RequireAuth(), handler.UpdateComponentApproval)
+
+		api.POST("/tags", write, middleware.RequireAuth(), handler.CreateTag)
+		api.GET("/tags", read, handler.GetAllTags)
+
+		api.GET("/export", read, handler.ExportCatalog)
+		api.POST("/import", write, middleware.RequireAuth(), handler.ImportCatalog)
+	}
+
+	// shadcn-compatible registry, e.g. npx shadcn add http://localhost:8080/registry/button.json
+	router.GET("/registry/:file", read, handler.GetRegistryFile)
+
+	// Spesifikasi OpenAPI dan Swagger UI yang menampilkannya
+	router.GET("/openapi.yaml", handler.GetOpenAPISpec)
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.yaml")))
+
+	return router
+}
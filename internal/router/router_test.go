@@ -0,0 +1,367 @@
+package router_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"service_components/docs"
+	"service_components/internal/auth"
+	"service_components/internal/config"
+	"service_components/internal/database"
+	"service_components/internal/events"
+	"service_components/internal/graphql"
+	"service_components/internal/model"
+	"service_components/internal/openapi"
+	"service_components/internal/ratelimit"
+	"service_components/internal/router"
+	"service_components/internal/storage"
+	"service_components/internal/testdb"
+	"service_components/internal/webhook"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TestRoutesMatchOpenAPI menjalankan router dengan OPENAPI_VALIDATION=test
+// terhadap setiap route /api/v1. Response yang tidak sesuai docs/openapi.yaml
+// atau route yang tidak terdokumentasi menjadi 500, sehingga status yang
+// diharapkan hanya didapat jika handler dan spesifikasi cocok.
+func TestRoutesMatchOpenAPI(t *testing.T) {
+	api := newTestAPI(t)
+
+	api.do(200, "GET /api/v1/health", "/api/v1/health", nil)
+
+	reg := api.do(201, "POST /api/v1/auth/register", "/api/v1/auth/register", map[string]string{"email": "jane@example.com", "password": "correct-horse", "name": "Jane"})
+	api.token = str(reg, "data", "access_token")
+	database.DB.Model(&model.User{}).Where("email = ?", "jane@example.com").Update("role", "admin")
+	api.do(200, "POST /api/v1/auth/login", "/api/v1/auth/login", map[string]string{"email": "jane@example.com", "password": "correct-horse"})
+	api.do(400, "POST /api/v1/auth/login", "/api/v1/auth/login", map[string]any{"email": 5})
+	refreshed := api.do(200, "POST /api/v1/auth/refresh", "/api/v1/auth/refresh", map[string]string{"refresh_token": str(reg, "data", "refresh_token")})
+	api.token = str(refreshed, "data", "access_token")
+	api.do(202, "POST /api/v1/auth/password/forgot", "/api/v1/auth/password/forgot", map[string]string{"email": "jane@example.com"})
+	api.do(400, "POST /api/v1/auth/password/reset", "/api/v1/auth/password/reset", map[string]string{"token": "unknown", "password": "another-horse"})
+	api.do(404, "GET /api/v1/auth/oidc/login", "/api/v1/auth/oidc/login", nil)
+	api.do(404, "GET /api/v1/auth/oidc/callback", "/api/v1/auth/oidc/callback?code=x&state=y", nil)
+	api.do(200, "GET /api/v1/me", "/api/v1/me", nil)
+
+	category := api.do(201, "POST /api/v1/categories", "/api/v1/categories", map[string]string{"name": "Buttons"})
+	api.do(200, "GET /api/v1/categories", "/api/v1/categories", nil)
+	tag := api.do(201, "POST /api/v1/tags", "/api/v1/tags", map[string]string{"name": "primary"})
+	api.do(200, "GET /api/v1/tags", "/api/v1/tags", nil)
+
+	api.do(400, "POST /api/v1/components", "/api/v1/components", map[string]any{"name": "Button", "category_id": "nope"})
+	component := api.do(201, "POST /api/v1/components", "/api/v1/components", map[string]any{
+		"name":             "Button",
+		"category_id":      str(category, "data", "id"),
+		"code_jsx":         "export default function Button() { return <button/> }",
+		"code_css":         ".button {}",
+		"props_definition": map[string]string{"size": "string"},
+	})
+	slug := str(component, "data", "slug")
+	base := "/api/v1/components/" + slug
+
+	api.do(200, "GET /api/v1/components", "/api/v1/components", nil)
+	api.do(400, "GET /api/v1/components", "/api/v1/components?limit=abc", nil)
+	api.do(200, "GET /api/v1/components/:slug", base, nil)
+	api.do(200, "PATCH /api/v1/components/:slug", base, map[string]any{"description": "A button"})
+	api.do(200, "POST /api/v1/components/:slug/tags", base+"/tags", map[string]string{"tag_id": str(tag, "data", "id")})
+
+	api.do(200, "GET /api/v1/components/:slug/variants", base+"/variants", nil)
+	api.do(200, "PUT /api/v1/components/:slug/variants/:framework", base+"/variants/vue", map[string]string{"code": "<template><button/></template>"})
+	api.do(204, "DELETE /api/v1/components/:slug/variants/:framework", base+"/variants/vue", nil)
+
+	api.do(200, "GET /api/v1/components/:slug/files", base+"/files", nil)
+	file := api.do(201, "POST /api/v1/components/:slug/files", base+"/files", map[string]string{"path": "useButton.ts", "role": "hook", "content": "export {}"})
+	api.do(200, "PATCH /api/v1/components/:slug/files/:id", base+"/files/"+str(file, "data", "id"), map[string]string{"content": "export const x = 1"})
+	api.do(204, "DELETE /api/v1/components/:slug/files/:id", base+"/files/"+str(file, "data", "id"), nil)
+	api.do(400, "DELETE /api/v1/components/:slug/files/:id", base+"/files/not-a-uuid", nil)
+	api.raw(200, "GET /api/v1/components/:slug/download", base+"/download", nil, "")
+
+	api.do(201, "POST /api/v1/components/:slug/releases", base+"/releases", map[string]string{"version": "1.0.0", "changelog": "First release"})
+	api.do(200, "GET /api/v1/components/:slug/releases", base+"/releases", nil)
+
+	fork := api.do(201, "POST /api/v1/components/:slug/fork", base+"/fork", nil)
+	forkBase := "/api/v1/components/" + str(fork, "data", "slug")
+	api.do(200, "GET /api/v1/components/:slug/forks", base+"/forks", nil)
+	api.do(200, "PUT /api/v1/components/:slug/deprecation", forkBase+"/deprecation", map[string]string{"message": "Use button", "replaced_by": slug, "sunset_at": "2030-01-01"})
+	api.do(200, "DELETE /api/v1/components/:slug/deprecation", forkBase+"/deprecation", nil)
+
+	api.do(200, "PATCH /api/v1/components/:slug/visibility", base+"/visibility", map[string]string{"visibility": "public"})
+	share := api.do(201, "POST /api/v1/components/:slug/share-links", base+"/share-links", map[string]int{"expires_in_hours": 2})
+	api.do(200, "GET /api/v1/components/:slug/share-links", base+"/share-links", nil)
+	api.do(200, "GET /api/v1/shared/:token", "/api/v1/shared/"+str(share, "data", "token"), nil)
+	api.do(204, "DELETE /api/v1/components/:slug/share-links/:id", base+"/share-links/"+str(share, "data", "id"), nil)
+
+	assets := api.upload(201, "POST /api/v1/components/:slug/assets", base+"/assets", "icon.svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), nil)
+	api.do(200, "GET /api/v1/components/:slug/assets", base+"/assets", nil)
+	asset := first(t, assets)
+	api.raw(200, "GET /api/v1/assets/:id/download", asset["url"].(string), nil, "")
+	api.do(204, "DELETE /api/v1/components/:slug/assets/:id", base+"/assets/"+asset["id"].(string), nil)
+
+	screenshots := api.upload(201, "POST /api/v1/components/:slug/screenshots", base+"/screenshots", "shot.png", pngPixel, map[string]string{"theme": "dark"})
+	api.do(200, "GET /api/v1/components/:slug/screenshots", base+"/screenshots", nil)
+	screenshot := first(t, screenshots)
+	id := screenshot["id"].(string)
+	api.raw(200, "GET /api/v1/screenshots/:id/:size", screenshot["thumbnail_url"].(string), nil, "")
+	api.do(200, "PATCH /api/v1/components/:slug/screenshots/:id", base+"/screenshots/"+id, map[string]any{"caption": "Dark", "is_primary": true})
+	api.do(200, "PUT /api/v1/components/:slug/screenshots/order", base+"/screenshots/order", map[string]any{"ids": []string{id}})
+	api.do(204, "DELETE /api/v1/components/:slug/screenshots/:id", base+"/screenshots/"+id, nil)
+
+	api.do(200, "PATCH /api/v1/components/:slug/status", base+"/status", map[string]string{"status": "published"})
+	api.do(200, "PATCH /api/v1/components/:slug/approval", base+"/approval", map[string]string{"approval_status": "approved"})
+
+	key := api.do(201, "POST /api/v1/api-keys", "/api/v1/api-keys", map[string]any{"name": "ci", "scopes": []string{"components:read"}})
+	api.do(200, "GET /api/v1/api-keys", "/api/v1/api-keys", nil)
+	api.do(200, "POST /api/v1/api-keys/:id/rotate", "/api/v1/api-keys/"+str(key, "data", "id")+"/rotate", nil)
+	api.do(204, "DELETE /api/v1/api-keys/:id", "/api/v1/api-keys/"+str(key, "data", "id"), nil)
+
+	api.do(200, "GET /api/v1/audit", "/api/v1/audit?limit=5", nil)
+	api.do(400, "GET /api/v1/audit", "/api/v1/audit?from=yesterday", nil)
+
+	hook := api.do(201, "POST /api/v1/webhooks", "/api/v1/webhooks", map[string]any{"url": "https://example.com/hook", "events": []string{"*"}})
+	hookBase := "/api/v1/webhooks/" + str(hook, "data", "id")
+	api.do(200, "GET /api/v1/webhooks", "/api/v1/webhooks", nil)
+	api.do(200, "GET /api/v1/webhooks/:id", hookBase, nil)
+	api.do(200, "GET /api/v1/webhooks/:id/deliveries", hookBase+"/deliveries", nil)
+	api.do(404, "POST /api/v1/webhooks/:id/deliveries/:delivery_id/replay", hookBase+"/deliveries/"+uuid.NewString()+"/replay", nil)
+	api.do(200, "PATCH /api/v1/webhooks/:id", hookBase, map[string]any{"active": false})
+	api.do(204, "DELETE /api/v1/webhooks/:id", hookBase, nil)
+
+	workspace := api.do(201, "POST /api/v1/workspaces", "/api/v1/workspaces", map[string]string{"name": "Acme"})
+	workspaceBase := "/api/v1/workspaces/" + str(workspace, "data", "slug")
+	api.do(200, "GET /api/v1/workspaces", "/api/v1/workspaces", nil)
+	api.do(200, "GET /api/v1/workspaces/:workspace", workspaceBase, nil)
+	token := api.token
+	bob := api.do(201, "POST /api/v1/auth/register", "/api/v1/auth/register", map[string]string{"email": "bob@example.com", "password": "correct-horse", "name": "Bob"})
+	api.token = token
+	bobID := str(bob, "data", "user", "id")
+	api.do(200, "POST /api/v1/workspaces/:workspace/members", workspaceBase+"/members", map[string]string{"user_id": bobID})
+	api.do(204, "DELETE /api/v1/workspaces/:workspace/members/:user_id", workspaceBase+"/members/"+bobID, nil)
+
+	export := api.raw(200, "GET /api/v1/export", "/api/v1/export?format=ndjson", nil, "")
+	api.raw(200, "POST /api/v1/import", "/api/v1/import?dry_run=true", export, "application/x-ndjson")
+
+	api.do(200, "POST /api/v1/graphql", "/api/v1/graphql", map[string]string{"query": "{ categories { slug } }"})
+	api.do(200, "GET /api/v1/graphql", "/api/v1/graphql?query=%7B+tags+%7B+slug+%7D+%7D", nil)
+	api.do(400, "GET /api/v1/graphql", "/api/v1/graphql", nil)
+
+	api.stream("GET /api/v1/events/stream", "/api/v1/events/stream")
+
+	api.do(204, "DELETE /api/v1/components/:slug", forkBase, nil)
+	api.do(204, "POST /api/v1/auth/logout", "/api/v1/auth/logout", nil)
+
+	for _, route := range api.engine.Routes() {
+		name := route.Method + " " + route.Path
+		if strings.HasPrefix(route.Path, "/api/v1/") && !api.hits[name] {
+			t.Errorf("%s is not covered by this test", name)
+		}
+	}
+}
+
+// TestGraphQLMutationsChargeRateLimit memastikan mutation GraphQL, yang
+// dijalankan in-process tanpa middleware RateLimit, tetap menghabiskan token
+// write: satu untuk request /graphql dan satu untuk setiap mutation berikutnya.
+func TestGraphQLMutationsChargeRateLimit(t *testing.T) {
+	api := newTestAPI(t)
+	reg := api.do(201, "POST /api/v1/auth/register", "/api/v1/auth/register", map[string]string{"email": "jane@example.com", "password": "correct-horse", "name": "Jane"})
+	api.token = str(reg, "data", "access_token")
+	database.DB.Model(&model.User{}).Where("email = ?", "jane@example.com").Update("role", "admin")
+
+	enabled, limits, store := ratelimit.Enabled, ratelimit.Limits, ratelimit.Default
+	t.Cleanup(func() { ratelimit.Enabled, ratelimit.Limits, ratelimit.Default = enabled, limits, store })
+	ratelimit.Enabled = true
+	ratelimit.Limits = map[string]ratelimit.Limit{"write": {PerMinute: 1, Burst: 3}}
+	ratelimit.Default = ratelimit.NewMemoryStore()
+
+	query := `mutation { a: createTag(name: "a") { slug } b: createTag(name: "b") { slug } c: createTag(name: "c") { slug } d: createTag(name: "d") { slug } }`
+	response := decode(t, api.raw(200, "POST /api/v1/graphql", "/api/v1/graphql", []byte(`{"query": `+strconv.Quote(query)+`}`), "application/json"))
+	data, _ := response["data"].(map[string]any)
+	for _, field := range []string{"a", "b", "c"} {
+		if data[field] == nil {
+			t.Errorf("%s: no data in %v", field, response)
+		}
+	}
+	if data["d"] != nil {
+		t.Errorf("d: got %v, want rate limited", data["d"])
+	}
+	errs, _ := response["errors"].([]any)
+	if len(errs) != 1 || str(errs[0].(map[string]any), "extensions", "code") != "RATE_LIMITED" {
+		t.Errorf("errors = %v, want one RATE_LIMITED", errs)
+	}
+}
+
+// TestOpenAPIOperationsAreRouted memastikan setiap operasi di
+// docs/openapi.yaml punya route di router.
+func TestOpenAPIOperationsAreRouted(t *testing.T) {
+	api := newTestAPI(t)
+	spec, err := openapi.Load(docs.OpenAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routes := map[string]bool{}
+	for _, route := range api.engine.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+
+	param := regexp.MustCompile(`\{([^}]+)\}`)
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			name := method + " /api/v1" + param.ReplaceAllString(path, ":$1")
+			if !routes[name] {
+				t.Errorf("%s is documented but not routed", name)
+			}
+		}
+	}
+}
+
+type testAPI struct {
+	t      *testing.T
+	engine *gin.Engine
+	token  string
+	hits   map[string]bool
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Setenv("DATABASE_URL", "sqlite")
+	t.Setenv("OPENAPI_VALIDATION", openapi.ModeTest)
+	t.Setenv("STORAGE_PATH", t.TempDir())
+	t.Setenv("URL_SIGNING_KEY", "test-signing-key")
+	t.Setenv("RATE_LIMIT_ENABLED", "false")
+	t.Setenv("EVENT_PUBLISHERS", "memory")
+	t.Setenv("METRICS_ENABLED", "false")
+	cfg := config.LoadConfig()
+
+	gin.SetMode(gin.TestMode)
+	database.DB = testdb.Open(t,
+		&model.Category{}, &model.Tag{}, &model.Component{}, &model.ComponentVariant{}, &model.ComponentFile{},
+		&model.Asset{}, &model.Screenshot{}, &model.ComponentRelease{}, &model.Workspace{}, &model.WorkspaceMember{},
+		&model.ShareLink{}, &model.User{}, &model.Session{}, &model.PasswordReset{}, &model.UserIdentity{},
+		&model.OIDCLogin{}, &model.APIKey{}, &model.AuditEvent{}, &model.Webhook{}, &model.WebhookDelivery{},
+		&model.OutboxEvent{},
+	)
+	storage.InitStorage(cfg)
+	auth.InitAuth(cfg)
+	ratelimit.InitRateLimit(cfg)
+	webhook.InitWebhook(cfg)
+	events.InitEvents(cfg, database.DB)
+	graphql.InitGraphQL(cfg)
+	openapi.InitOpenAPI(cfg)
+
+	return &testAPI{t: t, engine: router.New(cfg), hits: map[string]bool{}}
+}
+
+// do mengirim body sebagai JSON dan mengembalikan response yang sudah
+// di-decode. route adalah template gin yang dicakup request ini.
+func (a *testAPI) do(want int, route, path string, body any) map[string]any {
+	a.t.Helper()
+	var data []byte
+	contentType := ""
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			a.t.Fatal(err)
+		}
+		contentType = "application/json"
+	}
+	return decode(a.t, a.raw(want, route, path, data, contentType))
+}
+
+func (a *testAPI) upload(want int, route, path, name string, content []byte, fields map[string]string) map[string]any {
+	a.t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	part.Write(content)
+	for key, value := range fields {
+		w.WriteField(key, value)
+	}
+	w.Close()
+	return decode(a.t, a.raw(want, route, path, body.Bytes(), w.FormDataContentType()))
+}
+
+func (a *testAPI) raw(want int, route, path string, body []byte, contentType string) []byte {
+	a.t.Helper()
+	method, _, _ := strings.Cut(route, " ")
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if a.token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	}
+
+	w := httptest.NewRecorder()
+	a.engine.ServeHTTP(w, req)
+	a.hits[route] = true
+	if w.Code != want {
+		a.t.Errorf("%s %s: got %d, want %d: %s", method, path, w.Code, want, w.Body.String())
+	}
+	return w.Body.Bytes()
+}
+
+// stream membuka stream SSE sebentar; handler berhenti saat context request
+// selesai.
+func (a *testAPI) stream(route, path string) {
+	a.t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+a.token)
+
+	w := httptest.NewRecorder()
+	a.engine.ServeHTTP(w, req)
+	a.hits[route] = true
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+		a.t.Errorf("GET %s: got %d %s", path, w.Code, w.Header().Get("Content-Type"))
+	}
+}
+
+func decode(t *testing.T, body []byte) map[string]any {
+	t.Helper()
+	var out map[string]any
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &out); err != nil {
+			t.Fatalf("invalid JSON response %q: %v", body, err)
+		}
+	}
+	return out
+}
+
+func str(m map[string]any, keys ...string) string {
+	var cur any = m
+	for _, key := range keys {
+		obj, _ := cur.(map[string]any)
+		cur = obj[key]
+	}
+	s, _ := cur.(string)
+	return s
+}
+
+func first(t *testing.T, response map[string]any) map[string]any {
+	t.Helper()
+	list, _ := response["data"].([]any)
+	if len(list) == 0 {
+		t.Fatalf("expected at least one item in %v", response)
+	}
+	return list[0].(map[string]any)
+}
+
+// pngPixel adalah PNG 1x1 transparan.
+var pngPixel = []byte{
+	0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a,
+	0, 0, 0, 0x0d, 'I', 'H', 'D', 'R', 0, 0, 0, 1, 0, 0, 0, 1, 8, 6, 0, 0, 0, 0x1f, 0x15, 0xc4, 0x89,
+	0, 0, 0, 0x0a, 'I', 'D', 'A', 'T', 0x78, 0x9c, 0x63, 0, 1, 0, 0, 5, 0, 1, 0x0d, 0x0a, 0x2d, 0xb4,
+	0, 0, 0, 0, 'I', 'E', 'N', 'D', 0xae, 0x42, 0x60, 0x82,
+}
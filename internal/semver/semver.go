@@ -0,0 +1,52 @@
+// Package semver mem-parsing dan membandingkan versi rilis komponen dengan
+// format MAJOR.MINOR.PATCH.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// Parse menerima "1.2.3" atau "v1.2.3". Pre-release dan build metadata tidak
+// didukung.
+func Parse(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q: expected MAJOR.MINOR.PATCH", s)
+	}
+
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || strings.Trim(part, "0123456789") != "" || (len(part) > 1 && part[0] == '0') {
+			return Version{}, fmt.Errorf("invalid version %q: expected MAJOR.MINOR.PATCH", s)
+		}
+		nums[i] = n
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare mengembalikan -1, 0 atau 1 jika v lebih kecil, sama atau lebih
+// besar dari other.
+func (v Version) Compare(other Version) int {
+	for _, d := range [3]int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
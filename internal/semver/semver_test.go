@@ -0,0 +1,67 @@
+package semver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	valid := map[string]Version{
+		"0.0.0":    {0, 0, 0},
+		"1.2.3":    {1, 2, 3},
+		"v1.2.3":   {1, 2, 3},
+		"10.20.30": {10, 20, 30},
+		"2.0.100":  {2, 0, 100},
+		"v0.10.0":  {0, 10, 0},
+	}
+	for input, want := range valid {
+		got, err := Parse(input)
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+
+	invalid := []string{
+		"", "1", "1.2", "1.2.3.4", "v", "V1.2.3", "vv1.2.3",
+		"01.2.3", "1.02.3", "1.2.03",
+		"1.2.x", "-1.2.3", "+1.2.3", "1.2.3-beta", "1.2.3+build", " 1.2.3", "1..3",
+		"99999999999999999999.0.0",
+	}
+	for _, input := range invalid {
+		if got, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", input, got)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	v, err := Parse("v1.20.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.String(); got != "1.20.3" {
+		t.Errorf("String() = %q, want 1.20.3", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3", "v1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.2.10", "1.2.9", 1},
+		{"1.10.0", "1.9.99", 1},
+		{"2.0.0", "1.99.99", 1},
+		{"0.9.9", "1.0.0", -1},
+	}
+	for _, tt := range tests {
+		a, _ := Parse(tt.a)
+		b, _ := Parse(tt.b)
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := b.Compare(a); got != -tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"log/slog"
+	"service_components/internal/config"
+	"strconv"
+	"time"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore menyimpan isi asset dan screenshot berdasarkan key. Key dibentuk
+// dari hash isi file sehingga upload yang sama hanya disimpan sekali, juga
+// jika dipakai asset dan screenshot sekaligus.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	Store         BlobStore
+	MaxUploadSize int64
+	SignedURLTTL  time.Duration
+	signingKey    []byte
+)
+
+func InitStorage(cfg *config.Config) {
+	switch cfg.StorageDriver {
+	case "s3":
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+			log.Fatal("FATAL: S3_ENDPOINT and S3_BUCKET are required for the s3 storage driver")
+		}
+		Store = &S3Store{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		}
+	case "local", "":
+		Store = &LocalStore{Root: cfg.StoragePath}
+	default:
+		log.Fatalf("FATAL: unknown STORAGE_DRIVER %q", cfg.StorageDriver)
+	}
+
+	MaxUploadSize = cfg.MaxUploadSize
+	SignedURLTTL = cfg.SignedURLTTL
+	signingKey = []byte(cfg.URLSigningKey)
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			log.Fatalf("FATAL: failed to generate URL signing key: %v", err)
+		}
+		slog.Warn("URL_SIGNING_KEY not set, signed URLs will not survive a restart")
+	}
+}
+
+// HashKey mengembalikan hash sha256 isi file dan key penyimpanannya.
+func HashKey(data []byte) (string, string) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	return hash, "sha256/" + hash[:2] + "/" + hash
+}
+
+// Sign membuat signature untuk URL download yang berlaku sampai expires.
+func Sign(id string, expires time.Time) string {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(id + "." + strconv.FormatInt(expires.Unix(), 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify mengecek signature dan masa berlaku URL download.
+func Verify(id, expires, signature string) bool {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return false
+	}
+	expected := Sign(id, time.Unix(unix, 0))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,105 @@
+// Package testdb membuka database SQLite in-memory untuk test yang butuh
+// GORM. AutoMigrate tidak bisa dipakai karena default gen_random_uuid() khusus
+// Postgres, jadi tabel dibuat dari schema GORM dan ID UUID diisi lewat
+// callback. Driver SQLite butuh cgo.
+package testdb
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// Open membuat database baru untuk t dengan tabel dari models, ditambah tabel
+// join component_tags. Database ditutup saat test selesai.
+func Open(t testing.TB, models ...interface{}) *gorm.DB {
+	t.Helper()
+	dsn := "file:" + url.PathEscape(t.Name()) + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.Callback().Create().Before("gorm:create").Register("testdb:uuid", setUUID); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := &sync.Map{}
+	for _, m := range models {
+		s, err := schema.Parse(m, cache, db.NamingStrategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var columns []string
+		for _, field := range s.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			column := `"` + field.DBName + `" ` + columnType(field.DataType)
+			if field.PrimaryKey {
+				column += " PRIMARY KEY"
+			}
+			if field.DefaultValue != "" && !strings.Contains(field.DefaultValue, "(") {
+				column += " DEFAULT '" + strings.Trim(field.DefaultValue, "'") + "'"
+			}
+			columns = append(columns, column)
+		}
+		if err := db.Exec("CREATE TABLE " + s.Table + " (" + strings.Join(columns, ", ") + ")").Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Exec("CREATE TABLE component_tags (component_id text, tag_id text)").Error; err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// setUUID mengisi ID UUID yang masih kosong, pengganti gen_random_uuid().
+func setUUID(tx *gorm.DB) {
+	if tx.Statement.Schema == nil {
+		return
+	}
+	field := tx.Statement.Schema.LookUpField("ID")
+	if field == nil || field.FieldType != reflect.TypeOf(uuid.UUID{}) {
+		return
+	}
+	set := func(v reflect.Value) {
+		if _, zero := field.ValueOf(tx.Statement.Context, v); zero {
+			field.Set(tx.Statement.Context, v, uuid.New())
+		}
+	}
+	switch value := tx.Statement.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			set(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		set(value)
+	}
+}
+
+func columnType(dataType schema.DataType) string {
+	switch dataType {
+	case schema.Int, schema.Uint:
+		return "integer"
+	case schema.Bool:
+		return "boolean"
+	case schema.Time:
+		return "datetime"
+	default:
+		return "text"
+	}
+}
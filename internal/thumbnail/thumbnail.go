@@ -0,0 +1,102 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	_ "image/gif"
+)
+
+// MaxPixels membatasi ukuran gambar yang mau di-decode supaya file kecil
+// dengan dimensi raksasa tidak menghabiskan memori.
+const MaxPixels = 40_000_000
+
+var ErrTooLarge = errors.New("image dimensions are too large")
+
+// Info membaca dimensi dan format gambar tanpa men-decode seluruh pixel.
+func Info(data []byte) (width, height int, format string, err error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return 0, 0, "", ErrTooLarge
+	}
+	return cfg.Width, cfg.Height, format, nil
+}
+
+// Generate membuat thumbnail dengan lebar maksimal maxWidth (rasio dijaga).
+// Sumber PNG dan GIF menghasilkan PNG supaya transparansi tidak hilang,
+// selain itu JPEG.
+func Generate(data []byte, maxWidth int) ([]byte, string, error) {
+	if _, _, _, err := Info(data); err != nil {
+		return nil, "", err
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	dst := resize(src, maxWidth)
+
+	var buf bytes.Buffer
+	if format == "png" || format == "gif" {
+		err = png.Encode(&buf, dst)
+		return buf.Bytes(), "image/png", err
+	}
+	err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80})
+	return buf.Bytes(), "image/jpeg", err
+}
+
+// resize memperkecil gambar dengan rata-rata area (box filter): setiap pixel
+// tujuan adalah rata-rata (premultiplied alpha) semua pixel sumber yang
+// tertutup olehnya. Gambar
+// yang sudah lebih kecil dari maxWidth hanya disalin.
+func resize(src image.Image, maxWidth int) *image.NRGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+
+	if sw <= maxWidth {
+		dst := image.NewNRGBA(image.Rect(0, 0, sw, sh))
+		draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+		return dst
+	}
+
+	dw := maxWidth
+	dh := max(1, sh*dw/sw)
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		y0 := b.Min.Y + y*sh/dh
+		y1 := max(y0+1, b.Min.Y+(y+1)*sh/dh)
+		for x := 0; x < dw; x++ {
+			x0 := b.Min.X + x*sw/dw
+			x1 := max(x0+1, b.Min.X+(x+1)*sw/dw)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					r16, g16, b16, a16 := src.At(sx, sy).RGBA()
+					r += uint64(r16)
+					g += uint64(g16)
+					bl += uint64(b16)
+					a += uint64(a16)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
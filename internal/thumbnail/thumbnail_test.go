@@ -0,0 +1,160 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// encode membuat gambar w x h dengan gradasi dalam format tersebut.
+func encode(t *testing.T, format string, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(&buf, img)
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, nil)
+	case "gif":
+		err = gif.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerateSizes(t *testing.T) {
+	sizes := []struct {
+		name   string
+		w, h   int
+		tw, th int
+	}{
+		{"landscape", 800, 600, 400, 300},
+		{"portrait", 600, 1200, 400, 800},
+		{"square", 1000, 1000, 400, 400},
+		{"odd ratio", 1001, 333, 400, 133},
+		{"panorama", 4000, 5, 400, 1},
+		{"exact width", 400, 250, 400, 250},
+		{"smaller than the thumbnail", 120, 80, 120, 80},
+	}
+	formats := []struct {
+		format      string
+		contentType string
+		encoded     string
+	}{
+		{"png", "image/png", "png"},
+		{"gif", "image/png", "png"},
+		{"jpeg", "image/jpeg", "jpeg"},
+	}
+	for _, f := range formats {
+		for _, size := range sizes {
+			t.Run(f.format+"/"+size.name, func(t *testing.T) {
+				data := encode(t, f.format, size.w, size.h)
+				w, h, format, err := Info(data)
+				if err != nil || w != size.w || h != size.h || format != f.format {
+					t.Fatalf("Info = %d x %d %s, %v", w, h, format, err)
+				}
+
+				thumb, contentType, err := Generate(data, 400)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if contentType != f.contentType {
+					t.Errorf("content type %s, want %s", contentType, f.contentType)
+				}
+				cfg, encoded, err := image.DecodeConfig(bytes.NewReader(thumb))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if encoded != f.encoded || cfg.Width != size.tw || cfg.Height != size.th {
+					t.Errorf("thumbnail %d x %d %s, want %d x %d %s", cfg.Width, cfg.Height, encoded, size.tw, size.th, f.encoded)
+				}
+			})
+		}
+	}
+}
+
+// Box filter: papan catur hitam-putih 2x2 per pixel tujuan menjadi abu-abu,
+// dan alpha dirata-rata tanpa warna pixel transparan ikut menggelapkan.
+func TestResizeAveragesArea(t *testing.T) {
+	checker := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if (x+y)%2 == 0 {
+				checker.Set(x, y, color.White)
+			} else {
+				checker.Set(x, y, color.Black)
+			}
+		}
+	}
+	dst := resize(checker, 4)
+	if dst.Bounds().Dx() != 4 || dst.Bounds().Dy() != 4 {
+		t.Fatalf("size %v", dst.Bounds())
+	}
+	if got := dst.NRGBAAt(1, 2); got.R < 126 || got.R > 128 || got.R != got.G || got.A != 255 {
+		t.Errorf("checkerboard averaged to %v, want mid gray", got)
+	}
+
+	// Setengah merah opak, setengah transparan: hasilnya merah dengan alpha
+	// setengah, bukan merah gelap.
+	half := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	half.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	half.Set(1, 0, color.NRGBA{})
+	if got := resize(half, 1).NRGBAAt(0, 0); got.R < 254 || got.G != 0 || got.A < 127 || got.A > 128 {
+		t.Errorf("transparent average = %v, want red at half alpha", got)
+	}
+}
+
+// Transparansi sumber PNG dipertahankan di thumbnail.
+func TestGenerateKeepsTransparency(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	thumb, _, err := Generate(buf.Bytes(), 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := png.Decode(bytes.NewReader(thumb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, a := decoded.At(200, 100).RGBA(); a != 0 {
+		t.Errorf("alpha %d, want transparent", a)
+	}
+}
+
+func TestGenerateRejectsInvalidImages(t *testing.T) {
+	// Header GIF dengan dimensi layar 65535 x 65535, tanpa data pixel.
+	huge := encode(t, "gif", 1, 1)
+	copy(huge[6:10], []byte{0xff, 0xff, 0xff, 0xff})
+	if _, _, err := Generate(huge, 400); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("huge image: %v, want ErrTooLarge", err)
+	}
+	if _, _, _, err := Info(huge); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Info of huge image: %v, want ErrTooLarge", err)
+	}
+
+	if _, _, err := Generate([]byte("not an image"), 400); err == nil {
+		t.Error("text accepted as an image")
+	}
+	truncated := encode(t, "png", 100, 100)
+	if _, _, err := Generate(truncated[:len(truncated)/2], 400); err == nil {
+		t.Error("truncated PNG accepted")
+	}
+}
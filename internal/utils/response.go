@@ -2,13 +2,29 @@ package utils
 
 import (
 	"net/http"
+	"service_components/internal/logging"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ErrorResponse adalah bentuk response Error dan ValidationError, untuk
+// dokumentasi.
 type ErrorResponse struct {
-	Status  string `json:"status" example:"error"`
-	Message string `json:"message" example:"Component not found"`
+	Success bool          `json:"success"`
+	Data    interface{}   `json:"data"`
+	Error   string        `json:"error"`
+	Details []ErrorDetail `json:"details,omitempty"`
+	// RequestID sama dengan header X-Request-ID, untuk mencari log request.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// ErrorDetail menjelaskan satu bagian request yang tidak valid.
+type ErrorDetail struct {
+	// In adalah lokasi input: path, query, header atau body.
+	In string `json:"in"`
+	// Field adalah nama parameter, atau JSON pointer untuk body.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
 }
 
 func Success(c *gin.Context, data interface{}) {
@@ -27,10 +43,35 @@ func Created(c *gin.Context, data interface{}) {
 	})
 }
 
+func Accepted(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    data,
+		"error":   nil,
+	})
+}
+
+// Error menulis response error. request_id disertakan jika request melewati
+// middleware RequestID.
 func Error(c *gin.Context, status int, message string) {
-	c.JSON(status, gin.H{
+	c.JSON(status, errorBody(c, message))
+}
+
+// ValidationError sama seperti Error dengan rincian per field di "details".
+func ValidationError(c *gin.Context, status int, message string, details []ErrorDetail) {
+	body := errorBody(c, message)
+	body["details"] = details
+	c.JSON(status, body)
+}
+
+func errorBody(c *gin.Context, message string) gin.H {
+	body := gin.H{
 		"success": false,
 		"data":    nil,
 		"error":   message,
-	})
+	}
+	if id := logging.RequestID(c.Request.Context()); id != "" {
+		body["request_id"] = id
+	}
+	return body
 }
@@ -0,0 +1,139 @@
+// Package webhook mengirim event katalog ke URL yang berlangganan. Event
+// diantrikan sebagai WebhookDelivery di database oleh dispatcher outbox, lalu
+// dikirim oleh worker dengan retry exponential backoff.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"service_components/internal/config"
+	"service_components/internal/model"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+
+	// AllEvents dipakai di daftar event untuk berlangganan semua event.
+	AllEvents = "*"
+
+	SecretPrefix = "whsec_"
+)
+
+var (
+	MaxAttempts  = 8
+	Timeout      = 10 * time.Second
+	DisableAfter = 20
+
+	httpClient = &http.Client{Timeout: Timeout}
+)
+
+func InitWebhook(cfg *config.Config) {
+	if cfg.WebhookMaxAttempts > 0 {
+		MaxAttempts = cfg.WebhookMaxAttempts
+	}
+	if cfg.WebhookTimeout > 0 {
+		Timeout = cfg.WebhookTimeout
+	}
+	if cfg.WebhookDisableAfter > 0 {
+		DisableAfter = cfg.WebhookDisableAfter
+	}
+	httpClient = &http.Client{
+		Timeout: Timeout,
+		// Redirect tidak diikuti supaya payload tidak terkirim ke host lain.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Payload adalah body JSON yang dikirim ke webhook.
+type Payload struct {
+	ID        uuid.UUID   `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Sign menghitung signature "sha256=<hex>" dari HMAC-SHA256 atas
+// "<timestamp>.<body>". Timestamp ikut ditandatangani supaya penerima bisa
+// menolak replay lama.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Subscribed melaporkan apakah daftar event mencakup event tersebut.
+func Subscribed(events []string, event string) bool {
+	return slices.Contains(events, AllEvents) || slices.Contains(events, event)
+}
+
+// ValidEvent melaporkan apakah event dikenal (atau "*").
+func ValidEvent(event string) bool {
+	return event == AllEvents || slices.Contains(model.WebhookEvents, event)
+}
+
+// Enqueue membuat delivery untuk setiap webhook aktif yang berlangganan event.
+// Payload.ID dipakai sebagai event ID, jadi event yang diantrikan ulang tetap
+// bisa dideduplikasi penerima.
+func Enqueue(db *gorm.DB, payload Payload) error {
+	var webhooks []model.Webhook
+	if err := db.Where("active = ?", true).Find(&webhooks).Error; err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []model.WebhookDelivery
+	for _, hook := range webhooks {
+		if !Subscribed(hook.Events, payload.Event) {
+			continue
+		}
+		deliveries = append(deliveries, model.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventID:       payload.ID,
+			Event:         payload.Event,
+			Payload:       body,
+			Status:        model.DeliveryPending,
+			NextAttemptAt: now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.Create(&deliveries).Error
+}
+
+// Replay mengantrikan ulang payload delivery sebagai delivery baru.
+func Replay(db *gorm.DB, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error) {
+	replay := model.WebhookDelivery{
+		WebhookID:     delivery.WebhookID,
+		EventID:       delivery.EventID,
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		Status:        model.DeliveryPending,
+		NextAttemptAt: time.Now().UTC(),
+	}
+	if err := db.Create(&replay).Error; err != nil {
+		return nil, err
+	}
+	return &replay, nil
+}
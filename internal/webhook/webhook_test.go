@@ -0,0 +1,475 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"service_components/internal/config"
+	"service_components/internal/model"
+	"service_components/internal/testdb"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"component.created"}`)
+
+	// Nilai golden dihitung di luar Go:
+	// printf '1700000000.{"event":"component.created"}' | openssl dgst -sha256 -hmac whsec_test
+	got := Sign("whsec_test", 1700000000, body)
+	if got != "sha256=12b84bce328add3e8a232e5e5fb8aa42e32c6ab07a8d5e56bb9bc002f33e9f21" {
+		t.Fatalf("Sign = %q", got)
+	}
+
+	if Sign("whsec_other", 1700000000, body) == got {
+		t.Error("signature tidak bergantung pada secret")
+	}
+	if Sign("whsec_test", 1700000001, body) == got {
+		t.Error("signature tidak bergantung pada timestamp")
+	}
+	if Sign("whsec_test", 1700000000, []byte(`{"event":"component.deleted"}`)) == got {
+		t.Error("signature tidak bergantung pada body")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{9, 128 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, 6 * time.Hour},
+		{100, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	tests := []struct {
+		events []string
+		event  string
+		want   bool
+	}{
+		{[]string{model.EventComponentCreated}, model.EventComponentCreated, true},
+		{[]string{model.EventComponentCreated}, model.EventComponentDeleted, false},
+		{[]string{AllEvents}, model.EventComponentDeleted, true},
+		{nil, model.EventComponentCreated, false},
+	}
+	for _, tt := range tests {
+		if got := Subscribed(tt.events, tt.event); got != tt.want {
+			t.Errorf("Subscribed(%v, %q) = %v, want %v", tt.events, tt.event, got, tt.want)
+		}
+	}
+}
+
+func TestDeliverSignsRequest(t *testing.T) {
+	db := openDB(t)
+	receiver := newReceiver(t)
+	hook := createHook(t, db, receiver.URL, AllEvents)
+	delivery := enqueueOne(t, db, model.EventComponentCreated)
+
+	if err := Deliver(context.Background(), db, delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	req := receiver.last(t)
+	if req.header.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q", req.header.Get("Content-Type"))
+	}
+	if got := req.header.Get(EventHeader); got != model.EventComponentCreated {
+		t.Errorf("%s = %q", EventHeader, got)
+	}
+	if got := req.header.Get(DeliveryHeader); got != delivery.ID.String() {
+		t.Errorf("%s = %q, want %s", DeliveryHeader, got, delivery.ID)
+	}
+
+	timestamp, err := strconv.ParseInt(req.header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatalf("%s: %v", TimestampHeader, err)
+	}
+	if age := time.Since(time.Unix(timestamp, 0)); age < -time.Minute || age > time.Minute {
+		t.Errorf("timestamp terlalu jauh dari sekarang: %v", age)
+	}
+	// Verifikasi seperti yang dilakukan penerima.
+	want := "sha256=" + hmacHex(hook.Secret, req.header.Get(TimestampHeader)+"."+string(req.body))
+	if got := req.header.Get(SignatureHeader); !hmac.Equal([]byte(got), []byte(want)) {
+		t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+	}
+	if string(req.body) != string(delivery.Payload) {
+		t.Errorf("body = %s, want %s", req.body, delivery.Payload)
+	}
+
+	got := reloadDelivery(t, db, delivery.ID)
+	if got.Status != model.DeliverySucceeded || got.Attempts != 1 || got.ResponseStatus != http.StatusOK || got.DeliveredAt == nil {
+		t.Errorf("delivery = %+v", got)
+	}
+	if got.ResponseBody != "ok" {
+		t.Errorf("response body = %q", got.ResponseBody)
+	}
+}
+
+func TestDeliverSchedulesRetry(t *testing.T) {
+	db := openDB(t)
+	receiver := newReceiver(t)
+	receiver.status = http.StatusServiceUnavailable
+	hook := createHook(t, db, receiver.URL, AllEvents)
+	delivery := enqueueOne(t, db, model.EventComponentUpdated)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		before := time.Now().UTC()
+		if err := Deliver(context.Background(), db, delivery); err != nil {
+			t.Fatal(err)
+		}
+		got := reloadDelivery(t, db, delivery.ID)
+		if got.Status != model.DeliveryPending || got.Attempts != attempt {
+			t.Fatalf("attempt %d: status %q attempts %d", attempt, got.Status, got.Attempts)
+		}
+		if got.ResponseStatus != http.StatusServiceUnavailable || got.Error != "receiver responded with status 503" {
+			t.Errorf("attempt %d: response %d error %q", attempt, got.ResponseStatus, got.Error)
+		}
+		delay := got.NextAttemptAt.Sub(before)
+		if want := backoff(attempt); delay < want || delay > want+5*time.Second {
+			t.Errorf("attempt %d: next attempt in %v, want %v", attempt, delay, want)
+		}
+	}
+
+	if got := reloadHook(t, db, hook.ID); got.FailureCount != 3 || got.LastError != "receiver responded with status 503" || !got.Active {
+		t.Errorf("hook = %+v", got)
+	}
+}
+
+func TestDeliverFailsAfterMaxAttempts(t *testing.T) {
+	setGlobal(t, &MaxAttempts, 2)
+	db := openDB(t)
+	receiver := newReceiver(t)
+	receiver.status = http.StatusInternalServerError
+	createHook(t, db, receiver.URL, AllEvents)
+	delivery := enqueueOne(t, db, model.EventComponentUpdated)
+
+	for range 2 {
+		if err := Deliver(context.Background(), db, delivery); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := reloadDelivery(t, db, delivery.ID); got.Status != model.DeliveryFailed || got.Attempts != 2 {
+		t.Errorf("status %q attempts %d, want failed after 2", got.Status, got.Attempts)
+	}
+}
+
+func TestDeliverSuccessResetsFailureCount(t *testing.T) {
+	db := openDB(t)
+	receiver := newReceiver(t)
+	hook := createHook(t, db, receiver.URL, AllEvents)
+	if err := db.Model(hook).Updates(map[string]interface{}{"failure_count": 5, "last_error": "timeout"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	delivery := enqueueOne(t, db, model.EventComponentUpdated)
+
+	if err := Deliver(context.Background(), db, delivery); err != nil {
+		t.Fatal(err)
+	}
+	if got := reloadHook(t, db, hook.ID); got.FailureCount != 0 || got.LastError != "" {
+		t.Errorf("failure_count %d last_error %q, want reset", got.FailureCount, got.LastError)
+	}
+}
+
+func TestDeliverDisablesWebhook(t *testing.T) {
+	setGlobal(t, &DisableAfter, 3)
+	db := openDB(t)
+	receiver := newReceiver(t)
+	receiver.status = http.StatusBadGateway
+	hook := createHook(t, db, receiver.URL, AllEvents)
+
+	for i := 1; i <= 3; i++ {
+		delivery := enqueueOne(t, db, model.EventComponentUpdated)
+		if err := Deliver(context.Background(), db, delivery); err != nil {
+			t.Fatal(err)
+		}
+		got := reloadHook(t, db, hook.ID)
+		if i < 3 && (!got.Active || got.DisabledAt != nil) {
+			t.Fatalf("webhook dinonaktifkan setelah %d kegagalan", i)
+		}
+		if i == 3 && (got.Active || got.DisabledAt == nil || got.FailureCount != 3) {
+			t.Fatalf("webhook belum dinonaktifkan: %+v", got)
+		}
+	}
+
+	// Delivery yang masih antri untuk webhook nonaktif gagal tanpa dikirim.
+	sent := receiver.count()
+	delivery := model.WebhookDelivery{
+		WebhookID:     hook.ID,
+		EventID:       uuid.New(),
+		Event:         model.EventComponentDeleted,
+		Payload:       []byte(`{}`),
+		NextAttemptAt: time.Now().UTC(),
+	}
+	if err := db.Create(&delivery).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := Deliver(context.Background(), db, &delivery); err != nil {
+		t.Fatal(err)
+	}
+	if receiver.count() != sent {
+		t.Error("payload terkirim ke webhook nonaktif")
+	}
+	if got := reloadDelivery(t, db, delivery.ID); got.Status != model.DeliveryFailed || got.Error != "webhook is disabled" {
+		t.Errorf("status %q error %q", got.Status, got.Error)
+	}
+
+	// Webhook nonaktif tidak menerima event baru.
+	payload := Payload{ID: uuid.New(), Event: model.EventComponentCreated}
+	if err := Enqueue(db, payload); err != nil {
+		t.Fatal(err)
+	}
+	var queued int64
+	db.Model(&model.WebhookDelivery{}).Where("event_id = ?", payload.ID).Count(&queued)
+	if queued != 0 {
+		t.Errorf("%d delivery baru untuk webhook nonaktif", queued)
+	}
+}
+
+func TestEnqueueFiltersSubscriptions(t *testing.T) {
+	db := openDB(t)
+	created := createHook(t, db, "http://created.invalid", model.EventComponentCreated)
+	all := createHook(t, db, "http://all.invalid", AllEvents)
+	createHook(t, db, "http://deleted.invalid", model.EventComponentDeleted)
+
+	payload := Payload{ID: uuid.New(), Event: model.EventComponentCreated, CreatedAt: time.Now().UTC(), Data: map[string]string{"slug": "button"}}
+	if err := Enqueue(db, payload); err != nil {
+		t.Fatal(err)
+	}
+
+	var deliveries []model.WebhookDelivery
+	if err := db.Find(&deliveries).Error; err != nil {
+		t.Fatal(err)
+	}
+	targets := map[uuid.UUID]bool{}
+	for _, d := range deliveries {
+		targets[d.WebhookID] = true
+		if d.EventID != payload.ID || d.Event != payload.Event || d.Status != model.DeliveryPending {
+			t.Errorf("delivery = %+v", d)
+		}
+		var decoded Payload
+		if err := json.Unmarshal(d.Payload, &decoded); err != nil || decoded.ID != payload.ID {
+			t.Errorf("payload %s: %v", d.Payload, err)
+		}
+	}
+	if len(deliveries) != 2 || !targets[created.ID] || !targets[all.ID] {
+		t.Errorf("delivery untuk %v, want webhook %s dan %s", targets, created.ID, all.ID)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	db := openDB(t)
+	receiver := newReceiver(t)
+	createHook(t, db, receiver.URL, AllEvents)
+	original := enqueueOne(t, db, model.EventComponentPublished)
+	if err := Deliver(context.Background(), db, original); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := Replay(db, original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.ID == original.ID {
+		t.Fatal("replay memakai ID delivery yang sama")
+	}
+	if replay.EventID != original.EventID || replay.WebhookID != original.WebhookID || string(replay.Payload) != string(original.Payload) {
+		t.Errorf("replay = %+v, original = %+v", replay, original)
+	}
+	if replay.Status != model.DeliveryPending || replay.Attempts != 0 {
+		t.Errorf("status %q attempts %d", replay.Status, replay.Attempts)
+	}
+
+	if err := Deliver(context.Background(), db, replay); err != nil {
+		t.Fatal(err)
+	}
+	first, second := receiver.requests[0], receiver.requests[1]
+	if first.header.Get(DeliveryHeader) == second.header.Get(DeliveryHeader) {
+		t.Error("replay memakai delivery ID yang sama")
+	}
+	if string(first.body) != string(second.body) {
+		t.Errorf("body replay %s, want %s", second.body, first.body)
+	}
+	if got := reloadDelivery(t, db, original.ID); got.Status != model.DeliverySucceeded || got.Attempts != 1 {
+		t.Errorf("delivery asli berubah: %+v", got)
+	}
+}
+
+func TestDeliverDue(t *testing.T) {
+	db := openDB(t)
+	receiver := newReceiver(t)
+	createHook(t, db, receiver.URL, AllEvents)
+	due := enqueueOne(t, db, model.EventComponentCreated)
+	later := enqueueOne(t, db, model.EventComponentUpdated)
+	if err := db.Model(later).Update("next_attempt_at", time.Now().UTC().Add(time.Hour)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := deliverDue(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+	if receiver.count() != 1 {
+		t.Fatalf("%d request, want 1", receiver.count())
+	}
+	if got := reloadDelivery(t, db, due.ID); got.Status != model.DeliverySucceeded {
+		t.Errorf("delivery jatuh tempo: status %q", got.Status)
+	}
+	if got := reloadDelivery(t, db, later.ID); got.Status != model.DeliveryPending || got.Attempts != 0 {
+		t.Errorf("delivery belum jatuh tempo ikut dikirim: %+v", got)
+	}
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	client := httpClient
+	t.Cleanup(func() { httpClient = client })
+	InitWebhook(&config.Config{})
+
+	var followed bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { followed = true }))
+	t.Cleanup(target.Close)
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	t.Cleanup(redirect.Close)
+
+	db := openDB(t)
+	createHook(t, db, redirect.URL, AllEvents)
+	delivery := enqueueOne(t, db, model.EventComponentCreated)
+	if err := Deliver(context.Background(), db, delivery); err != nil {
+		t.Fatal(err)
+	}
+	if followed {
+		t.Error("redirect diikuti")
+	}
+	if got := reloadDelivery(t, db, delivery.ID); got.Status != model.DeliveryPending || got.ResponseStatus != http.StatusTemporaryRedirect {
+		t.Errorf("status %q response %d, want retry setelah 307", got.Status, got.ResponseStatus)
+	}
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver adalah endpoint webhook palsu yang mencatat setiap request.
+type receiver struct {
+	*httptest.Server
+	status int
+
+	mu       sync.Mutex
+	requests []receivedRequest
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{status: http.StatusOK}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		r.mu.Unlock()
+		w.WriteHeader(r.status)
+		io.WriteString(w, "ok")
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func (r *receiver) last(t *testing.T) receivedRequest {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.requests) == 0 {
+		t.Fatal("receiver tidak menerima request")
+	}
+	return r.requests[len(r.requests)-1]
+}
+
+func openDB(t *testing.T) *gorm.DB {
+	return testdb.Open(t, &model.Webhook{}, &model.WebhookDelivery{})
+}
+
+func createHook(t *testing.T, db *gorm.DB, url string, events ...string) *model.Webhook {
+	t.Helper()
+	hook := &model.Webhook{
+		URL:       url,
+		Events:    events,
+		Secret:    SecretPrefix + uuid.NewString(),
+		Active:    true,
+		CreatedBy: uuid.New(),
+	}
+	if err := db.Create(hook).Error; err != nil {
+		t.Fatal(err)
+	}
+	return hook
+}
+
+// enqueueOne mengantrikan event baru dan mengembalikan delivery-nya. Hanya
+// dipakai saat tepat satu webhook aktif berlangganan event tersebut.
+func enqueueOne(t *testing.T, db *gorm.DB, event string) *model.WebhookDelivery {
+	t.Helper()
+	payload := Payload{ID: uuid.New(), Event: event, CreatedAt: time.Now().UTC(), Data: map[string]string{"slug": "button"}}
+	if err := Enqueue(db, payload); err != nil {
+		t.Fatal(err)
+	}
+	var delivery model.WebhookDelivery
+	if err := db.Where("event_id = ?", payload.ID).First(&delivery).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &delivery
+}
+
+func reloadDelivery(t *testing.T, db *gorm.DB, id uuid.UUID) model.WebhookDelivery {
+	t.Helper()
+	var delivery model.WebhookDelivery
+	if err := db.Where("id = ?", id).First(&delivery).Error; err != nil {
+		t.Fatal(err)
+	}
+	return delivery
+}
+
+func reloadHook(t *testing.T, db *gorm.DB, id uuid.UUID) model.Webhook {
+	t.Helper()
+	var hook model.Webhook
+	if err := db.Where("id = ?", id).First(&hook).Error; err != nil {
+		t.Fatal(err)
+	}
+	return hook
+}
+
+// setGlobal mengganti variabel paket selama satu test.
+func setGlobal(t *testing.T, v *int, value int) {
+	old := *v
+	t.Cleanup(func() { *v = old })
+	*v = value
+}
+
+func hmacHex(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"service_components/internal/model"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	pollInterval = 2 * time.Second
+	batchSize    = 20
+
+	// Backoff retry: 30 detik, lalu dua kali lipat tiap percobaan, maksimal
+	// 6 jam.
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+
+	maxResponseBody = 1 << 10
+)
+
+// Start menjalankan worker pengiriman sampai ctx selesai. Beberapa instance
+// boleh berjalan bersamaan; setiap delivery diklaim dulu sebelum dikirim.
+func Start(ctx context.Context, db *gorm.DB) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := deliverDue(ctx, db); err != nil {
+				slog.ErrorContext(ctx, "webhook worker failed", "error", err)
+			}
+		}
+	}
+}
+
+func backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+func deliverDue(ctx context.Context, db *gorm.DB) error {
+	var due []model.WebhookDelivery
+	err := db.Where("status = ? AND next_attempt_at <= ?", model.DeliveryPending, time.Now().UTC()).
+		Order("next_attempt_at asc").Limit(batchSize).Find(&due).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range due {
+		delivery := &due[i]
+		// Klaim dengan memajukan next_attempt_at; instance lain yang membaca
+		// baris yang sama akan gagal di kondisi WHERE.
+		lease := time.Now().UTC().Add(2 * Timeout)
+		result := db.Model(&model.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, model.DeliveryPending, delivery.NextAttemptAt).
+			Update("next_attempt_at", lease)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		if err := Deliver(ctx, db, delivery); err != nil {
+			slog.ErrorContext(ctx, "webhook delivery failed", "delivery_id", delivery.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// Deliver mengirim satu delivery lalu menyimpan hasilnya: sukses, dijadwalkan
+// ulang, atau gagal permanen setelah MaxAttempts. Webhook dinonaktifkan
+// setelah DisableAfter kegagalan berturut-turut.
+func Deliver(ctx context.Context, db *gorm.DB, delivery *model.WebhookDelivery) error {
+	var hook model.Webhook
+	if err := db.Where("id = ?", delivery.WebhookID).First(&hook).Error; err != nil {
+		return err
+	}
+	if !hook.Active {
+		return db.Model(delivery).Updates(map[string]interface{}{
+			"status": model.DeliveryFailed,
+			"error":  "webhook is disabled",
+		}).Error
+	}
+
+	status, body, sendErr := send(ctx, &hook, delivery)
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+
+	if sendErr == nil {
+		delivery.Status = model.DeliverySucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+		if err := db.Save(delivery).Error; err != nil {
+			return err
+		}
+		return db.Model(&hook).Updates(map[string]interface{}{"failure_count": 0, "last_error": ""}).Error
+	}
+
+	delivery.Error = sendErr.Error()
+	if delivery.Attempts >= MaxAttempts {
+		delivery.Status = model.DeliveryFailed
+	} else {
+		delivery.NextAttemptAt = now.Add(backoff(delivery.Attempts))
+	}
+	if err := db.Save(delivery).Error; err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"failure_count": gorm.Expr("failure_count + 1"),
+		"last_error":    delivery.Error,
+	}
+	if hook.FailureCount+1 >= DisableAfter {
+		updates["active"] = false
+		updates["disabled_at"] = now
+		slog.Warn("webhook disabled after consecutive failures", "webhook_id", hook.ID, "failures", hook.FailureCount+1)
+	}
+	return db.Model(&hook).Updates(updates).Error
+}
+
+// send mengirim payload dan mengembalikan status serta awal body response.
+// Hanya status 2xx yang dianggap sukses.
+func send(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) (int, string, error) {
+	timestamp := time.Now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ComponentHub-Webhook/1.0")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, timestamp, delivery.Payload))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	// Body disimpan di kolom text, jadi harus UTF-8 valid tanpa NUL.
+	body := strings.ReplaceAll(strings.ToValidUTF8(string(raw), ""), "\x00", "")
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, body, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, body, nil
+}